@@ -0,0 +1,172 @@
+package network
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// How often to check if the watchtower has finished generating the tree
+const roundTripPollInterval = 30 * time.Second
+
+func testClaimRoundTrip(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get config
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if cfg.Smartnode.Network.Value.(cfgtypes.Network) == cfgtypes.Network_Mainnet {
+		return fmt.Errorf("The claim round-trip test is only available on test networks.")
+	}
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the index
+	var index uint64
+	if c.IsSet("index") {
+		index = c.Uint64("index")
+	} else {
+		indexString := cliutils.Prompt("Which interval would you like to test the claim round-trip for?", "^\\d+$", "Invalid interval. Please provide a number.")
+		index, err = strconv.ParseUint(indexString, 0, 64)
+		if err != nil {
+			return fmt.Errorf("'%s' is not a valid interval: %w.\n", indexString, err)
+		}
+	}
+
+	status, err := rp.GetClaimRoundTripStatus(index)
+	if err != nil {
+		return err
+	}
+	if status.CurrentIndex <= index {
+		return fmt.Errorf("The current active rewards period is interval %d. You cannot test the round-trip for interval %d until the active interval is past it.", status.CurrentIndex, index)
+	}
+
+	// Step 1: the tree
+	fmt.Printf("%s== Step 1: Rewards tree ==%s\n", colorGreen, colorReset)
+	if !status.TreeFileExists {
+		fmt.Printf("The rewards tree for interval %d doesn't exist on this machine yet, so the watchtower will generate it.\n", index)
+		_, err = rp.GenerateRewardsTree(index, 0, "", false, nil, false, 0)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Generation has been requested; waiting for the watchtower to finish (this can take a while). Press Ctrl+C to stop waiting, and run this command again later.\nYou can follow its progress with %s`rocketpool service logs watchtower`%s.\n", colorGreen, colorReset)
+		for !status.TreeFileExists {
+			time.Sleep(roundTripPollInterval)
+			status, err = rp.GetClaimRoundTripStatus(index)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	fmt.Printf("Tree file: %s\n", status.TreeFilePath)
+	if !status.MerkleRootValid {
+		printRoundTripResult(false, "The tree's Merkle root does not match the canonical root for this interval.")
+		return nil
+	}
+	printRoundTripResult(true, "The tree's Merkle root matches the canonical root for this interval.")
+	fmt.Println()
+
+	// Step 2: the root submission
+	fmt.Printf("%s== Step 2: Root submission ==%s\n", colorGreen, colorReset)
+	if !status.IsTrusted {
+		fmt.Println("This node is not an Oracle DAO member, so it doesn't submit roots. Skipping.")
+	} else if status.HasSubmittedRoot {
+		printRoundTripResult(true, "This node submitted the root for this interval.")
+	} else {
+		printRoundTripResult(false, "This node is an Oracle DAO member but did not submit the root for this interval. Check the watchtower logs around the end of the interval.")
+	}
+	fmt.Println()
+
+	// Step 3: the proof
+	fmt.Printf("%s== Step 3: Node proof ==%s\n", colorGreen, colorReset)
+	if !status.NodeExists {
+		fmt.Printf("Node %s has no rewards in interval %d, so there is nothing to claim.\n", status.NodeAddress.Hex(), index)
+		return nil
+	}
+	fmt.Printf("Rewards: %.6f collateral RPL, %.6f Oracle DAO RPL, %.6f smoothing pool ETH\n", eth.WeiToEth(status.CollateralRpl), eth.WeiToEth(status.OracleDaoRpl), eth.WeiToEth(status.SmoothingPoolEth))
+	if !status.ProofValid {
+		printRoundTripResult(false, "The node's Merkle proof does not verify against the interval's root; a claim would be rejected.")
+		return nil
+	}
+	printRoundTripResult(true, "The node's Merkle proof verifies against the interval's root.")
+	fmt.Println()
+
+	// Step 4: the claim
+	fmt.Printf("%s== Step 4: Claim ==%s\n", colorGreen, colorReset)
+	if status.IsClaimed {
+		printRoundTripResult(true, "The node has already claimed its rewards for this interval.")
+		return nil
+	}
+	indices := []uint64{index}
+	canClaim, err := rp.CanNodeClaimRewards(indices)
+	if err != nil {
+		printRoundTripResult(false, fmt.Sprintf("The claim would fail: %s", err.Error()))
+		return nil
+	}
+	err = gas.AssignMaxFeeAndLimit(canClaim.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to claim your rewards for interval %d?", index))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	response, err := rp.NodeClaimRewards(indices)
+	if err != nil {
+		printRoundTripResult(false, fmt.Sprintf("The claim transaction could not be submitted: %s", err.Error()))
+		return nil
+	}
+	fmt.Printf("Claiming Rewards...\n")
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		printRoundTripResult(false, fmt.Sprintf("The claim transaction failed: %s", err.Error()))
+		return nil
+	}
+
+	// Make sure the claim was recorded
+	status, err = rp.GetClaimRoundTripStatus(index)
+	if err != nil {
+		return err
+	}
+	printRoundTripResult(status.IsClaimed, describeClaimResult(status))
+	return nil
+
+}
+
+// Print the result of a round-trip step
+func printRoundTripResult(success bool, message string) {
+	if success {
+		fmt.Printf("%sPASS:%s %s\n", colorGreen, colorReset, message)
+	} else {
+		fmt.Printf("%sFAIL:%s %s\n", colorRed, colorReset, message)
+	}
+}
+
+// Describe whether the claim was recorded on chain
+func describeClaimResult(status api.NetworkClaimRoundTripStatusResponse) string {
+	if status.IsClaimed {
+		return fmt.Sprintf("Interval %d is now marked as claimed for node %s. The full round-trip succeeded.", status.Index, status.NodeAddress.Hex())
+	}
+	return fmt.Sprintf("The claim transaction succeeded but interval %d is still not marked as claimed for node %s.", status.Index, status.NodeAddress.Hex())
+}
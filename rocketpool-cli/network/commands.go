@@ -68,6 +68,23 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "reward-interval-calendar",
+				Usage:     "Show when the current rewards interval started and ends, and when the next one is projected to end",
+				UsageText: "rocketpool network reward-interval-calendar",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getRewardIntervalCalendar(c)
+
+				},
+			},
+
 			{
 				Name:      "rpl-price",
 				Aliases:   []string{"p"},
@@ -104,6 +121,34 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 						Name:  "yes, y",
 						Usage: "Automatically confirm any questions about tree generation",
 					},
+					cli.BoolFlag{
+						Name:  "dry-run, d",
+						Usage: "Generate the tree and compare its root against the canonical one, but don't save the rewards file",
+					},
+					cli.Uint64Flag{
+						Name:  "threads, t",
+						Usage: "The number of threads to use for the per-node rewards calculations (defaults to the Smartnode setting)",
+					},
+					cli.StringFlag{
+						Name:  "output-dir, o",
+						Usage: "Save the generated files to this directory instead of your rewards trees folder, so the existing files aren't overwritten. In Docker mode, it must be inside your Smartnode data directory.",
+					},
+					cli.BoolFlag{
+						Name:  "proofs-only, p",
+						Usage: "Rebuild the Merkle proofs and root from the reward amounts in your existing tree file instead of recalculating the rewards. Use this to quickly repair a tree file whose proofs were damaged.",
+					},
+					cli.StringFlag{
+						Name:  "node-address, n",
+						Usage: "Only calculate the rewards for this node and print them in the watchtower logs, without building the Merkle tree or saving any files. This is much faster than a full generation when you only want to check one node's payout.",
+					},
+					cli.BoolFlag{
+						Name:  "force, f",
+						Usage: "Regenerate the tree even if your existing rewards file already matches the canonical Merkle root. Without this, a known-good file is never replaced.",
+					},
+					cli.Uint64Flag{
+						Name:  "el-block, b",
+						Usage: "Generate the tree against this EL block instead of the one from the interval's snapshot event. The result is still checked against the canonical root, which helps tell whether a mismatch comes from block selection or the reward calculation. Combine it with --dry-run to avoid saving the result.",
+					},
 				},
 				Action: func(c *cli.Context) error {
 
@@ -118,6 +163,172 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "verify-rewards-tree",
+				Aliases:   []string{"v"},
+				Usage:     "Generate the rewards tree for the provided interval twice and check that both runs produce byte-identical files.\nLike tree generation, this is an asynchronous process; use `rocketpool service logs watchtower` to follow its progress.",
+				UsageText: "rocketpool network verify-rewards-tree [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "index",
+						Usage: "The index of the rewards interval you want to verify the tree for",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm any questions about tree verification",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return verifyRewardsTree(c)
+
+				},
+			},
+
+			{
+				Name:      "test-claim-round-trip",
+				Aliases:   []string{"r"},
+				Usage:     "Test networks only: check every step of the rewards pipeline for an interval, from generating the tree and submitting its root through verifying the node's proof and claiming its rewards",
+				UsageText: "rocketpool network test-claim-round-trip [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "index",
+						Usage: "The index of the rewards interval you want to test",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the claim",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return testClaimRoundTrip(c)
+
+				},
+			},
+
+			{
+				Name:      "estimate-tree-memory",
+				Aliases:   []string{"m"},
+				Usage:     "Estimate the peak memory needed to generate a rewards tree for a network of the given size",
+				UsageText: "rocketpool network estimate-tree-memory [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "nodes, n",
+						Usage: "The number of nodes to estimate for (ignore this flag to use the current network size)",
+					},
+					cli.Uint64Flag{
+						Name:  "minipools, m",
+						Usage: "The number of staking minipools to estimate for",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return estimateTreeMemory(c)
+
+				},
+			},
+
+			{
+				Name:      "watchtower-files",
+				Aliases:   []string{"w"},
+				Usage:     "List the watchtower's request and state files, along with their ages and purposes",
+				UsageText: "rocketpool network watchtower-files [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "clean, c",
+						Usage: "Remove stale files (such as requests for intervals that have already been generated); active state is never removed",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getWatchtowerFiles(c)
+
+				},
+			},
+
+			{
+				Name:      "local-rewards-trees",
+				Aliases:   []string{"l"},
+				Usage:     "List the rewards tree files you have on disk and check whether each one matches the canonical Merkle root for its interval",
+				UsageText: "rocketpool network local-rewards-trees",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getLocalRewardsTrees(c)
+
+				},
+			},
+
+			{
+				Name:      "diff-rewards-trees",
+				Aliases:   []string{"c"},
+				Usage:     "Compare two rewards tree files and show how each node's rewards differ between them. Node order doesn't matter, and compressed files are supported.",
+				UsageText: "rocketpool network diff-rewards-trees first-file second-file",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+
+					// Run
+					return diffRewardsTrees(c, c.Args().Get(0), c.Args().Get(1))
+
+				},
+			},
+
+			{
+				Name:      "verify-merkle-proof",
+				Aliases:   []string{"o"},
+				Usage:     "Check that a node's Merkle proof in a rewards tree file leads to the file's Merkle root, so the proof can be trusted before claiming. Compressed files are supported.",
+				UsageText: "rocketpool network verify-merkle-proof tree-file node-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					nodeAddress, err := cliutils.ValidateAddress("node address", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return verifyMerkleProof(c, c.Args().Get(0), nodeAddress)
+
+				},
+			},
+
 			{
 				Name:      "dao-proposals",
 				Aliases:   []string{"d"},
@@ -0,0 +1,61 @@
+package network
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+)
+
+func diffRewardsTrees(c *cli.Context, firstPath string, secondPath string) error {
+
+	// Load the files
+	first, err := rprewards.LoadRewardsFile(firstPath)
+	if err != nil {
+		return err
+	}
+	second, err := rprewards.LoadRewardsFile(secondPath)
+	if err != nil {
+		return err
+	}
+
+	// Print an overview of each file
+	fmt.Printf("First:  %s (interval %d on %s, %d nodes, root %s)\n", firstPath, first.Index, first.Network, len(first.NodeRewards), first.MerkleRoot)
+	fmt.Printf("Second: %s (interval %d on %s, %d nodes, root %s)\n\n", secondPath, second.Index, second.Network, len(second.NodeRewards), second.MerkleRoot)
+	if first.Index != second.Index || first.Network != second.Network {
+		fmt.Printf("%sWARNING: these files are for different intervals or networks, so they aren't expected to match.%s\n\n", colorYellow, colorReset)
+	}
+	if first.MerkleRoot == second.MerkleRoot {
+		fmt.Printf("%sThe Merkle roots match.%s\n", colorGreen, colorReset)
+	} else {
+		fmt.Printf("%sThe Merkle roots are different.%s\n", colorRed, colorReset)
+	}
+
+	// Print the nodes that differ
+	differences := rprewards.DiffRewardsFiles(first, second)
+	if len(differences) == 0 {
+		fmt.Println("Every node's rewards are identical.")
+		return nil
+	}
+	fmt.Printf("%d node(s) have different rewards (amounts are second minus first):\n\n", len(differences))
+	for _, difference := range differences {
+		fmt.Printf("%s%s%s: %s\n", colorYellow, difference.Address.Hex(), colorReset, difference.Description)
+		printRewardsDelta("Collateral RPL", difference.CollateralRplDelta)
+		printRewardsDelta("Oracle DAO RPL", difference.OracleDaoRplDelta)
+		printRewardsDelta("Smoothing pool ETH", difference.SmoothingPoolEthDelta)
+		fmt.Println()
+	}
+	return nil
+
+}
+
+// Print the change in one kind of reward, if there was any
+func printRewardsDelta(name string, delta *big.Int) {
+	if delta.Sign() == 0 {
+		return
+	}
+	fmt.Printf("\t%-19s %+.6f (%s wei)\n", name+":", eth.WeiToEth(delta), delta.String())
+}
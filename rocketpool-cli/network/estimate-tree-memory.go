@@ -0,0 +1,51 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize"
+	"github.com/urfave/cli"
+
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func estimateTreeMemory(c *cli.Context) error {
+
+	nodeCount := c.Uint64("nodes")
+	minipoolCount := c.Uint64("minipools")
+
+	// Use the current network size if the counts weren't provided
+	if nodeCount == 0 {
+		rp, err := rocketpool.NewClientFromCtx(c)
+		if err != nil {
+			return err
+		}
+		defer rp.Close()
+
+		stats, err := rp.NetworkStats()
+		if err != nil {
+			return err
+		}
+		nodeCount = stats.NodeCount
+		if minipoolCount == 0 {
+			minipoolCount = stats.StakingMinipoolCount
+		}
+		fmt.Printf("Using the current network size of %d nodes and %d staking minipools.\n\n", nodeCount, minipoolCount)
+	} else if minipoolCount == 0 {
+		// Assume a typical number of minipools per node
+		minipoolCount = nodeCount * 5
+		fmt.Printf("No minipool count provided, assuming 5 minipools per node (%d total).\n\n", minipoolCount)
+	}
+
+	// Print the estimate
+	estimate := rprewards.EstimateTreeGenerationMemory(nodeCount, minipoolCount)
+	fmt.Printf("RPL rewards calculation: %s\n", humanize.IBytes(estimate.RplCalculationBytes))
+	fmt.Printf("Merkle tree generation:  %s\n", humanize.IBytes(estimate.MerkleTreeBytes))
+	fmt.Printf("JSON serialization:      %s\n", humanize.IBytes(estimate.SerializationBytes))
+	fmt.Printf("%sEstimated peak memory:   %s%s\n\n", colorGreen, humanize.IBytes(estimate.PeakBytes), colorReset)
+	fmt.Println("Note that this is an estimate of the tree generator's own data structures; the Go runtime, your clients, and the rest of the Smartnode will need memory on top of this.")
+
+	return nil
+
+}
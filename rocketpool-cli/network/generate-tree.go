@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/urfave/cli"
@@ -11,6 +12,7 @@ import (
 
 const (
 	colorReset  string = "\033[0m"
+	colorRed    string = "\033[31m"
 	colorGreen  string = "\033[32m"
 	colorYellow string = "\033[33m"
 )
@@ -59,22 +61,91 @@ func generateRewardsTree(c *cli.Context) error {
 		return fmt.Errorf("The current active rewards period is interval %d. You cannot generate the tree for interval %d until the active interval is past it.", canResponse.CurrentIndex, index)
 	}
 
+	// Dry runs don't write anything, so there's nothing to overwrite
+	dryRun := c.Bool("dry-run")
+
+	// Rebuilding the proofs works from the existing tree file and replaces it
+	proofsOnly := c.Bool("proofs-only")
+	if proofsOnly {
+		if dryRun {
+			return fmt.Errorf("--proofs-only can't be used with --dry-run.")
+		}
+		if !canResponse.TreeFileExists {
+			return fmt.Errorf("You don't have a rewards file for interval %d, so there are no amounts to rebuild the proofs from. Generate the full tree instead.", index)
+		}
+	}
+
+	// Single-node runs only report that node's rewards
+	var nodeAddress *common.Address
+	if c.String("node-address") != "" {
+		if dryRun || proofsOnly {
+			return fmt.Errorf("--node-address can't be used with --dry-run or --proofs-only.")
+		}
+		if c.String("output-dir") != "" {
+			return fmt.Errorf("Single-node runs don't save any files, so --output-dir can't be used with --node-address.")
+		}
+		address, err := cliutils.ValidateAddress("node address", c.String("node-address"))
+		if err != nil {
+			return err
+		}
+		nodeAddress = &address
+	}
+
+	// Get the output directory as the watchtower sees it
+	outputDir := ""
+	if c.String("output-dir") != "" {
+		if dryRun {
+			return fmt.Errorf("Dry runs don't save any files, so --output-dir can't be used with --dry-run.")
+		}
+		outputDir, err = cfg.Smartnode.GetDaemonPath(c.String("output-dir"))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Pinning the EL block only matters when the rewards are recalculated
+	elBlock := c.Uint64("el-block")
+	if elBlock > 0 && proofsOnly {
+		return fmt.Errorf("--el-block can't be used with --proofs-only, since the proofs are rebuilt from your existing tree file's amounts.")
+	}
+
+	// Forcing only matters when the existing file would be replaced
+	force := c.Bool("force")
+	if force && (dryRun || proofsOnly || nodeAddress != nil || outputDir != "") {
+		return fmt.Errorf("--force only applies when regenerating over your existing rewards file, so it can't be used with --dry-run, --proofs-only, --node-address, or --output-dir.")
+	}
+
 	// Confirm file overwrite
-	if canResponse.TreeFileExists {
-		if c.Bool("yes") {
+	if canResponse.TreeFileExists && !dryRun && !proofsOnly && nodeAddress == nil && outputDir == "" {
+		if !force {
+			fmt.Println("You already have a rewards file for this interval. It will only be replaced if it doesn't match the canonical Merkle root; use --force to regenerate it regardless.")
+		} else if c.Bool("yes") {
 			fmt.Println("Overwriting existing rewards file.")
-		} else if !cliutils.Confirm("You already have a rewards file for this interval. Would you like to overwrite it?") {
+		} else if !cliutils.Confirm("You already have a rewards file for this interval. Would you like to overwrite it, even if it matches the canonical Merkle root?") {
 			fmt.Println("Cancelled.")
 			return nil
 		}
 	}
 
 	// Create the generation request
-	_, err = rp.GenerateRewardsTree(index)
+	if dryRun {
+		_, err = rp.DryRunRewardsTree(index, c.Uint64("threads"), elBlock)
+	} else {
+		_, err = rp.GenerateRewardsTree(index, c.Uint64("threads"), outputDir, proofsOnly, nodeAddress, force, elBlock)
+	}
 	if err != nil {
 		return err
 	}
 
+	if dryRun {
+		fmt.Println("This is a dry run, so the tree's root will be checked against the canonical one but the rewards file won't be saved.")
+	}
+	if elBlock > 0 {
+		fmt.Printf("The tree will be generated against EL block %d instead of the one from the interval's snapshot event.\n", elBlock)
+	}
+	if nodeAddress != nil {
+		fmt.Printf("Only the rewards for node %s will be calculated; they'll be printed in the watchtower logs and no files will be saved.\n", nodeAddress.Hex())
+	}
 	fmt.Printf("Your request to generate the rewards tree for interval %d has been applied, and your `watchtower` container will begin the process during its next duty check (typically 5 minutes).\nYou can follow its progress with %s`rocketpool service logs watchtower`%s.\n\n", index, colorGreen, colorReset)
 
 	if c.Bool("yes") || cliutils.Confirm("Would you like to restart the watchtower container now, so it starts generating the file immediately?") {
@@ -0,0 +1,58 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func getLocalRewardsTrees(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the trees
+	response, err := rp.GetLocalRewardsTrees()
+	if err != nil {
+		return err
+	}
+	if len(response.Trees) == 0 {
+		fmt.Println("You don't have any rewards tree files.")
+		return nil
+	}
+
+	// Print them
+	mismatched := 0
+	fmt.Printf("%-10s%-68s%-68s%s\n", "Interval", "File Root", "Canonical Root", "Status")
+	for _, tree := range response.Trees {
+		var status string
+		switch {
+		case tree.Error != "":
+			mismatched++
+			status = fmt.Sprintf("%sERROR: %s%s", colorRed, tree.Error, colorReset)
+		case !tree.Finalized:
+			status = fmt.Sprintf("%snot finalized yet%s", colorYellow, colorReset)
+		case tree.Matches:
+			status = fmt.Sprintf("%smatch%s", colorGreen, colorReset)
+		default:
+			mismatched++
+			status = fmt.Sprintf("%sMISMATCH%s", colorRed, colorReset)
+		}
+		fmt.Printf("%-10d%-68s%-68s%s\n", tree.Index, tree.FileRoot, tree.CanonicalRoot, status)
+	}
+	fmt.Println()
+
+	if mismatched > 0 {
+		fmt.Printf("%d tree file(s) are stale or corrupt. You can regenerate them with `rocketpool network generate-rewards-tree`.\n", mismatched)
+	} else {
+		fmt.Println("All of your finalized tree files match the canonical roots.")
+	}
+	return nil
+
+}
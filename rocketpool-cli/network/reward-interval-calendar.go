@@ -0,0 +1,61 @@
+package network
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getRewardIntervalCalendar(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the calendar
+	response, err := rp.RewardIntervalCalendar()
+	if err != nil {
+		return err
+	}
+
+	// Print & return
+	intervalEndString := cliutils.GetDateTimeString(uint64(response.IntervalEnd.Unix()))
+	nextIntervalEndString := cliutils.GetDateTimeString(uint64(response.NextIntervalEnd.Unix()))
+	fmt.Printf("The current rewards interval is %d.\n", response.CurrentIndex)
+	fmt.Printf("It started on %s.\n", cliutils.GetDateTimeString(uint64(response.IntervalStart.Unix())))
+	if response.IntervalsPassed == 0 {
+		timeToEnd := response.IntervalEnd.Sub(response.LatestBlockTime).Round(time.Second)
+		fmt.Printf("It will end on %s (%s from now).\n", intervalEndString, timeToEnd)
+	} else {
+		fmt.Printf("It ended on %s; its rewards tree will be available once the Oracle DAO has generated and submitted it.\n", intervalEndString)
+		if response.IntervalsPassed > 1 {
+			fmt.Printf("%s%d interval times have passed since it started, so its tree will cover all of them.%s\n", colorYellow, response.IntervalsPassed, colorReset)
+		}
+	}
+	fmt.Printf("Interval %d is projected to end on %s.\n", response.CurrentIndex+1, nextIntervalEndString)
+	fmt.Printf("Each interval lasts %s.\n", response.IntervalTime)
+
+	if response.PreviousIntervalFound {
+		fmt.Println()
+		fmt.Printf("Interval %d ran from %s to %s.\n",
+			response.CurrentIndex-1,
+			cliutils.GetDateTimeString(uint64(response.PreviousIntervalStart.Unix())),
+			cliutils.GetDateTimeString(uint64(response.PreviousIntervalEnd.Unix())),
+		)
+	}
+	return nil
+
+}
@@ -0,0 +1,74 @@
+package network
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+)
+
+func verifyMerkleProof(c *cli.Context, path string, nodeAddress common.Address) error {
+
+	// Load the file
+	rewardsFile, err := rprewards.LoadRewardsFile(path)
+	if err != nil {
+		return err
+	}
+	root := common.HexToHash(rewardsFile.MerkleRoot)
+	fmt.Printf("Tree: %s (interval %d on %s, root %s)\n", path, rewardsFile.Index, rewardsFile.Network, root.Hex())
+	fmt.Printf("Node: %s\n\n", nodeAddress.Hex())
+
+	// Get the node's leaf
+	nodeRewards, exists := rewardsFile.NodeRewards[nodeAddress]
+	if !exists {
+		fmt.Println("This node isn't in the rewards tree, so it doesn't have anything to claim for this interval.")
+		return nil
+	}
+	rplRewards := big.NewInt(0).Add(&nodeRewards.CollateralRpl.Int, &nodeRewards.OracleDaoRpl.Int)
+	if rplRewards.Sign() == 0 && nodeRewards.SmoothingPoolEth.Sign() == 0 {
+		fmt.Println("This node didn't earn any rewards in this interval, so it isn't a leaf of the tree and doesn't have anything to claim.")
+		return nil
+	}
+	fmt.Println("Leaf:")
+	fmt.Printf("\tNetwork:            %d\n", nodeRewards.RewardNetwork)
+	fmt.Printf("\tRPL:                %.6f (%s wei)\n", eth.WeiToEth(rplRewards), rplRewards.String())
+	fmt.Printf("\tSmoothing pool ETH: %.6f (%s wei)\n\n", eth.WeiToEth(&nodeRewards.SmoothingPoolEth.Int), nodeRewards.SmoothingPoolEth.String())
+
+	// Walk the proof
+	proofPath, err := rprewards.GetNodeMerkleProofPath(nodeAddress, nodeRewards)
+	if err != nil {
+		return err
+	}
+	proof, err := nodeRewards.GetMerkleProof()
+	if err != nil {
+		return err
+	}
+	fmt.Println("Proof path:")
+	fmt.Printf("\tLeaf hash: %s\n", proofPath[0].Hex())
+	for i, proofHash := range proof {
+		fmt.Printf("\tLevel %d:   %s (sibling %s)\n", i+1, proofPath[i+1].Hex(), proofHash.Hex())
+	}
+	fmt.Println()
+
+	// Check the proof against the stored root
+	if proofPath[len(proofPath)-1] != root {
+		fmt.Printf("%sThe proof leads to %s, which doesn't match the root stored in the file. Don't claim with this file; download or regenerate it first.%s\n", colorRed, proofPath[len(proofPath)-1].Hex(), colorReset)
+		return nil
+	}
+	fmt.Printf("%sThe proof leads to the root stored in the file.%s\n", colorGreen, colorReset)
+
+	// Check the stored root against the one the file's rewards produce, in case the amounts were changed along with the root
+	computedRoot, err := rprewards.ComputeMerkleRoot(rewardsFile)
+	if err != nil {
+		return err
+	}
+	if computedRoot != root {
+		fmt.Printf("%sWARNING: the node rewards in the file produce a root of %s, which doesn't match the stored root. The file may be corrupt.%s\n", colorYellow, computedRoot.Hex(), colorReset)
+	}
+	return nil
+
+}
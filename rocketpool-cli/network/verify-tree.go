@@ -0,0 +1,78 @@
+package network
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/urfave/cli"
+)
+
+func verifyRewardsTree(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get config
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+
+	// Get the index
+	var index uint64
+	if c.IsSet("index") {
+		index = c.Uint64("index")
+	} else {
+		indexString := cliutils.Prompt("Which interval would you like to verify the Merkle rewards tree generation for?", "^\\d+$", "Invalid interval. Please provide a number.")
+		index, err = strconv.ParseUint(indexString, 0, 64)
+		if err != nil {
+			return fmt.Errorf("'%s' is not a valid interval: %w.\n", indexString, err)
+		}
+	}
+
+	// Check if generation will work
+	canResponse, err := rp.CanGenerateRewardsTree(index)
+	if err != nil {
+		return err
+	}
+	if canResponse.CurrentIndex <= index {
+		return fmt.Errorf("The current active rewards period is interval %d. You cannot generate the tree for interval %d until the active interval is past it.", canResponse.CurrentIndex, index)
+	}
+
+	// Confirm
+	fmt.Printf("This will generate the rewards tree for interval %d twice and check that both runs produce identical files. It will take about twice as long as a normal generation, and your existing rewards file will not be modified.\n\n", index)
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to continue?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Create the verification request
+	_, err = rp.VerifyRewardsTree(index)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Your request to verify the rewards tree for interval %d has been applied, and your `watchtower` container will begin the process during its next duty check (typically 5 minutes).\nYou can follow its progress with %s`rocketpool service logs watchtower`%s.\n\n", index, colorGreen, colorReset)
+
+	if c.Bool("yes") || cliutils.Confirm("Would you like to restart the watchtower container now, so it starts verifying immediately?") {
+		container := fmt.Sprintf("%s_watchtower", cfg.Smartnode.ProjectName.Value.(string))
+		response, err := rp.RestartContainer(container)
+		if err != nil {
+			return fmt.Errorf("Error restarting watchtower: %w", err)
+		}
+		if response != container {
+			return fmt.Errorf("Unexpected output while restarting watchtower: %s", response)
+		}
+
+		fmt.Println("Done!")
+	}
+
+	return nil
+
+}
@@ -0,0 +1,64 @@
+package network
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func getWatchtowerFiles(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the watchtower files
+	clean := c.Bool("clean")
+	response, err := rp.GetWatchtowerFiles(clean)
+	if err != nil {
+		return err
+	}
+
+	if len(response.Files) == 0 {
+		fmt.Println("The watchtower folder doesn't have any control files.")
+		return nil
+	}
+
+	// Print them
+	var maxNameLength int
+	for _, file := range response.Files {
+		if len(file.Name)+2 > maxNameLength {
+			maxNameLength = len(file.Name) + 2
+		}
+	}
+
+	staleCount := 0
+	removedCount := 0
+	for _, file := range response.Files {
+		status := ""
+		if file.Removed {
+			status = fmt.Sprintf(" %s(removed)%s", colorGreen, colorReset)
+			removedCount++
+		} else if file.IsStale {
+			status = fmt.Sprintf(" %s(stale)%s", colorYellow, colorReset)
+			staleCount++
+		}
+		fmt.Printf("%-*s%-12s%s%s\n", maxNameLength, file.Name, file.Age.Truncate(time.Second).String(), file.Purpose, status)
+	}
+	fmt.Println()
+
+	if clean {
+		fmt.Printf("Removed %d stale file(s).\n", removedCount)
+	} else if staleCount > 0 {
+		fmt.Printf("%d file(s) are stale. Run this command again with `--clean` to remove them.\n", staleCount)
+	}
+
+	return nil
+
+}
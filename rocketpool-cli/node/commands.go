@@ -281,6 +281,48 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "rewards-watch-file",
+				Aliases:   []string{"wf"},
+				Usage:     "Create or update a single JSON file with your node's rewards and Merkle proofs for every interval, for dashboards to read.\nOnce the file exists, the node daemon keeps it updated as new rewards trees are downloaded.",
+				UsageText: "rocketpool node rewards-watch-file",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return updateRewardsWatchFile(c)
+
+				},
+			},
+
+			{
+				Name:      "reward-rank",
+				Aliases:   []string{"rr"},
+				Usage:     "Show how your node's RPL rewards for an interval compare to every other node's",
+				UsageText: "rocketpool node reward-rank [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "index",
+						Usage: "The index of the rewards interval to rank your node in",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getRewardRank(c)
+
+				},
+			},
+
 			{
 				Name:      "withdraw-rpl",
 				Aliases:   []string{"i"},
@@ -0,0 +1,57 @@
+package node
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getRewardRank(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the index
+	var index uint64
+	if c.IsSet("index") {
+		index = c.Uint64("index")
+	} else {
+		indexString := cliutils.Prompt("Which interval would you like to see your reward rank for?", "^\\d+$", "Invalid interval. Please provide a number.")
+		index, err = strconv.ParseUint(indexString, 0, 64)
+		if err != nil {
+			return fmt.Errorf("'%s' is not a valid interval: %w.\n", indexString, err)
+		}
+	}
+
+	// Get the rank
+	response, err := rp.NodeRewardRank(index)
+	if err != nil {
+		return err
+	}
+	if !response.TreeFileExists {
+		fmt.Printf("You don't have the rewards tree for interval %d (expected at %s). You can download it with `rocketpool node claim-rewards`, or generate it with `rocketpool network generate-rewards-tree`.\n", index, response.TreeFilePath)
+		return nil
+	}
+
+	// Print the results
+	fmt.Printf("Interval %d had %d nodes with rewards.\n", index, response.NodeCount)
+	fmt.Printf("Median RPL rewards:  %.6f RPL\n", eth.WeiToEth(response.MedianRpl))
+	fmt.Printf("Maximum RPL rewards: %.6f RPL\n\n", eth.WeiToEth(response.MaxRpl))
+	if !response.NodeExists {
+		fmt.Println("Your node did not earn any rewards in this interval.")
+		return nil
+	}
+	fmt.Printf("Your RPL rewards: %.6f RPL\n", eth.WeiToEth(response.NodeRpl))
+	fmt.Printf("Your rank: %d of %d (you earned more than %.2f%% of nodes)\n", response.Rank, response.NodeCount, response.Percentile)
+	return nil
+
+}
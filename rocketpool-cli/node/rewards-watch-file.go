@@ -0,0 +1,42 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func updateRewardsWatchFile(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Update the watch file
+	response, err := rp.UpdateRewardsWatchFile()
+	if err != nil {
+		return err
+	}
+
+	if len(response.AddedIntervals) == 0 {
+		fmt.Printf("Your rewards watch file at %s is already up to date.\n", response.WatchFilePath)
+	} else {
+		fmt.Printf("Added intervals %v to your rewards watch file at %s.\n", response.AddedIntervals, response.WatchFilePath)
+	}
+	fmt.Println("The node daemon will keep this file updated as new rewards trees become available.")
+
+	return nil
+
+}
@@ -0,0 +1,60 @@
+package odao
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func broadcastChallengeResponse(c *cli.Context, signedTx string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Load the signed transaction from a file if one was provided
+	if !strings.HasPrefix(signedTx, "0x") {
+		fileBytes, err := os.ReadFile(signedTx)
+		if err != nil {
+			return fmt.Errorf("'%s' is neither a hex-encoded transaction nor a readable file: %w", signedTx, err)
+		}
+		signedTx = strings.TrimSpace(string(fileBytes))
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to broadcast this challenge response?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Broadcast the response
+	response, err := rp.BroadcastChallengeResponse(signedTx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Responding to the challenge...\n")
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Println("Successfully responded to the challenge against the node.")
+	return nil
+
+}
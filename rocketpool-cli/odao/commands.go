@@ -1,6 +1,8 @@
 package odao
 
 import (
+	"fmt"
+
 	"github.com/urfave/cli"
 
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -50,6 +52,81 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "monitor-challenge",
+				Aliases:   []string{"c"},
+				Usage:     "Monitor a challenge against the node, showing the blocks and time left to respond and the current gas price",
+				UsageText: "rocketpool odao monitor-challenge [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "interval, i",
+						Usage: fmt.Sprintf("The number of seconds to wait between checks (default %d)", defaultChallengeMonitorInterval),
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return monitorChallenge(c)
+
+				},
+			},
+
+			{
+				Name:      "broadcast-challenge-response",
+				Aliases:   []string{"r"},
+				Usage:     "Broadcast a challenge response that was exported by the watchtower and signed offline. Provide the signed transaction as a hex string or the path to a file containing it.",
+				UsageText: "rocketpool odao broadcast-challenge-response [options] signed-tx",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the broadcast",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return broadcastChallengeResponse(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:      "decide-challenge",
+				Usage:     "Decide the challenge against a member whose response window has passed, removing them from the oracle DAO",
+				UsageText: "rocketpool odao decide-challenge [options] member-address",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm deciding the challenge",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					memberAddress, err := cliutils.ValidateAddress("member address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return decideChallenge(c, memberAddress)
+
+				},
+			},
+
 			{
 				Name:      "member-settings",
 				Aliases:   []string{"b"},
@@ -0,0 +1,77 @@
+package odao
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func decideChallenge(c *cli.Context, memberAddress common.Address) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Check if the challenge can be decided
+	canDecide, err := rp.CanDecideTNDAOChallenge(memberAddress)
+	if err != nil {
+		return err
+	}
+	if !canDecide.CanDecide {
+		fmt.Printf("Cannot decide the challenge against %s:\n", memberAddress.Hex())
+		if canDecide.MemberDoesNotExist {
+			fmt.Println("The provided address is not a member of the oracle DAO.")
+		}
+		if canDecide.NotChallenged {
+			fmt.Println("The member does not have an active challenge against it.")
+		}
+		if canDecide.WindowActive {
+			fmt.Printf("The member can still respond to the challenge until %s.\n", canDecide.ExpiryTime.UTC().Format(time.RFC3339))
+		}
+		return nil
+	}
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(canDecide.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("The challenge against %s expired at %s. Are you sure you want to decide it? This will remove the member from the oracle DAO.", memberAddress.Hex(), canDecide.ExpiryTime.UTC().Format(time.RFC3339)))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Decide the challenge
+	response, err := rp.DecideTNDAOChallenge(memberAddress)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Deciding the challenge against %s...\n", memberAddress.Hex())
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return err
+	}
+
+	// Log & return
+	fmt.Printf("Successfully decided the challenge against %s.\n", memberAddress.Hex())
+	return nil
+
+}
@@ -0,0 +1,87 @@
+package odao
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+const (
+	colorReset  string = "\033[0m"
+	colorRed    string = "\033[31m"
+	colorGreen  string = "\033[32m"
+	colorYellow string = "\033[33m"
+)
+
+// The default number of seconds between challenge status checks
+const defaultChallengeMonitorInterval uint64 = 12
+
+func monitorChallenge(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check and assign the EC status
+	err = cliutils.CheckClientStatus(rp)
+	if err != nil {
+		return err
+	}
+
+	// Get the polling interval
+	interval := c.Uint64("interval")
+	if interval == 0 {
+		interval = defaultChallengeMonitorInterval
+	}
+
+	wasChallenged := false
+	for {
+		status, err := rp.TNDAOChallengeStatus()
+		if err != nil {
+			return err
+		}
+		if !status.IsMember {
+			fmt.Printf("Node %s is not a member of the oracle DAO.\n", status.Address.Hex())
+			return nil
+		}
+
+		// Check if the challenge has been resolved
+		if !status.IsChallenged {
+			if wasChallenged {
+				fmt.Printf("%sThe challenge against node %s has been resolved.%s\n", colorGreen, status.Address.Hex(), colorReset)
+			} else {
+				fmt.Printf("Node %s does not have an active challenge against it.\n", status.Address.Hex())
+			}
+			return nil
+		}
+		if !wasChallenged {
+			fmt.Printf("%sNode %s was challenged in block %d and must respond by block %d.%s\n", colorYellow, status.Address.Hex(), status.ChallengeBlock, status.DeadlineBlock, colorReset)
+			fmt.Println("Press Ctrl+C to stop monitoring.")
+			fmt.Println()
+			wasChallenged = true
+		}
+
+		// Check if the window has closed
+		if status.CurrentBlock > status.DeadlineBlock {
+			fmt.Printf("%sThe challenge window closed at block %d (current block: %d). The challenge can now be decided, which will remove the node from the oracle DAO.%s\n", colorRed, status.DeadlineBlock, status.CurrentBlock, colorReset)
+			return nil
+		}
+
+		// Print the countdown
+		remainingBlocks := status.DeadlineBlock - status.CurrentBlock
+		remainingTime := time.Duration(remainingBlocks*status.SecondsPerBlock) * time.Second
+		gasPrice := eth.WeiToGwei(status.GasPrice)
+		fmt.Printf("[%s] Block %d: %d blocks remaining (about %s), current gas price %.2f gwei\n", time.Now().Format("15:04:05"), status.CurrentBlock, remainingBlocks, remainingTime.String(), gasPrice)
+
+		time.Sleep(time.Duration(interval) * time.Second)
+	}
+
+}
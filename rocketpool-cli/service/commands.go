@@ -422,6 +422,60 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "config-diff",
+				Aliases:   []string{"cd"},
+				Usage:     "Show the settings you've changed from the defaults for your selected network",
+				UsageText: "rocketpool service config-diff",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return diffConfig(c)
+
+				},
+			},
+
+			{
+				Name:      "validate-containers",
+				Aliases:   []string{"vc"},
+				Usage:     "Check that the Smartnode's Docker containers match your configuration and report any drift",
+				UsageText: "rocketpool service validate-containers",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return validateContainers(c)
+
+				},
+			},
+
+			{
+				Name:      "self-test",
+				Aliases:   []string{"st"},
+				Usage:     "Check that your node can reach its Execution client, its Beacon node, and the Rocket Pool contracts",
+				UsageText: "rocketpool service self-test",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return selfTest(c)
+
+				},
+			},
+
 			{
 				Name:      "export-eth1-data",
 				Usage:     "Exports the execution client (eth1) chain data to an external folder. Use this if you want to back up your chain data before switching execution clients.",
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Print the settings that differ from the defaults for the selected network
+func diffConfig(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Get the changes, sorted by section
+	changes := cfg.GetChangesFromDefaults()
+	sections := make([]string, 0, len(changes))
+	for section, settings := range changes {
+		if len(settings) > 0 {
+			sections = append(sections, section)
+		}
+	}
+	sort.Strings(sections)
+
+	network := cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	if len(sections) == 0 {
+		fmt.Printf("Your configuration matches the defaults for the %s network.\n", network)
+		return nil
+	}
+
+	fmt.Printf("The following settings differ from the defaults for the %s network:\n\n", network)
+	for _, section := range sections {
+		fmt.Printf("%s== %s ==%s\n", colorGreen, section, colorReset)
+		for _, setting := range changes[section] {
+			fmt.Printf("%s: %s%s%s (default: %s)\n", setting.Name, colorYellow, formatConfigValue(setting.NewValue), colorReset, formatConfigValue(setting.OldValue))
+		}
+		fmt.Println()
+	}
+
+	return nil
+
+}
+
+// Make blank or unset values readable
+func formatConfigValue(value string) string {
+	switch value {
+	case "":
+		return "<blank>"
+	case "<nil>":
+		return "<not set>"
+	default:
+		return value
+	}
+}
@@ -0,0 +1,98 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+func createLocalMevRelaysStep(wiz *wizard, currentStep int, totalSteps int) *checkBoxWizardStep {
+
+	helperText := "Select the relays you would like to use below. Relays marked as censoring comply with government regulations (e.g. OFAC sanctions) and won't include sanctioned transactions in your blocks; non-censoring relays don't follow any sanctions lists. You must select at least one relay.\n\n[lime]Please read our guide to learn more about MEV:\nhttps://docs.rocketpool.net/guides/node/mev.html\n"
+
+	show := func(modal *checkBoxModalLayout) {
+		labels, descriptions, selections := getMevRelayChoices(wiz.md.Config.MevBoost)
+		modal.generateCheckboxes(labels, descriptions, selections)
+
+		wiz.md.setPage(modal.page)
+		modal.focus()
+	}
+
+	done := func(choices map[string]bool) {
+		// Make sure at least one relay was chosen before changing anything
+		relays := wiz.md.Config.MevBoost.GetAvailableRelays()
+		atLeastOneEnabled := false
+		for _, relay := range relays {
+			atLeastOneEnabled = atLeastOneEnabled || choices[getMevRelayLabel(relay)]
+		}
+		if !atLeastOneEnabled {
+			wiz.showError("[orange]Please select at least one relay. If you wish to opt out of MEV-Boost for now, go back and choose Profile Mode instead.", wiz.localMevRelaysModal)
+			return
+		}
+
+		wiz.md.Config.MevBoost.Mode.Value = cfgtypes.Mode_Local
+		wiz.md.Config.MevBoost.SelectionMode.Value = cfgtypes.MevSelectionMode_Relay
+		for _, relay := range relays {
+			param := wiz.md.Config.MevBoost.GetRelayParameter(relay.ID)
+			if param != nil {
+				param.Value = choices[getMevRelayLabel(relay)]
+			}
+		}
+
+		wiz.md.Config.EnableMevBoost.Value = true
+		wiz.finishedModal.show()
+	}
+
+	back := func() {
+		wiz.mevSelectionModeModal.show()
+	}
+
+	return newCheckBoxStep(
+		wiz,
+		currentStep,
+		totalSteps,
+		helperText,
+		90,
+		"MEV-Boost Relays",
+		show,
+		done,
+		back,
+		"step-mev-local-relays",
+	)
+
+}
+
+func getMevRelayChoices(config *config.MevBoostConfig) ([]string, []string, []bool) {
+	labels := []string{}
+	descriptions := []string{}
+	settings := []bool{}
+
+	for _, relay := range config.GetAvailableRelays() {
+		param := config.GetRelayParameter(relay.ID)
+		if param == nil {
+			continue
+		}
+
+		description := relay.Description + "\n\n"
+		if relay.NoSandwiching {
+			description += "Allows Sandwich Attacks: NO"
+		} else {
+			description += "Allows Sandwich Attacks: YES"
+		}
+
+		labels = append(labels, getMevRelayLabel(relay))
+		descriptions = append(descriptions, description)
+		settings = append(settings, param.Value == true)
+	}
+
+	return labels, descriptions, settings
+}
+
+// Get the checkbox label for a relay, marking whether or not it censors transactions
+func getMevRelayLabel(relay cfgtypes.MevRelay) string {
+	if relay.Regulated {
+		return fmt.Sprintf("%s (censoring)", relay.Name)
+	}
+	return fmt.Sprintf("%s (non-censoring)", relay.Name)
+}
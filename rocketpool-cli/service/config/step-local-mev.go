@@ -57,7 +57,7 @@ func createLocalMevStep(wiz *wizard, currentStep int, totalSteps int) *checkBoxW
 	}
 
 	back := func() {
-		wiz.mevModeModal.show()
+		wiz.mevSelectionModeModal.show()
 	}
 
 	return newCheckBoxStep(
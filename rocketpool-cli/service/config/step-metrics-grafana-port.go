@@ -0,0 +1,100 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+func createGrafanaPortStep(wiz *wizard, currentStep int, totalSteps int) *textBoxWizardStep {
+
+	// Create the labels
+	portLabel := wiz.md.Config.Grafana.Port.Name
+
+	helperText := "Grafana serves its dashboard on the port below - this is the port you will connect to in your browser.\n\nIf another program on your machine already uses this port, enter a different one here. It must be between 1 and 65535, and can't be used by any other Smartnode service."
+
+	show := func(modal *textBoxModalLayout) {
+		wiz.md.setPage(modal.page)
+		modal.focus()
+		for label, box := range modal.textboxes {
+			for _, param := range wiz.md.Config.Grafana.GetParameters() {
+				if param.Name == label {
+					box.SetText(fmt.Sprint(param.Value))
+				}
+			}
+		}
+	}
+
+	done := func(text map[string]string) {
+		portString := strings.TrimSpace(text[portLabel])
+		port, err := strconv.ParseUint(portString, 10, 16)
+		if err != nil || port == 0 {
+			wiz.showError(fmt.Sprintf("[orange]'%s' is not a valid port. Please enter a number between 1 and 65535.", portString), wiz.grafanaPortModal)
+			return
+		}
+		if conflict := getPortConflict(wiz.md.Config, &wiz.md.Config.Grafana.Port, uint16(port)); conflict != "" {
+			wiz.showError(fmt.Sprintf("[orange]Port %d is already used by the %s setting. Please enter a different port.", port, conflict), wiz.grafanaPortModal)
+			return
+		}
+
+		wiz.md.Config.Grafana.Port.Value = uint16(port)
+		wiz.metricsRetentionModal.show()
+	}
+
+	back := func() {
+		wiz.metricsModal.show()
+	}
+
+	return newTextBoxWizardStep(
+		wiz,
+		currentStep,
+		totalSteps,
+		helperText,
+		70,
+		"Metrics > Grafana Port",
+		[]string{portLabel},
+		[]int{5},
+		[]string{"^[0-9]+$"},
+		show,
+		done,
+		back,
+		"step-metrics-grafana-port",
+	)
+
+}
+
+// Get the name of the Smartnode port setting (other than the one being changed) that already uses the provided port,
+// or an empty string if it's free
+func getPortConflict(cfg *config.RocketPoolConfig, target *cfgtypes.Parameter, port uint16) string {
+
+	ports := []*cfgtypes.Parameter{
+		&cfg.ExecutionCommon.HttpPort,
+		&cfg.ExecutionCommon.WsPort,
+		&cfg.ExecutionCommon.EnginePort,
+		&cfg.ExecutionCommon.P2pPort,
+		&cfg.ConsensusCommon.P2pPort,
+		&cfg.ConsensusCommon.ApiPort,
+		&cfg.Prysm.RpcPort,
+		&cfg.MevBoost.Port,
+		&cfg.Grafana.Port,
+		&cfg.Prometheus.Port,
+		&cfg.EcMetricsPort,
+		&cfg.BnMetricsPort,
+		&cfg.VcMetricsPort,
+		&cfg.NodeMetricsPort,
+		&cfg.ExporterMetricsPort,
+		&cfg.WatchtowerMetricsPort,
+	}
+
+	portString := fmt.Sprint(port)
+	for _, param := range ports {
+		if param != target && fmt.Sprint(param.Value) == portString {
+			return param.Name
+		}
+	}
+	return ""
+
+}
@@ -0,0 +1,49 @@
+package config
+
+import "fmt"
+
+func createMetricsRetentionStep(wiz *wizard, currentStep int, totalSteps int) *textBoxWizardStep {
+
+	// Create the labels
+	retentionLabel := wiz.md.Config.Prometheus.Retention.Name
+
+	helperText := "How long would you like Prometheus to keep your metrics? Older metrics are deleted automatically to save disk space.\n\nEnter a number followed by a unit, such as `15d` for 15 days, `8w` for 8 weeks, or `1y` for 1 year. The default is 15 days."
+
+	show := func(modal *textBoxModalLayout) {
+		wiz.md.setPage(modal.page)
+		modal.focus()
+		for label, box := range modal.textboxes {
+			for _, param := range wiz.md.Config.Prometheus.GetParameters() {
+				if param.Name == label {
+					box.SetText(fmt.Sprint(param.Value))
+				}
+			}
+		}
+	}
+
+	done := func(text map[string]string) {
+		wiz.md.Config.Prometheus.Retention.Value = text[retentionLabel]
+		wiz.rewardsModeModal.show()
+	}
+
+	back := func() {
+		wiz.grafanaPortModal.show()
+	}
+
+	return newTextBoxWizardStep(
+		wiz,
+		currentStep,
+		totalSteps,
+		helperText,
+		70,
+		"Metrics > Retention",
+		[]string{retentionLabel},
+		[]int{wiz.md.Config.Prometheus.Retention.MaxLength},
+		[]string{wiz.md.Config.Prometheus.Retention.Regex},
+		show,
+		done,
+		back,
+		"step-metrics-retention",
+	)
+
+}
@@ -20,10 +20,11 @@ func createMetricsStep(wiz *wizard, currentStep int, totalSteps int) *choiceWiza
 	done := func(buttonIndex int, buttonLabel string) {
 		if buttonIndex == 1 {
 			wiz.md.Config.EnableMetrics.Value = true
+			wiz.grafanaPortModal.show()
 		} else {
 			wiz.md.Config.EnableMetrics.Value = false
+			wiz.rewardsModeModal.show()
 		}
-		wiz.mevModeModal.show()
 	}
 
 	back := func() {
@@ -35,7 +35,7 @@ func createMevModeStep(wiz *wizard, currentStep int, totalSteps int) *choiceWiza
 		wiz.md.Config.MevBoost.Mode.Value = modes[buttonIndex].Value
 		switch modes[buttonIndex].Value {
 		case cfgtypes.Mode_Local:
-			wiz.localMevModal.show()
+			wiz.mevSelectionModeModal.show()
 		case cfgtypes.Mode_External:
 			switch wiz.md.Config.ExecutionClientMode.Value {
 			case cfgtypes.Mode_Local:
@@ -53,7 +53,7 @@ func createMevModeStep(wiz *wizard, currentStep int, totalSteps int) *choiceWiza
 	}
 
 	back := func() {
-		wiz.metricsModal.show()
+		wiz.rewardsModeModal.show()
 	}
 
 	return newChoiceStep(
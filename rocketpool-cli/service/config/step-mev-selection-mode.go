@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+func createMevSelectionModeStep(wiz *wizard, currentStep int, totalSteps int) *choiceWizardStep {
+
+	// Create the button names and descriptions from the config
+	modes := wiz.md.Config.MevBoost.SelectionMode.Options
+	modeNames := []string{}
+	modeDescriptions := []string{}
+	for _, mode := range modes {
+		modeNames = append(modeNames, mode.Name)
+		modeDescriptions = append(modeDescriptions, mode.Description)
+	}
+
+	helperText := "Would you like to choose the MEV-Boost relays you use by profile, or pick each relay individually?\n\n[lime]Please read our guide to learn more about MEV:\nhttps://docs.rocketpool.net/guides/node/mev.html\n"
+
+	show := func(modal *choiceModalLayout) {
+		wiz.md.setPage(modal.page)
+		modal.focus(0) // Catch-all for safety
+
+		for i, option := range wiz.md.Config.MevBoost.SelectionMode.Options {
+			if option.Value == wiz.md.Config.MevBoost.SelectionMode.Value {
+				modal.focus(i)
+				break
+			}
+		}
+	}
+
+	done := func(buttonIndex int, buttonLabel string) {
+		switch modes[buttonIndex].Value {
+		case cfgtypes.MevSelectionMode_Profile:
+			wiz.localMevModal.show()
+		case cfgtypes.MevSelectionMode_Relay:
+			wiz.localMevRelaysModal.show()
+		default:
+			panic(fmt.Sprintf("Unknown MEV selection mode %s", modes[buttonIndex].Value))
+		}
+	}
+
+	back := func() {
+		wiz.mevModeModal.show()
+	}
+
+	return newChoiceStep(
+		wiz,
+		currentStep,
+		totalSteps,
+		helperText,
+		modeNames,
+		modeDescriptions,
+		76,
+		"MEV-Boost Relay Selection",
+		DirectionalModalVertical,
+		show,
+		done,
+		back,
+		"step-mev-selection-mode",
+	)
+}
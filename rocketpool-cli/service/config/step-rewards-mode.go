@@ -0,0 +1,56 @@
+package config
+
+func createRewardsModeStep(wiz *wizard, currentStep int, totalSteps int) *choiceWizardStep {
+
+	// Create the button names and descriptions from the config
+	modes := wiz.md.Config.Smartnode.RewardsTreeMode.Options
+	modeNames := []string{}
+	modeDescriptions := []string{}
+	for _, mode := range modes {
+		modeNames = append(modeNames, mode.Name)
+		modeDescriptions = append(modeDescriptions, mode.Description)
+	}
+
+	helperText := "After each rewards interval, your node needs the Merkle rewards tree file for that interval in order to claim its rewards. Would you like to download the trees published by the Oracle DAO, or generate them yourself?\n\nGenerating a tree needs access to an Execution client with archival state, which your Smartnode's Execution client does not provide by default. If you don't have one, choose Download."
+
+	show := func(modal *choiceModalLayout) {
+		wiz.md.setPage(modal.page)
+		modal.focus(0) // Catch-all for safety
+
+		for i, option := range modes {
+			if option.Value == wiz.md.Config.Smartnode.RewardsTreeMode.Value {
+				modal.focus(i)
+				break
+			}
+		}
+	}
+
+	done := func(buttonIndex int, buttonLabel string) {
+		wiz.md.Config.Smartnode.RewardsTreeMode.Value = modes[buttonIndex].Value
+		wiz.mevModeModal.show()
+	}
+
+	back := func() {
+		if wiz.md.Config.EnableMetrics.Value == true {
+			wiz.metricsRetentionModal.show()
+		} else {
+			wiz.metricsModal.show()
+		}
+	}
+
+	return newChoiceStep(
+		wiz,
+		currentStep,
+		totalSteps,
+		helperText,
+		modeNames,
+		modeDescriptions,
+		76,
+		"Rewards Trees",
+		DirectionalModalVertical,
+		show,
+		done,
+		back,
+		"step-rewards-mode",
+	)
+}
@@ -1,5 +1,7 @@
 package config
 
+import "github.com/rivo/tview"
+
 type wizard struct {
 	md *mainDisplay
 
@@ -19,8 +21,13 @@ type wizard struct {
 	tekuExternalSettingsModal       *textBoxWizardStep
 	externalGraffitiModal           *textBoxWizardStep
 	metricsModal                    *choiceWizardStep
+	grafanaPortModal                *textBoxWizardStep
+	metricsRetentionModal           *textBoxWizardStep
+	rewardsModeModal                *choiceWizardStep
 	mevModeModal                    *choiceWizardStep
+	mevSelectionModeModal           *choiceWizardStep
 	localMevModal                   *checkBoxWizardStep
+	localMevRelaysModal             *checkBoxWizardStep
 	externalMevModal                *textBoxWizardStep
 	finishedModal                   *choiceWizardStep
 	consensusLocalRandomModal       *choiceWizardStep
@@ -53,7 +60,7 @@ func newWizard(md *mainDisplay) *wizard {
 		md: md,
 	}
 
-	totalDockerSteps := 9
+	totalDockerSteps := 10
 	totalNativeSteps := 10
 
 	// Docker mode
@@ -78,10 +85,15 @@ func newWizard(md *mainDisplay) *wizard {
 	wiz.fallbackNormalModal = createFallbackNormalStep(wiz, 6, totalDockerSteps)
 	wiz.fallbackPrysmModal = createFallbackPrysmStep(wiz, 6, totalDockerSteps)
 	wiz.metricsModal = createMetricsStep(wiz, 7, totalDockerSteps)
-	wiz.mevModeModal = createMevModeStep(wiz, 8, totalDockerSteps)
-	wiz.localMevModal = createLocalMevStep(wiz, 8, totalDockerSteps)
-	wiz.externalMevModal = createExternalMevStep(wiz, 8, totalDockerSteps)
-	wiz.finishedModal = createFinishedStep(wiz, 9, totalDockerSteps)
+	wiz.grafanaPortModal = createGrafanaPortStep(wiz, 7, totalDockerSteps)
+	wiz.metricsRetentionModal = createMetricsRetentionStep(wiz, 7, totalDockerSteps)
+	wiz.rewardsModeModal = createRewardsModeStep(wiz, 8, totalDockerSteps)
+	wiz.mevModeModal = createMevModeStep(wiz, 9, totalDockerSteps)
+	wiz.mevSelectionModeModal = createMevSelectionModeStep(wiz, 9, totalDockerSteps)
+	wiz.localMevModal = createLocalMevStep(wiz, 9, totalDockerSteps)
+	wiz.localMevRelaysModal = createLocalMevRelaysStep(wiz, 9, totalDockerSteps)
+	wiz.externalMevModal = createExternalMevStep(wiz, 9, totalDockerSteps)
+	wiz.finishedModal = createFinishedStep(wiz, 10, totalDockerSteps)
 
 	// Native mode
 	wiz.nativeWelcomeModal = createNativeWelcomeStep(wiz, 1, totalNativeSteps)
@@ -99,3 +111,15 @@ func newWizard(md *mainDisplay) *wizard {
 	return wiz
 
 }
+
+// Show an error message for invalid input, then return to the provided step
+func (wiz *wizard) showError(message string, returnTo wizardStep) {
+	modal := tview.NewModal().
+		SetText(message).
+		AddButtons([]string{"OK"}).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			wiz.md.app.SetRoot(wiz.md.mainGrid, true)
+			returnTo.show()
+		})
+	wiz.md.app.SetRoot(modal, false).SetFocus(modal)
+}
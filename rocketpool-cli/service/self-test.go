@@ -0,0 +1,52 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Check the node's connections to its clients and the Rocket Pool contracts and print a pass/fail table
+func selfTest(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Run the checks
+	response, err := rp.SelfTest()
+	if err != nil {
+		return err
+	}
+
+	// Print the results
+	nameWidth := 0
+	for _, check := range response.Checks {
+		if len(check.Name) > nameWidth {
+			nameWidth = len(check.Name)
+		}
+	}
+	failures := 0
+	for _, check := range response.Checks {
+		if check.Passed {
+			fmt.Printf("%-*s  %sPASS%s  %s\n", nameWidth, check.Name, colorGreen, colorReset, check.Details)
+		} else {
+			failures++
+			fmt.Printf("%-*s  %sFAIL%s  %s\n", nameWidth, check.Name, colorRed, colorReset, check.Details)
+		}
+	}
+	fmt.Println()
+
+	if failures == 0 {
+		fmt.Println("All checks passed.")
+	} else {
+		fmt.Printf("%s%d check(s) failed.%s Your node won't be able to do its duties until these are fixed; check `rocketpool service logs` for the affected containers for more details.\n", colorRed, failures, colorReset)
+	}
+	return nil
+
+}
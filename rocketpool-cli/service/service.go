@@ -258,6 +258,22 @@ func configureService(c *cli.Context) error {
 		}
 	}
 
+	// Save any schema migrations that were applied while loading the config, and let the user review them
+	if !isNew && len(cfg.AppliedMigrations) > 0 {
+		fmt.Println("Your settings file was migrated to the latest format:")
+		for _, migration := range cfg.AppliedMigrations {
+			fmt.Printf("\t%s\n", migration)
+		}
+		fmt.Println()
+		err = rp.SaveConfig(cfg)
+		if err != nil {
+			return fmt.Errorf("error saving migrated settings: %w", err)
+		}
+		if c.NumFlags() == 0 && !cliutils.Confirm("The migrated settings have been saved. Would you like to continue to the configuration wizard?") {
+			return nil
+		}
+	}
+
 	// Check if this is a new install
 	isUpdate, err := rp.IsFirstRun()
 	if err != nil {
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Check the Smartnode's Docker containers against the config and print any drift
+func validateContainers(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Validate the containers
+	response, err := rp.ValidateContainers()
+	if err != nil {
+		return err
+	}
+
+	// Print the results
+	problems := 0
+	for _, container := range response.Containers {
+		if len(container.Issues) == 0 {
+			fmt.Printf("%s%s%s: OK (%s)\n", colorGreen, container.Name, colorReset, container.ActualImage)
+			continue
+		}
+		problems++
+		fmt.Printf("%s%s%s:\n", colorYellow, container.Name, colorReset)
+		for _, issue := range container.Issues {
+			fmt.Printf("\t- %s\n", issue)
+		}
+	}
+	fmt.Println()
+
+	if problems == 0 {
+		fmt.Println("All of the Smartnode's containers match your configuration.")
+	} else {
+		fmt.Printf("%s%d container(s) don't match your configuration.%s Run `rocketpool service start` to recreate them with your current settings.\n", colorYellow, problems, colorReset)
+	}
+	return nil
+
+}
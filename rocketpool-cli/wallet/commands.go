@@ -231,10 +231,167 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "test-custom-key-passwords",
+				Aliases:   []string{"c"},
+				Usage:     "Check whether your custom validator keystores can be decrypted with the node password, and report any that use a different password",
+				UsageText: "rocketpool wallet test-custom-key-passwords",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return testCustomKeyPasswords(c)
+
+				},
+			},
+
+			{
+				Name:      "encrypt-custom-keys",
+				Usage:     "Encrypt your custom validator keystores with your node password as an extra layer of protection. The Smartnode decrypts them automatically when it reads them.",
+				UsageText: "rocketpool wallet encrypt-custom-keys [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm encrypting the keystores",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return encryptCustomKeys(c)
+
+				},
+			},
+
+			{
+				Name:      "reencrypt-custom-keys",
+				Usage:     "Re-encrypt your custom validator keystores with a new password, such as after changing your node password",
+				UsageText: "rocketpool wallet reencrypt-custom-keys [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "old-password",
+						Usage: "The password the custom keystores are currently encrypted with",
+					},
+					cli.StringFlag{
+						Name:  "new-password",
+						Usage: "The password to re-encrypt the custom keystores with",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm re-encrypting the keystores",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return reencryptCustomKeys(c)
+
+				},
+			},
+
+			{
+				Name:      "verify-custom-keys",
+				Aliases:   []string{"v"},
+				Usage:     "Restart your Validator Client and confirm that it loaded every custom validator key, reporting any that failed to load",
+				UsageText: "rocketpool wallet verify-custom-keys [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm restarting the Validator Client",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return verifyCustomKeys(c)
+
+				},
+			},
+
+			{
+				Name:      "security-audit",
+				Usage:     "Check that your custom validator keystores can't be read by other users on this machine",
+				UsageText: "rocketpool wallet security-audit [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "fix, f",
+						Usage: "Automatically tighten the permissions of any custom key paths other users can access",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return securityAudit(c)
+
+				},
+			},
+
+			{
+				Name:      "inspect-keystore",
+				Usage:     "Decrypt a validator keystore file and show the pubkey it holds, without using the node wallet",
+				UsageText: "rocketpool wallet inspect-keystore [options] keystore-path",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "password, p",
+						Usage: "The password the keystore is encrypted with",
+					},
+					cli.StringFlag{
+						Name:  "node-password",
+						Usage: "The node password, if the keystore was encrypted with it by encrypt-custom-keys",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					path := c.Args().Get(0)
+
+					// Run
+					return inspectKeystore(c, path)
+
+				},
+			},
+
 			{
 				Name:      "purge",
 				Usage:     fmt.Sprintf("%sDeletes your node wallet, your validator keys, and restarts your Validator Client while preserving your chain data. WARNING: Only use this if you want to stop validating with this machine!%s", colorRed, colorReset),
-				UsageText: "rocketpool wallet purge",
+				UsageText: "rocketpool wallet purge [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "dry-run, d",
+						Usage: "Show what would be deleted without deleting anything or restarting your Validator Client",
+					},
+					cli.StringFlag{
+						Name:  "backup",
+						Usage: "Before purging, save a password-protected backup of your node wallet to this path (inside your Smartnode data directory unless you're in Native mode)",
+					},
+				},
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -0,0 +1,77 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func testCustomKeyPasswords(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check the custom keys
+	response, err := rp.TestCustomKeyPasswords()
+	if err != nil {
+		return err
+	}
+	if len(response.Keys) == 0 {
+		fmt.Println("You don't have any custom validator keystores.")
+		return nil
+	}
+
+	// Print the results
+	differentPasswords := 0
+	undecryptable := 0
+	mismatched := 0
+	for _, key := range response.Keys {
+		name := key.File
+		if key.Pubkey != (types.ValidatorPubkey{}) {
+			name = fmt.Sprintf("%s (%s)", key.File, key.Pubkey.Hex())
+		}
+
+		switch {
+		case key.PubkeyMismatch:
+			mismatched++
+			fmt.Printf("%s%s%s: its private key is NOT for the pubkey it claims to be for (%s).\n", colorRed, name, colorReset, key.Error)
+		case key.DecryptsWithNodePassword:
+			fmt.Printf("%s%s%s: uses the node password.\n", colorGreen, name, colorReset)
+		case key.DecryptsWithPasswordEntry:
+			differentPasswords++
+			fmt.Printf("%s%s%s: uses a different password than the node password, but its entry in the custom key password file is correct.\n", colorYellow, name, colorReset)
+		case key.HasPasswordFileEntry:
+			undecryptable++
+			fmt.Printf("%s%s%s: could not be decrypted with the node password or its entry in the custom key password file (%s).\n", colorRed, name, colorReset, key.Error)
+		case key.Error != "":
+			undecryptable++
+			fmt.Printf("%s%s%s: %s.\n", colorRed, name, colorReset, key.Error)
+		default:
+			undecryptable++
+			fmt.Printf("%s%s%s: uses a different password than the node password, and has no entry in the custom key password file.\n", colorRed, name, colorReset)
+		}
+	}
+	fmt.Println()
+
+	if mismatched > 0 {
+		fmt.Printf("%s%d custom keystore(s) contain a private key for a different validator than the pubkey they list. Replace them with the correct keystores.%s\n", colorRed, mismatched, colorReset)
+	}
+	if undecryptable > 0 {
+		fmt.Printf("%s%d custom keystore(s) can't be decrypted with any password the Smartnode knows about, so they will fail to load. Add the correct password for each one to the custom key password file.%s\n", colorRed, undecryptable, colorReset)
+	}
+	if differentPasswords > 0 {
+		fmt.Printf("%d custom keystore(s) use a different password than the node password. They will only load as long as their entries in the custom key password file are kept.\n", differentPasswords)
+	}
+	if undecryptable == 0 && differentPasswords == 0 && mismatched == 0 {
+		fmt.Println("All of your custom keystores can be decrypted with the node password.")
+	}
+	return nil
+
+}
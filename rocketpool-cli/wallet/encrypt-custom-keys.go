@@ -0,0 +1,47 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/urfave/cli"
+)
+
+func encryptCustomKeys(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Prompt for confirmation
+	fmt.Println("This will encrypt each of your custom validator keystores with your node password, on top of their own passwords. The Smartnode decrypts them automatically, but other tools won't be able to read the files directly.")
+	fmt.Println()
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to continue?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Encrypt the keys
+	response, err := rp.EncryptCustomKeys()
+	if err != nil {
+		return err
+	}
+
+	for _, file := range response.EncryptedFiles {
+		fmt.Printf("%s%s%s: encrypted.\n", colorGreen, file, colorReset)
+	}
+	for _, file := range response.AlreadyEncryptedFiles {
+		fmt.Printf("%s: already encrypted.\n", file)
+	}
+	for _, file := range response.SkippedFiles {
+		fmt.Printf("%s%s%s: could not be read as a validator keystore, so it was left alone.\n", colorYellow, file, colorReset)
+	}
+	fmt.Println()
+	fmt.Printf("Encrypted %d custom keystore(s).\n", len(response.EncryptedFiles))
+	return nil
+
+}
@@ -41,7 +41,11 @@ func initWallet(c *cli.Context) error {
 		if c.String("password") != "" {
 			password = c.String("password")
 		} else {
-			password = promptPassword()
+			cfg, _, err := rp.LoadConfig()
+			if err != nil {
+				return fmt.Errorf("Error loading configuration: %w", err)
+			}
+			password = promptPassword(cfg.Smartnode.GetPasswordPolicy())
 		}
 		if _, err := rp.SetPassword(password); err != nil {
 			return err
@@ -0,0 +1,61 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
+	"github.com/urfave/cli"
+)
+
+func inspectKeystore(c *cli.Context, path string) error {
+
+	// Read the keystore
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Error reading %s: %w", path, err)
+	}
+	name := filepath.Base(path)
+
+	// Keystores encrypted with the node password need it to be unwrapped first
+	nodePassword := ""
+	if walletutils.IsEncryptedCustomKeystore(fileBytes) {
+		nodePassword = c.String("node-password")
+		if nodePassword == "" {
+			nodePassword = cliutils.PromptPassword("This keystore is encrypted with a node password. Please enter the node password:", "^.+$", "")
+		}
+	}
+	keystore, err := walletutils.ParseCustomKeystore(fileBytes, name, nodePassword)
+	if err != nil {
+		return err
+	}
+
+	// Decrypt it
+	password := c.String("password")
+	if password == "" {
+		password = cliutils.PromptPassword(fmt.Sprintf("Please enter the password for %s:", name), "^.+$", "")
+	}
+	derivedPubkey := keystore.Pubkey
+	_, err = walletutils.DecryptCustomKeystore(keystore, name, password)
+	var mismatchErr *walletutils.PubkeyMismatchError
+	if errors.As(err, &mismatchErr) {
+		derivedPubkey = mismatchErr.Actual
+	} else if err != nil {
+		return err
+	}
+
+	// Print the results
+	fmt.Printf("Stored pubkey:  %s\n", keystore.Pubkey.Hex())
+	fmt.Printf("Derived pubkey: %s\n", derivedPubkey.Hex())
+	fmt.Println()
+	if keystore.Pubkey == derivedPubkey {
+		fmt.Printf("%sThe keystore's private key matches its stored pubkey.%s\n", colorGreen, colorReset)
+	} else {
+		fmt.Printf("%sThe keystore's private key does NOT match its stored pubkey, so it can't be used for that validator.%s\n", colorRed, colorReset)
+	}
+	return nil
+
+}
@@ -6,7 +6,9 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/rocketpool-cli/service"
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
@@ -25,17 +27,47 @@ func purge(c *cli.Context) error {
 		return fmt.Errorf("error loading user settings: %w", err)
 	}
 
+	// Dry runs just report what would be deleted
+	if c.Bool("dry-run") {
+		response, err := rp.PurgeDryRun()
+		if err != nil {
+			return err
+		}
+		printPurgeDryRun(response)
+		return nil
+	}
+
 	if !cliutils.Confirm(fmt.Sprintf("%sWARNING: This will delete your node wallet, all of your validator keys (including externally-generated ones in the 'custom-keys' folder), and restart your Validator Client.\nYou will NO LONGER be able to attest with this machine anymore until you recover your wallet or initialize a new one.\n\nYou MUST have your node wallet's mnemonic recorded before running this, or you will lose access to your node wallet and your validators forever!\n\n%sDo you want to continue?", colorRed, colorReset)) {
 		fmt.Println("Cancelled.")
 		return nil
 	}
 
+	// Get the backup path and password
+	backupPath := ""
+	backupPassword := ""
+	if c.String("backup") != "" {
+		backupPath, err = cfg.Smartnode.GetDaemonPath(c.String("backup"))
+		if err != nil {
+			return err
+		}
+		backupPassword = promptBackupPassword()
+	}
+
+	// Get the confirmation token
+	tokenResponse, err := rp.GetPurgeToken()
+	if err != nil {
+		return err
+	}
+
 	// Purge
-	_, err = rp.Purge()
+	response, err := rp.Purge(tokenResponse.Token, backupPath, backupPassword)
 	if err != nil {
 		fmt.Printf("%sTHERE WAS AN ERROR DELETING YOUR KEYS. They most likely have not been deleted. Proceed with caution.%s\n", colorRed, colorReset)
 		return err
 	}
+	if response.BackupCreated {
+		fmt.Printf("Your node wallet was backed up to %s%s%s before it was deleted.\nTo restore it, put the backup back in place as your wallet file and set your node password to the backup password.\n\n", colorGreen, c.String("backup"), colorReset)
+	}
 
 	// Restart RP node and watchtower now that the wallet's gone
 	if !cfg.IsNativeMode {
@@ -58,12 +90,103 @@ func purge(c *cli.Context) error {
 		fmt.Printf("%sNOTE: As you are in Native mode, please restart your node and watchtower services manually to remove the cached wallet information.%s\n\n", colorYellow, colorReset)
 	}
 
+	// Summarize what was deleted
+	if len(response.DeletedValidatorPubkeys) > 0 {
+		fmt.Printf("Deleted %d validator key(s) derived from your node wallet:\n", len(response.DeletedValidatorPubkeys))
+		for _, pubkey := range response.DeletedValidatorPubkeys {
+			fmt.Printf("\t%s\n", pubkey)
+		}
+		fmt.Println()
+	}
+	if len(response.DeletedCustomKeystores) > 0 {
+		fmt.Printf("Deleted %d custom keystore(s):\n", len(response.DeletedCustomKeystores))
+		for _, file := range response.DeletedCustomKeystores {
+			fmt.Printf("\t%s\n", file)
+		}
+		fmt.Println()
+	}
+	if len(response.SkippedCustomKeystores) > 0 {
+		fmt.Printf("%sThese files in your custom key directory couldn't be read as keystores, so they were left in place:\n", colorYellow)
+		for _, skipped := range response.SkippedCustomKeystores {
+			fmt.Printf("\t%s: %s\n", skipped.File, skipped.Error)
+		}
+		fmt.Printf("%s\n", colorReset)
+	}
+	if len(response.Warnings) > 0 {
+		fmt.Printf("%sYour custom key directory had redundant keystores:\n", colorYellow)
+		for _, warning := range response.Warnings {
+			fmt.Printf("\t%s\n", warning)
+		}
+		fmt.Printf("%s\n", colorReset)
+	}
+
+	if !response.ValidatorRestarted {
+		fmt.Println("Deleted the node wallet. It didn't have any validator keys, so your Validator Client was not restarted.")
+		return nil
+	}
 	fmt.Printf("Deleted the node wallet and all validator keys.\n**Please verify that the keys have been removed by looking at your validator logs before continuing.**\n\n")
 	fmt.Printf("%sWARNING: If you intend to use these keys for validating again on this or any other machine, you must wait **at least fifteen minutes** after running this command before you can safely begin validating with them again.\nFailure to wait **could cause you to be slashed!**%s\n", colorYellow, colorReset)
 	return nil
 
 }
 
+// Print what a purge would delete
+func printPurgeDryRun(response api.PurgeResponse) {
+	fmt.Println("This is a dry run; nothing has been deleted.")
+	fmt.Println()
+	if len(response.DeletedValidatorPubkeys) > 0 {
+		fmt.Printf("A purge would delete %d validator key(s) derived from your node wallet:\n", len(response.DeletedValidatorPubkeys))
+		for _, pubkey := range response.DeletedValidatorPubkeys {
+			fmt.Printf("\t%s\n", pubkey)
+		}
+		fmt.Println()
+	}
+	if len(response.DeletedCustomKeystores) > 0 {
+		fmt.Printf("A purge would delete %d custom keystore(s):\n", len(response.DeletedCustomKeystores))
+		for _, file := range response.DeletedCustomKeystores {
+			fmt.Printf("\t%s\n", file)
+		}
+		fmt.Println()
+	}
+	if len(response.SkippedCustomKeystores) > 0 {
+		fmt.Printf("%sThese files in your custom key directory can't be read as keystores, so they would be left in place:\n", colorYellow)
+		for _, skipped := range response.SkippedCustomKeystores {
+			fmt.Printf("\t%s: %s\n", skipped.File, skipped.Error)
+		}
+		fmt.Printf("%s\n", colorReset)
+	}
+	if len(response.Warnings) > 0 {
+		fmt.Printf("%sYour custom key directory has redundant keystores:\n", colorYellow)
+		for _, warning := range response.Warnings {
+			fmt.Printf("\t%s\n", warning)
+		}
+		fmt.Printf("%s\n", colorReset)
+	}
+
+	if response.ValidatorRestarted {
+		fmt.Println("A purge would also delete your node wallet and its password, and restart your Validator Client.")
+	} else {
+		fmt.Println("A purge would also delete your node wallet and its password. It wouldn't need to restart your Validator Client.")
+	}
+}
+
+// Prompt for the password to encrypt the wallet backup with
+func promptBackupPassword() string {
+	for {
+		password := cliutils.PromptPassword(
+			"Please enter a password to secure your wallet backup with:",
+			fmt.Sprintf("^.{%d,}$", passwords.MinPasswordLength),
+			fmt.Sprintf("Your password must be at least %d characters long. Please try again:", passwords.MinPasswordLength),
+		)
+		confirmation := cliutils.PromptPassword("Please confirm your password:", "^.*$", "")
+		if password == confirmation {
+			return password
+		}
+		fmt.Println("Password confirmation does not match.")
+		fmt.Println("")
+	}
+}
+
 func restartContainer(rp *rocketpool.Client, containerName string) error {
 	// Restart node
 	result, err := rp.RestartContainer(containerName)
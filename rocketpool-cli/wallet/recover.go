@@ -46,7 +46,11 @@ func recoverWallet(c *cli.Context) error {
 		if c.String("password") != "" {
 			password = c.String("password")
 		} else {
-			password = promptPassword()
+			cfg, _, err := rp.LoadConfig()
+			if err != nil {
+				return fmt.Errorf("Error loading configuration: %w", err)
+			}
+			password = promptPassword(cfg.Smartnode.GetPasswordPolicy())
 		}
 		if _, err := rp.SetPassword(password); err != nil {
 			return err
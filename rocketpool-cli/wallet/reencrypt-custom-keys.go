@@ -0,0 +1,74 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/urfave/cli"
+)
+
+func reencryptCustomKeys(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the passwords
+	oldPassword := c.String("old-password")
+	if oldPassword == "" {
+		oldPassword = cliutils.PromptPassword("Please enter the password your custom keystores are currently encrypted with:", "^.+$", "")
+	}
+	newPassword := c.String("new-password")
+	if newPassword == "" {
+		cfg, _, err := rp.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("Error loading configuration: %w", err)
+		}
+		policy := cfg.Smartnode.GetPasswordPolicy()
+		for {
+			newPassword = cliutils.PromptPassword("Please enter the new password for your custom keystores:", "^.+$", "")
+			if err := policy.Check(newPassword); err != nil {
+				fmt.Printf("%s. Please try again.\n\n", err.Error())
+				continue
+			}
+			confirmation := cliutils.PromptPassword("Please confirm the new password:", "^.*$", "")
+			if newPassword == confirmation {
+				break
+			}
+			fmt.Println("Password confirmation does not match.")
+			fmt.Println("")
+		}
+	}
+
+	// Prompt for confirmation
+	fmt.Println("This will re-encrypt each of your custom validator keystores with the new password, and update their entries in the custom key password file. It won't change your node password.")
+	fmt.Println()
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to continue?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Re-encrypt the keys
+	response, err := rp.ReencryptCustomKeys(oldPassword, newPassword)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range response.ReencryptedFiles {
+		fmt.Printf("%s%s%s: re-encrypted.\n", colorGreen, file, colorReset)
+	}
+	for _, file := range response.SkippedFiles {
+		fmt.Printf("%s%s%s: could not be read as a validator keystore, so it was left alone.\n", colorYellow, file, colorReset)
+	}
+	fmt.Println()
+	fmt.Printf("Re-encrypted %d custom keystore(s).\n", len(response.ReencryptedFiles))
+	if response.UpdatedPasswordEntries > 0 {
+		fmt.Printf("Updated %d entry(s) in the custom key password file.\n", response.UpdatedPasswordEntries)
+	}
+	return nil
+
+}
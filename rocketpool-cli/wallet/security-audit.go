@@ -0,0 +1,68 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/urfave/cli"
+)
+
+func securityAudit(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check the custom key permissions
+	response, err := rp.AuditCustomKeyPermissions(false)
+	if err != nil {
+		return err
+	}
+	if !response.DirectoryExists {
+		fmt.Printf("You don't have a custom key directory (%s), so there's nothing to check.\n", response.CustomKeyPath)
+		return nil
+	}
+	if len(response.Issues) == 0 {
+		fmt.Printf("%sThe custom key directory (%s) and its keystores are only accessible by their owner.%s\n", colorGreen, response.CustomKeyPath, colorReset)
+		return nil
+	}
+
+	// Print the issues
+	fmt.Printf("%sThe following custom key paths can be accessed by other users on this machine:%s\n", colorYellow, colorReset)
+	printPermissionIssues(response.Issues)
+	fmt.Println()
+	fmt.Println("Custom keystores contain sensitive key material. We recommend permissions of 0700 for the directory and 0600 for the keystores in it.")
+
+	// Offer to tighten them
+	if !(c.Bool("fix") || cliutils.Confirm("Would you like to tighten these permissions now?")) {
+		fmt.Println("The permissions were left as they are.")
+		return nil
+	}
+	response, err = rp.AuditCustomKeyPermissions(true)
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	printPermissionIssues(response.Issues)
+	return nil
+
+}
+
+// Print each permission issue, along with whether it was fixed
+func printPermissionIssues(issues []api.CustomKeyPermissionIssue) {
+	for _, issue := range issues {
+		switch {
+		case issue.Error != "":
+			fmt.Printf("%s%s%s: %s (recommended %s) - could not change its permissions: %s\n", colorRed, issue.Path, colorReset, issue.Mode, issue.RecommendedMode, issue.Error)
+		case issue.Fixed:
+			fmt.Printf("%s%s%s: %s, tightened to remove group and other access.\n", colorGreen, issue.Path, colorReset, issue.Mode)
+		default:
+			fmt.Printf("%s: %s (recommended %s)\n", issue.Path, issue.Mode, issue.RecommendedMode)
+		}
+	}
+}
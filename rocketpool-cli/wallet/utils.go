@@ -24,14 +24,18 @@ import (
 const bold string = "\033[1m"
 const unbold string = "\033[0m"
 
-// Prompt for a wallet password
-func promptPassword() string {
+// Prompt for a wallet password that meets the password policy
+func promptPassword(policy passwords.PasswordPolicy) string {
 	for {
 		password := cliutils.PromptPassword(
 			"Please enter a password to secure your wallet with:",
 			fmt.Sprintf("^.{%d,}$", passwords.MinPasswordLength),
 			fmt.Sprintf("Your password must be at least %d characters long. Please try again:", passwords.MinPasswordLength),
 		)
+		if err := policy.Check(password); err != nil {
+			fmt.Printf("%s. Please try again.\n\n", err.Error())
+			continue
+		}
 		confirmation := cliutils.PromptPassword("Please confirm your password:", "^.*$", "")
 		if password == confirmation {
 			return password
@@ -0,0 +1,65 @@
+package wallet
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/urfave/cli"
+)
+
+func verifyCustomKeys(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c)
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("This will restart your Validator Client, which will briefly interrupt your validator duties. Are you sure you want to continue?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Restart the VC and check the keys
+	fmt.Println("Restarting your Validator Client and waiting for it to load its keys...")
+	response, err := rp.VerifyCustomKeysLoaded()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Your Validator Client has %d keys loaded.\n\n", response.LoadedKeyCount)
+
+	for _, file := range response.UnreadableFiles {
+		fmt.Printf("%s%s%s: could not be read as a validator keystore.\n", colorYellow, file, colorReset)
+	}
+	if len(response.Keys) == 0 {
+		fmt.Println("You don't have any custom validator keystores.")
+		return nil
+	}
+
+	// Print the results
+	missing := 0
+	for _, key := range response.Keys {
+		name := fmt.Sprintf("%s (%s)", key.File, key.Pubkey.Hex())
+		switch {
+		case !key.InValidatorStore:
+			fmt.Printf("%s: not in your validator keystores (it may have been purged), so it isn't expected to be loaded.\n", name)
+		case key.Loaded:
+			fmt.Printf("%s%s%s: loaded.\n", colorGreen, name, colorReset)
+		default:
+			missing++
+			fmt.Printf("%s%s%s: in your validator keystores but NOT loaded by your Validator Client.\n", colorRed, name, colorReset)
+		}
+	}
+	fmt.Println()
+
+	if missing > 0 {
+		fmt.Printf("%s%d custom key(s) failed to load. Check your Validator Client's logs for errors about these keys.%s\n", colorRed, missing, colorReset)
+	} else {
+		fmt.Println("All of your custom keys were loaded by your Validator Client.")
+	}
+	return nil
+
+}
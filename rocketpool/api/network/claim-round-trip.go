@@ -0,0 +1,128 @@
+package network
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Get the state of each step of the rewards pipeline for an interval, from the tree file through the node's claim.
+// This is only meant for integration testing, so it refuses to run on mainnet.
+func getClaimRoundTripStatus(c *cli.Context, index uint64) (*api.NetworkClaimRoundTripStatusResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Smartnode.Network.Value.(cfgtypes.Network) == cfgtypes.Network_Mainnet {
+		return nil, fmt.Errorf("The claim round-trip test is only available on test networks.")
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkClaimRoundTripStatusResponse{
+		Index: index,
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.NodeAddress = nodeAccount.Address
+
+	// Make sure the interval has been finalized
+	currentIndexBig, err := rewards.GetRewardIndex(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.CurrentIndex = currentIndexBig.Uint64()
+	if index >= response.CurrentIndex {
+		return &response, nil
+	}
+
+	// Check the root submission
+	response.IsTrusted, err = trustednode.GetMemberExists(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	if response.IsTrusted {
+		indexBuffer := make([]byte, 32)
+		big.NewInt(0).SetUint64(index).FillBytes(indexBuffer)
+		response.HasSubmittedRoot, err = rp.RocketStorage.GetBool(nil, crypto.Keccak256Hash([]byte("rewards.snapshot.submitted.node"), nodeAccount.Address.Bytes(), indexBuffer))
+		if err != nil {
+			return nil, fmt.Errorf("Error checking if the node submitted the root for interval %d: %w", index, err)
+		}
+	}
+
+	// Check the tree file and the node's rewards in it
+	intervalInfo, err := rprewards.GetIntervalInfo(rp, cfg, nodeAccount.Address, index)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting info for interval %d: %w", index, err)
+	}
+	response.TreeFilePath = intervalInfo.TreeFilePath
+	response.TreeFileExists = intervalInfo.TreeFileExists
+	response.MerkleRootValid = intervalInfo.MerkleRootValid
+	response.NodeExists = intervalInfo.NodeExists
+	if intervalInfo.NodeExists {
+		response.CollateralRpl = &intervalInfo.CollateralRplAmount.Int
+		response.OracleDaoRpl = &intervalInfo.ODaoRplAmount.Int
+		response.SmoothingPoolEth = &intervalInfo.SmoothingPoolEthAmount.Int
+
+		// Verify the proof against the root, which matches the canonical one since the root was valid
+		response.ProofValid, err = verifyNodeProofInFile(intervalInfo.TreeFilePath, nodeAccount.Address)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Check the claim status
+	_, claimed, err := rprewards.GetClaimStatus(rp, nodeAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting claim status: %w", err)
+	}
+	for _, claimedIndex := range claimed {
+		if claimedIndex == index {
+			response.IsClaimed = true
+			break
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Verify a node's Merkle proof against the root in a rewards tree file
+func verifyNodeProofInFile(path string, nodeAddress common.Address) (bool, error) {
+	rewardsFile, err := rprewards.LoadRewardsFile(path)
+	if err != nil {
+		return false, fmt.Errorf("Error loading rewards tree file: %w", err)
+	}
+	nodeRewards, exists := rewardsFile.NodeRewards[nodeAddress]
+	if !exists {
+		return false, nil
+	}
+	return rprewards.VerifyNodeMerkleProof(nodeAddress, nodeRewards, common.HexToHash(rewardsFile.MerkleRoot))
+}
@@ -1,6 +1,7 @@
 package network
 
 import (
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -53,6 +54,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "reward-interval-calendar",
+				Usage:     "Get the start and end of the current rewards interval, and the projected end of the next one",
+				UsageText: "rocketpool api network reward-interval-calendar",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getRewardIntervalCalendar(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "stats",
 				Aliases:   []string{"s"},
@@ -117,7 +136,100 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 			{
 				Name:      "generate-rewards-tree",
 				Usage:     "Set a request marker for the watchtower to generate the rewards tree for the given interval",
-				UsageText: "rocketpool api network generate-rewards-tree index",
+				UsageText: "rocketpool api network generate-rewards-tree [options] index threads",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output-dir",
+						Usage: "Save the generated files to this directory instead of the rewards trees folder",
+					},
+					cli.BoolFlag{
+						Name:  "proofs-only",
+						Usage: "Rebuild the Merkle proofs from the amounts in the existing tree file instead of recalculating the rewards",
+					},
+					cli.StringFlag{
+						Name:  "node-address",
+						Usage: "Only calculate and report the rewards for this node, without building the tree or saving any files",
+					},
+					cli.BoolFlag{
+						Name:  "force",
+						Usage: "Regenerate the tree even if the existing file already matches the canonical Merkle root",
+					},
+					cli.Uint64Flag{
+						Name:  "el-block",
+						Usage: "Generate the tree against this EL block instead of the one from the interval's snapshot event",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+
+					index, err := cliutils.ValidateUint("index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					threads, err := cliutils.ValidateUint("threads", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					var nodeAddress *common.Address
+					if c.String("node-address") != "" {
+						address, err := cliutils.ValidateAddress("node address", c.String("node-address"))
+						if err != nil {
+							return err
+						}
+						nodeAddress = &address
+					}
+
+					// Run
+					api.PrintResponse(generateRewardsTree(c, index, threads, c.String("output-dir"), c.Bool("proofs-only"), nodeAddress, c.Bool("force"), c.Uint64("el-block")))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "dry-run-rewards-tree",
+				Usage:     "Set a request marker for the watchtower to generate the rewards tree for the given interval and check its root without saving it",
+				UsageText: "rocketpool api network dry-run-rewards-tree [options] index threads",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "el-block",
+						Usage: "Generate the tree against this EL block instead of the one from the interval's snapshot event",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+
+					index, err := cliutils.ValidateUint("index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					threads, err := cliutils.ValidateUint("threads", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(dryRunRewardsTree(c, index, threads, c.Uint64("el-block")))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "verify-rewards-tree",
+				Usage:     "Set a request marker for the watchtower to generate the rewards tree for the given interval twice and check that both runs match",
+				UsageText: "rocketpool api network verify-rewards-tree index",
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -131,7 +243,89 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(generateRewardsTree(c, index))
+					api.PrintResponse(verifyRewardsTree(c, index))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "claim-round-trip-status",
+				Usage:     "Get the state of each step of the rewards pipeline for an interval on a test network, from the tree file through the node's claim",
+				UsageText: "rocketpool api network claim-round-trip-status index",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					index, err := cliutils.ValidateUint("index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getClaimRoundTripStatus(c, index))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "local-rewards-trees",
+				Usage:     "List the rewards tree files on disk and check each one's root against the canonical root",
+				UsageText: "rocketpool api network local-rewards-trees",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getLocalRewardsTrees(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "rewards-tree-generation-status",
+				Usage:     "Get the status of the watchtower's latest manual rewards tree generation",
+				UsageText: "rocketpool api network rewards-tree-generation-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getRewardsTreeGenerationStatus(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "watchtower-files",
+				Usage:     "List the watchtower's control files, optionally removing stale ones",
+				UsageText: "rocketpool api network watchtower-files clean",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					clean, err := cliutils.ValidateBool("clean", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getWatchtowerFiles(c, clean))
 					return nil
 
 				},
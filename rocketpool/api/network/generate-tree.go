@@ -3,11 +3,15 @@ package network
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/fatih/color"
 	"github.com/rocket-pool/rocketpool-go/rewards"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
 	"github.com/urfave/cli"
 )
 
@@ -39,7 +43,7 @@ func canGenerateRewardsTree(c *cli.Context, index uint64) (*api.CanNetworkGenera
 	response.CurrentIndex = currentIndexBig.Uint64()
 
 	// Get the path of the file to save
-	filePath := cfg.Smartnode.GetRewardsTreePath(index, true)
+	filePath := cfg.Smartnode.GetExistingRewardsTreePath(index, true)
 	_, err = os.Stat(filePath)
 	if os.IsNotExist(err) {
 		response.TreeFileExists = false
@@ -51,19 +55,95 @@ func canGenerateRewardsTree(c *cli.Context, index uint64) (*api.CanNetworkGenera
 
 }
 
-func generateRewardsTree(c *cli.Context, index uint64) (*api.NetworkGenerateRewardsTreeResponse, error) {
+func generateRewardsTree(c *cli.Context, index uint64, threads uint64, outputDir string, proofsOnly bool, nodeAddress *common.Address, force bool, elBlock uint64) (*api.NetworkGenerateRewardsTreeResponse, error) {
 
 	// Get services
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
 	cfg, err := services.GetConfig(c)
 	if err != nil {
 		return nil, err
 	}
 
 	// Response
-	response := api.NetworkGenerateRewardsTreeResponse{}
+	response := api.NetworkGenerateRewardsTreeResponse{
+		Index: index,
+	}
+
+	// Only intervals that have finished can be generated
+	currentIndexBig, err := rewards.GetRewardIndex(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	currentIndex := currentIndexBig.Uint64()
+	if index >= currentIndex {
+		return nil, fmt.Errorf("the current active rewards period is interval %d, so the tree for interval %d can't be generated until the active interval is past it", currentIndex, index)
+	}
+
+	// Rebuilding the proofs needs the existing tree file's amounts
+	if proofsOnly {
+		treePath := cfg.Smartnode.GetExistingRewardsTreePath(index, true)
+		_, err = os.Stat(treePath)
+		if err != nil {
+			_, err = os.Stat(cfg.Smartnode.GetRewardsTreePath(index, true) + config.RewardsTreeIpfsExtension)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("the proofs can't be rebuilt because the tree file for interval %d can't be found: %w", index, err)
+		}
+	}
+
+	// Rebuilding the proofs doesn't look at any EL state
+	if proofsOnly && elBlock > 0 {
+		return nil, fmt.Errorf("an EL block can't be combined with rebuilding the proofs, since the proofs are rebuilt from the existing tree file's amounts")
+	}
+
+	// Single-node runs don't save anything
+	if nodeAddress != nil && (proofsOnly || outputDir != "") {
+		return nil, fmt.Errorf("a node address can't be combined with rebuilding the proofs or an output directory, since only that node's rewards are reported and no files are saved")
+	}
+
+	// Make sure the output directory can be written to
+	if outputDir != "" {
+		err = files.CheckDirWritable(outputDir)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// Create the generation request
 	requestPath := cfg.Smartnode.GetRegenerateRewardsTreeRequestPath(index, true)
+	err = writeRewardsTreeRequest(requestPath, config.RewardsTreeRequest{
+		Threads:     threads,
+		OutputDir:   outputDir,
+		ProofsOnly:  proofsOnly,
+		NodeAddress: nodeAddress,
+		Force:       force,
+		ElBlock:     elBlock,
+	})
+	if err != nil {
+		return nil, err
+	}
+	response.RequestFile = filepath.Base(requestPath)
+
+	return &response, nil
+
+}
+
+func verifyRewardsTree(c *cli.Context, index uint64) (*api.NetworkVerifyRewardsTreeResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkVerifyRewardsTreeResponse{}
+
+	// Create the verification request
+	requestPath := cfg.Smartnode.GetVerifyRewardsTreeRequestPath(index, true)
 	requestFile, err := os.Create(requestPath)
 	if requestFile != nil {
 		requestFile.Close()
@@ -75,3 +155,37 @@ func generateRewardsTree(c *cli.Context, index uint64) (*api.NetworkGenerateRewa
 	return &response, nil
 
 }
+
+func dryRunRewardsTree(c *cli.Context, index uint64, threads uint64, elBlock uint64) (*api.NetworkDryRunRewardsTreeResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkDryRunRewardsTreeResponse{}
+
+	// Create the dry run request
+	requestPath := cfg.Smartnode.GetRegenerateRewardsTreeDryRunRequestPath(index, true)
+	err = writeRewardsTreeRequest(requestPath, config.RewardsTreeRequest{
+		Threads: threads,
+		ElBlock: elBlock,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+
+}
+
+// Create a request marker for the watchtower, storing the request's options in it
+func writeRewardsTreeRequest(requestPath string, request config.RewardsTreeRequest) error {
+	err := os.WriteFile(requestPath, request.Serialize(), 0644)
+	if err != nil {
+		return fmt.Errorf("Error creating request marker: %w", err)
+	}
+	return nil
+}
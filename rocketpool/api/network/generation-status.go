@@ -0,0 +1,43 @@
+package network
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Get the status of the watchtower's latest manual rewards tree generation
+func getRewardsTreeGenerationStatus(c *cli.Context) (*api.NetworkRewardsTreeGenerationStatusResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkRewardsTreeGenerationStatusResponse{}
+
+	// Read the status the watchtower published
+	status, err := rprewards.LoadGenerationStatus(cfg.Smartnode.GetRewardsTreeGenerationStatusPath(true))
+	if err != nil {
+		return nil, err
+	}
+	if status == nil {
+		return &response, nil
+	}
+	response.HasRun = true
+	response.Running = status.Running
+	response.Interval = status.Interval
+	response.Stage = string(status.Stage)
+	response.Progress = status.Progress
+	response.StartTime = status.StartTime
+	response.LastUpdated = status.Updated
+	response.FailureKind = status.FailureKind
+	response.FailureMessage = status.FailureMessage
+
+	return &response, nil
+
+}
@@ -0,0 +1,104 @@
+package network
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Get every rewards tree file on disk, comparing each one's root against the canonical root for its interval
+func getLocalRewardsTrees(c *cli.Context) (*api.NetworkLocalRewardsTreesResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkLocalRewardsTreesResponse{
+		Trees: []api.LocalRewardsTreeInfo{},
+	}
+
+	// Find the tree files for this network
+	treeDir := filepath.Dir(cfg.Smartnode.GetRewardsTreePath(0, true))
+	entries, err := os.ReadDir(treeDir)
+	if os.IsNotExist(err) {
+		return &response, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error enumerating rewards trees in %s: %w", treeDir, err)
+	}
+	network := string(cfg.Smartnode.Network.Value.(cfgtypes.Network))
+	prefix := strings.SplitN(config.RewardsTreeFilenameFormat, "%", 2)[0] + network + "-"
+	extension := filepath.Ext(config.RewardsTreeFilenameFormat)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, extension) {
+			continue
+		}
+		index, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimPrefix(name, prefix), extension), 10, 64)
+		if err != nil {
+			continue
+		}
+		response.Trees = append(response.Trees, api.LocalRewardsTreeInfo{
+			Index:    index,
+			FileName: name,
+		})
+	}
+	sort.Slice(response.Trees, func(i, j int) bool {
+		return response.Trees[i].Index < response.Trees[j].Index
+	})
+
+	// Compare each one against the chain
+	currentIndexBig, err := rewards.GetRewardIndex(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	currentIndex := currentIndexBig.Uint64()
+	for i := range response.Trees {
+		tree := &response.Trees[i]
+		rewardsFile, err := rprewards.LoadRewardsFile(filepath.Join(treeDir, tree.FileName))
+		if err != nil {
+			tree.Error = err.Error()
+			continue
+		}
+		tree.FileRoot = rewardsFile.MerkleRoot
+
+		// Intervals that haven't been finalized don't have a canonical root yet
+		if tree.Index >= currentIndex {
+			continue
+		}
+		tree.Finalized = true
+		rewardsEvent, err := rprewards.GetRewardSnapshotEvent(rp, cfg, tree.Index)
+		if err != nil {
+			tree.Error = fmt.Sprintf("error getting the event for interval %d: %s", tree.Index, err.Error())
+			continue
+		}
+		tree.CanonicalRoot = rewardsEvent.MerkleRoot.Hex()
+		tree.Matches = (common.HexToHash(tree.FileRoot) == rewardsEvent.MerkleRoot)
+	}
+
+	return &response, nil
+
+}
@@ -0,0 +1,105 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Get the timing of the current rewards interval and the one after it
+func getRewardIntervalCalendar(c *cli.Context) (*api.RewardIntervalCalendarResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RewardIntervalCalendarResponse{}
+
+	// Sync
+	var wg errgroup.Group
+
+	// Get data
+	wg.Go(func() error {
+		index, err := rewards.GetRewardIndex(rp, nil)
+		if err == nil {
+			response.CurrentIndex = index.Uint64()
+		}
+		return err
+	})
+	wg.Go(func() error {
+		startTime, err := rewards.GetClaimIntervalTimeStart(rp, nil)
+		if err == nil {
+			response.IntervalStart = startTime
+		}
+		return err
+	})
+	wg.Go(func() error {
+		intervalTime, err := rewards.GetClaimIntervalTime(rp, nil)
+		if err == nil {
+			response.IntervalTime = intervalTime
+		}
+		return err
+	})
+	wg.Go(func() error {
+		header, err := ec.HeaderByNumber(context.Background(), nil)
+		if err == nil {
+			response.LatestBlockTime = time.Unix(int64(header.Time), 0)
+		}
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+	if response.IntervalTime == 0 {
+		return nil, fmt.Errorf("the rewards interval time is 0")
+	}
+
+	// The current interval ends after the number of intervals that have gone by since its start, the same way the
+	// Oracle DAO picks the end time; if none have, it ends one interval time after its start
+	intervalsPassed := response.LatestBlockTime.Sub(response.IntervalStart) / response.IntervalTime
+	response.IntervalsPassed = uint64(intervalsPassed)
+	if intervalsPassed < 1 {
+		intervalsPassed = 1
+	}
+	response.IntervalEnd = response.IntervalStart.Add(response.IntervalTime * intervalsPassed)
+	response.NextIntervalEnd = response.IntervalEnd.Add(response.IntervalTime)
+
+	// Cross-check the start against the end of the previous interval's rewards event; this is best-effort since
+	// some clients can't look that far back in the event logs
+	if response.CurrentIndex > 0 {
+		event, err := rprewards.GetRewardSnapshotEvent(rp, cfg, response.CurrentIndex-1)
+		if err == nil {
+			response.PreviousIntervalFound = true
+			response.PreviousIntervalStart = event.IntervalStartTime
+			response.PreviousIntervalEnd = event.IntervalEndTime
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
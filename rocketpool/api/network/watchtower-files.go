@@ -0,0 +1,185 @@
+package network
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/urfave/cli"
+)
+
+func getWatchtowerFiles(c *cli.Context, clean bool) (*api.NetworkWatchtowerFilesResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NetworkWatchtowerFilesResponse{
+		Files: []api.WatchtowerFileInfo{},
+	}
+
+	// Read the watchtower folder
+	watchtowerFolder := cfg.Smartnode.GetWatchtowerFolder(true)
+	entries, err := os.ReadDir(watchtowerFolder)
+	if os.IsNotExist(err) {
+		return &response, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error enumerating files in the watchtower folder: %w", err)
+	}
+
+	now := time.Now()
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("Error getting info for watchtower file %s: %w", entry.Name(), err)
+		}
+
+		fileInfo := classifyWatchtowerFile(cfg, entry.Name(), info)
+		fileInfo.Age = now.Sub(info.ModTime())
+
+		// Only stale control files are ever removed, active state is left alone
+		if clean && fileInfo.IsStale {
+			err = os.Remove(filepath.Join(watchtowerFolder, entry.Name()))
+			if err != nil {
+				return nil, fmt.Errorf("Error removing stale watchtower file %s: %w", entry.Name(), err)
+			}
+			fileInfo.Removed = true
+		}
+		response.Files = append(response.Files, fileInfo)
+	}
+
+	return &response, nil
+
+}
+
+// Determine the purpose of a file in the watchtower folder and whether or not it's stale
+func classifyWatchtowerFile(cfg *config.RocketPoolConfig, name string, info os.FileInfo) api.WatchtowerFileInfo {
+
+	fileInfo := api.WatchtowerFileInfo{
+		Name: name,
+	}
+
+	switch {
+	case name == config.WatchtowerStateFile:
+		fileInfo.Purpose = "Watchtower state"
+
+	case name == config.WatchtowerHeartbeatFile:
+		fileInfo.Purpose = "Watchtower task heartbeat"
+
+	case name == config.RewardsTreeGenerationStatusFile:
+		fileInfo.Purpose = "Status of the latest rewards tree generation"
+
+	case name == config.UnsignedChallengeResponseFile:
+		fileInfo.Purpose = "Unsigned challenge response for offline signing"
+
+	case strings.HasSuffix(name, config.RegenerateRewardsTreeRequestSuffix):
+		fileInfo.Purpose = "Rewards tree generation request"
+		intervals, err := config.ParseRewardsTreeRequestIntervals(strings.TrimSuffix(name, config.RegenerateRewardsTreeRequestSuffix))
+		if err != nil {
+			fileInfo.Purpose = "Malformed rewards tree generation request"
+			fileInfo.IsStale = true
+			break
+		}
+		if len(intervals) > 1 {
+			fileInfo.Purpose = fmt.Sprintf("Rewards tree generation request for intervals %d to %d", intervals[0], intervals[len(intervals)-1])
+		}
+		fileInfo.Interval = intervals[0]
+
+		// A request is stale if every tree it asked for has already been written since
+		fileInfo.IsStale = true
+		for _, index := range intervals {
+			if !isIntervalCompletedSince(cfg, index, info.ModTime()) {
+				fileInfo.IsStale = false
+				break
+			}
+		}
+
+	case strings.HasSuffix(name, config.VerifyRewardsTreeRequestSuffix):
+		fileInfo.Purpose = "Rewards tree determinism verification request"
+		index, err := strconv.ParseUint(strings.TrimSuffix(name, config.VerifyRewardsTreeRequestSuffix), 0, 64)
+		if err != nil {
+			fileInfo.Purpose = "Malformed rewards tree determinism verification request"
+			fileInfo.IsStale = true
+			break
+		}
+		fileInfo.Interval = index
+
+	case strings.HasSuffix(name, config.RegenerateRewardsTreeDryRunSuffix):
+		fileInfo.Purpose = "Rewards tree generation dry run request"
+		index, err := strconv.ParseUint(strings.TrimSuffix(name, config.RegenerateRewardsTreeDryRunSuffix), 0, 64)
+		if err != nil {
+			fileInfo.Purpose = "Malformed rewards tree generation dry run request"
+			fileInfo.IsStale = true
+			break
+		}
+		fileInfo.Interval = index
+
+	case strings.HasSuffix(name, config.RewardsTreeCheckpointSuffix):
+		fileInfo.Purpose = "Checkpoint from an interrupted rewards tree generation"
+		index, err := strconv.ParseUint(strings.TrimSuffix(name, config.RewardsTreeCheckpointSuffix), 0, 64)
+		if err != nil {
+			fileInfo.Purpose = "Malformed rewards tree generation checkpoint"
+			fileInfo.IsStale = true
+			break
+		}
+		fileInfo.Interval = index
+
+		// A checkpoint is stale if the tree it was for has been finished since
+		fileInfo.IsStale = isIntervalCompletedSince(cfg, index, info.ModTime())
+
+	case strings.HasSuffix(name, config.RewardsTreeNodeSnapshotSuffix):
+		fileInfo.Purpose = "Node snapshot for incremental rewards tree generation"
+		index, err := strconv.ParseUint(strings.TrimSuffix(name, config.RewardsTreeNodeSnapshotSuffix), 0, 64)
+		if err != nil {
+			fileInfo.Purpose = "Malformed rewards tree node snapshot"
+			fileInfo.IsStale = true
+			break
+		}
+		fileInfo.Interval = index
+
+		// Only the latest snapshot is built on, so one is stale once the next interval has its own
+		_, err = os.Stat(cfg.Smartnode.GetRewardsTreeNodeSnapshotPath(index+1, true))
+		fileInfo.IsStale = (err == nil)
+
+	case strings.HasSuffix(name, config.RewardsTreeGeneratingSuffix):
+		fileInfo.Purpose = "Lock for a rewards tree generation in progress"
+		index, err := strconv.ParseUint(strings.TrimSuffix(name, config.RewardsTreeGeneratingSuffix), 0, 64)
+		if err != nil {
+			fileInfo.Purpose = "Malformed rewards tree generation lock"
+			fileInfo.IsStale = true
+			break
+		}
+		fileInfo.Interval = index
+
+		// Running generations keep their lock fresh, so an old one was left behind by a crash
+		fileInfo.IsStale = time.Since(info.ModTime()) >= config.RewardsTreeGeneratingLockTimeout
+
+	default:
+		fileInfo.Purpose = "Unknown"
+	}
+
+	return fileInfo
+
+}
+
+// Check if the rewards tree for an interval was written after the given time
+func isIntervalCompletedSince(cfg *config.RocketPoolConfig, index uint64, since time.Time) bool {
+	treeInfo, err := os.Stat(cfg.Smartnode.GetExistingRewardsTreePath(index, true))
+	if err != nil {
+		return false
+	}
+	return treeInfo.ModTime().After(since)
+}
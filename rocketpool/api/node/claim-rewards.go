@@ -359,6 +359,12 @@ func getRewardsForIntervals(rp *rocketpool.RocketPool, cfg *config.RocketPoolCon
 			return nil, nil, nil, nil, fmt.Errorf("merkle root for rewards tree file '%s' doesn't match the canonical merkle root for interval %d", intervalInfo.TreeFilePath, index.Uint64())
 		}
 
+		// The recorded root can be right even if the rewards were tampered with, so recompute it before claiming
+		err = rprewards.VerifyRewardsFile(intervalInfo.RewardsFile, intervalInfo.TreeFilePath, index.Uint64(), intervalInfo.MerkleRoot)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+
 		// Get the rewards from it
 		if intervalInfo.NodeExists {
 			rplForInterval := big.NewInt(0)
@@ -1065,6 +1065,44 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "update-rewards-watch-file",
+				Usage:     "Add any new intervals to the node's rewards watch file, which holds its rewards and Merkle proofs for every interval",
+				UsageText: "rocketpool api node update-rewards-watch-file",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(updateRewardsWatchFile(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "reward-rank",
+				Usage:     "Get the node's RPL reward rank among all nodes in an interval",
+				UsageText: "rocketpool api node reward-rank index",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					index, err := cliutils.ValidateUint("index", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getRewardRank(c, index))
+					return nil
+
+				},
+			},
 			{
 				Name:      "can-claim-rewards",
 				Usage:     "Check if the rewards for the given intervals can be claimed",
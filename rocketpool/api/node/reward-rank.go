@@ -0,0 +1,65 @@
+package node
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getRewardRank(c *cli.Context, index uint64) (*api.NodeRewardRankResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeRewardRankResponse{
+		Index: index,
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the tree file
+	response.TreeFilePath = cfg.Smartnode.GetExistingRewardsTreePath(index, true)
+	_, err = os.Stat(response.TreeFilePath)
+	if os.IsNotExist(err) {
+		return &response, nil
+	}
+	response.TreeFileExists = true
+	rewardsFile, err := rprewards.LoadRewardsFile(response.TreeFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("Error loading rewards tree file: %w", err)
+	}
+
+	// Rank the node
+	rank := rprewards.GetNodeRewardRank(rewardsFile, nodeAccount.Address)
+	response.NodeExists = rank.NodeExists
+	response.NodeRpl = rank.NodeRpl
+	response.Rank = rank.Rank
+	response.NodeCount = rank.NodeCount
+	response.Percentile = rank.Percentile
+	response.MedianRpl = rank.MedianRpl
+	response.MaxRpl = rank.MaxRpl
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,51 @@
+package node
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func updateRewardsWatchFile(c *cli.Context) (*api.NodeUpdateRewardsWatchFileResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeUpdateRewardsWatchFileResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Update the watch file
+	response.AddedIntervals, err = rprewards.UpdateRewardsWatchFile(rp, cfg, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	response.WatchFilePath = cfg.Smartnode.GetRewardsWatchFilePath(false)
+
+	return &response, nil
+
+}
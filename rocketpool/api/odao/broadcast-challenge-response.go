@@ -0,0 +1,94 @@
+package odao
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Broadcast a challenge response that was exported by the watchtower and signed offline
+func broadcastChallengeResponse(c *cli.Context, signedTx string) (*api.BroadcastChallengeResponseResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.BroadcastChallengeResponseResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Deserialize the transaction
+	txBytes, err := hexutil.Decode(signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("The signed transaction is not valid hex: %w", err)
+	}
+	tx := new(types.Transaction)
+	err = tx.UnmarshalBinary(txBytes)
+	if err != nil {
+		return nil, fmt.Errorf("Could not deserialize the signed transaction: %w", err)
+	}
+
+	// Make sure it's a challenge response from this node on this network
+	chainID := big.NewInt(int64(cfg.Smartnode.GetChainID()))
+	if tx.ChainId().Cmp(chainID) != 0 {
+		return nil, fmt.Errorf("The signed transaction is for chain %s, but this node is on chain %s.", tx.ChainId().String(), chainID.String())
+	}
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+	if err != nil {
+		return nil, fmt.Errorf("Could not recover the signer of the transaction: %w", err)
+	}
+	if sender != nodeAccount.Address {
+		return nil, fmt.Errorf("The transaction was signed by %s, but it must be signed by the node account %s.", sender.Hex(), nodeAccount.Address.Hex())
+	}
+	actionsContract, err := rp.GetContract("rocketDAONodeTrustedActions", nil)
+	if err != nil {
+		return nil, err
+	}
+	if tx.To() == nil || *tx.To() != *actionsContract.Address {
+		return nil, fmt.Errorf("The transaction is not addressed to the Oracle DAO actions contract (%s).", actionsContract.Address.Hex())
+	}
+	methodID := actionsContract.ABI.Methods["actionChallengeDecide"].ID
+	if len(tx.Data()) < len(methodID) || !bytes.Equal(tx.Data()[:len(methodID)], methodID) {
+		return nil, fmt.Errorf("The transaction is not a challenge response.")
+	}
+
+	// Broadcast it
+	err = rp.Client.SendTransaction(context.Background(), tx)
+	if err != nil {
+		return nil, fmt.Errorf("Could not broadcast the challenge response: %w", err)
+	}
+	response.TxHash = tx.Hash()
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,139 @@
+package odao
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	tnsettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getChallengeStatus(c *cli.Context) (*api.TNDAOChallengeStatusResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TNDAOChallengeStatusResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.Address = nodeAccount.Address
+
+	// Check membership status
+	isMember, err := trustednode.GetMemberExists(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.IsMember = isMember
+	if !isMember {
+		return &response, nil
+	}
+
+	// Sync
+	var wg errgroup.Group
+
+	// Get the challenge status
+	wg.Go(func() error {
+		isChallenged, err := trustednode.GetMemberIsChallenged(rp, nodeAccount.Address, nil)
+		if err == nil {
+			response.IsChallenged = isChallenged
+		}
+		return err
+	})
+
+	// Get the block the challenge was made in
+	wg.Go(func() error {
+		challengeBlock, err := getMemberChallengeBlock(rp, nodeAccount.Address.Bytes())
+		if err == nil {
+			response.ChallengeBlock = challengeBlock
+		}
+		return err
+	})
+
+	// Get the challenge window
+	wg.Go(func() error {
+		challengeWindow, err := tnsettings.GetChallengeWindow(rp, nil)
+		if err == nil {
+			response.ChallengeWindow = challengeWindow
+		}
+		return err
+	})
+
+	// Get the current block
+	wg.Go(func() error {
+		currentBlock, err := rp.Client.BlockNumber(context.Background())
+		if err == nil {
+			response.CurrentBlock = currentBlock
+		}
+		return err
+	})
+
+	// Get the current gas price
+	wg.Go(func() error {
+		gasPrice, err := rp.Client.SuggestGasPrice(context.Background())
+		if err == nil {
+			response.GasPrice = gasPrice
+		}
+		return err
+	})
+
+	// Get the block time
+	wg.Go(func() error {
+		eth2Config, err := bc.GetEth2Config()
+		if err == nil {
+			response.SecondsPerBlock = eth2Config.SecondsPerSlot
+		}
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Get the deadline
+	if response.IsChallenged {
+		response.DeadlineBlock = response.ChallengeBlock + response.ChallengeWindow
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the block a member was challenged in from RocketStorage, since there isn't a contract getter for it
+func getMemberChallengeBlock(rp *rocketpool.RocketPool, memberAddress []byte) (uint64, error) {
+	challengeBlock, err := rp.RocketStorage.GetUint(nil, crypto.Keccak256Hash([]byte("dao.trustednodes.member.challenged.block"), memberAddress))
+	if err != nil {
+		return 0, fmt.Errorf("Could not get member challenge block: %w", err)
+	}
+	return challengeBlock.Uint64(), nil
+}
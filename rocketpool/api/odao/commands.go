@@ -53,6 +53,86 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "challenge-status",
+				Aliases:   []string{"cs"},
+				Usage:     "Get the status of any challenge against the node, including the deadline to respond to it",
+				UsageText: "rocketpool api odao challenge-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getChallengeStatus(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "broadcast-challenge-response",
+				Usage:     "Broadcast a challenge response that was exported by the watchtower and signed offline",
+				UsageText: "rocketpool api odao broadcast-challenge-response signed-tx",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(broadcastChallengeResponse(c, c.Args().Get(0)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "can-decide-challenge",
+				Usage:     "Check whether the node can decide the challenge against a member",
+				UsageText: "rocketpool api odao can-decide-challenge member-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					memberAddress, err := cliutils.ValidateAddress("member address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canDecideChallenge(c, memberAddress))
+					return nil
+
+				},
+			},
+			{
+				Name:      "decide-challenge",
+				Usage:     "Decide the challenge against a member whose response window has passed, removing them from the oracle DAO",
+				UsageText: "rocketpool api odao decide-challenge member-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					memberAddress, err := cliutils.ValidateAddress("member address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(decideChallenge(c, memberAddress))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "proposals",
 				Aliases:   []string{"p"},
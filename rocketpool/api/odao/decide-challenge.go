@@ -0,0 +1,157 @@
+package odao
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	tnsettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+func canDecideChallenge(c *cli.Context, memberAddress common.Address) (*api.CanDecideTNDAOChallengeResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeTrusted(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanDecideTNDAOChallengeResponse{}
+
+	// Check that the member exists
+	isMember, err := trustednode.GetMemberExists(rp, memberAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.MemberDoesNotExist = !isMember
+	if !isMember {
+		return &response, nil
+	}
+
+	// Sync
+	var wg errgroup.Group
+	var challengedTime uint64
+	var challengeWindow uint64
+
+	// Get the challenge status
+	wg.Go(func() error {
+		isChallenged, err := trustednode.GetMemberIsChallenged(rp, memberAddress, nil)
+		if err == nil {
+			response.NotChallenged = !isChallenged
+		}
+		return err
+	})
+
+	// Get the time the challenge was made
+	wg.Go(func() error {
+		var err error
+		challengedTime, err = getMemberChallengedTime(rp, memberAddress)
+		return err
+	})
+
+	// Get the challenge window
+	wg.Go(func() error {
+		var err error
+		challengeWindow, err = tnsettings.GetChallengeWindow(rp, nil)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+	if response.NotChallenged {
+		return &response, nil
+	}
+
+	// Check that the member can no longer respond
+	response.ExpiryTime = time.Unix(int64(challengedTime+challengeWindow), 0)
+	response.WindowActive = !time.Now().After(response.ExpiryTime)
+	if response.WindowActive {
+		return &response, nil
+	}
+
+	// Get gas estimate
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+	gasInfo, err := trustednode.EstimateDecideChallengeGas(rp, memberAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.GasInfo = gasInfo
+
+	// Update & return response
+	response.CanDecide = true
+	return &response, nil
+
+}
+
+func decideChallenge(c *cli.Context, memberAddress common.Address) (*api.DecideTNDAOChallengeResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeTrusted(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.DecideTNDAOChallengeResponse{}
+
+	// Get transactor
+	opts, err := w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Override the provided pending TX if requested
+	err = eth1.CheckForNonceOverride(c, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Error checking for nonce override: %w", err)
+	}
+
+	// Decide the challenge
+	hash, err := trustednode.DecideChallenge(rp, memberAddress, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.TxHash = hash
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the time a challenge was made against a member from RocketStorage, since there isn't a contract getter for it
+func getMemberChallengedTime(rp *rocketpool.RocketPool, memberAddress common.Address) (uint64, error) {
+	challengedTime, err := rp.RocketStorage.GetUint(nil, crypto.Keccak256Hash([]byte("dao.trustednodes."), []byte("member.challenged.time"), memberAddress.Bytes()))
+	if err != nil {
+		return 0, fmt.Errorf("Could not get the challenge time for node %s: %w", memberAddress.Hex(), err)
+	}
+	return challengedTime.Uint64(), nil
+}
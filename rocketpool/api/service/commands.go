@@ -51,6 +51,44 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "validate-containers",
+				Aliases:   []string{"v"},
+				Usage:     "Checks that the Smartnode's Docker containers exist and match the configured images, ports, and volume mounts",
+				UsageText: "rocketpool api service validate-containers",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(validateContainers(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "self-test",
+				Aliases:   []string{"s"},
+				Usage:     "Checks that the node can reach its Execution client, its Beacon node, and the Rocket Pool contracts",
+				UsageText: "rocketpool api service self-test",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(runSelfTest(c))
+					return nil
+
+				},
+			},
 		},
 	})
 }
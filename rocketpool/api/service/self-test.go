@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Checks that the node can reach its Execution client, its Beacon node, and the Rocket Pool contracts. Each failure is
+// reported as a failed check instead of an error, so every check runs even if an earlier one fails.
+func runSelfTest(c *cli.Context) (*api.SelfTestResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.SelfTestResponse{
+		Checks: []api.SelfTestCheck{},
+	}
+	addCheck := func(name string, err error, details string) {
+		check := api.SelfTestCheck{
+			Name:    name,
+			Passed:  (err == nil),
+			Details: details,
+		}
+		if err != nil {
+			check.Details = err.Error()
+		}
+		response.Checks = append(response.Checks, check)
+	}
+
+	// Check the EC's sync status
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		addCheck("Execution client", fmt.Errorf("error connecting: %w", err), "")
+	} else {
+		progress, err := ec.SyncProgress(context.Background())
+		if err != nil {
+			addCheck("Execution client", fmt.Errorf("error getting sync status: %w", err), "")
+		} else if progress != nil {
+			addCheck("Execution client", fmt.Errorf("still syncing (block %d of %d)", progress.CurrentBlock, progress.HighestBlock), "")
+		} else {
+			blockNumber, err := ec.BlockNumber(context.Background())
+			if err != nil {
+				addCheck("Execution client", fmt.Errorf("error getting the latest block: %w", err), "")
+			} else {
+				addCheck("Execution client", nil, fmt.Sprintf("synced, latest block is %d", blockNumber))
+			}
+		}
+	}
+
+	// Check the Beacon node's config
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		addCheck("Beacon node", fmt.Errorf("error connecting: %w", err), "")
+	} else {
+		eth2Config, err := bc.GetEth2Config()
+		if err != nil {
+			addCheck("Beacon node", fmt.Errorf("error getting the Beacon config: %w", err), "")
+		} else {
+			genesisTime := time.Unix(int64(eth2Config.GenesisTime), 0).UTC()
+			addCheck("Beacon node", nil, fmt.Sprintf("genesis time %s, %d slots per epoch", genesisTime.Format(time.RFC3339), eth2Config.SlotsPerEpoch))
+		}
+	}
+
+	// Check that the Rocket Pool contracts can be read, using the rETH address as a known value
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		addCheck("Rocket Pool contracts", fmt.Errorf("error connecting: %w", err), "")
+	} else {
+		address, err := rp.RocketStorage.GetAddress(nil, crypto.Keccak256Hash([]byte("contract.addressrocketTokenRETH")))
+		expectedAddress := cfg.Smartnode.GetRethAddress()
+		if err != nil {
+			addCheck("Rocket Pool contracts", fmt.Errorf("error reading the rETH address from RocketStorage: %w", err), "")
+		} else if address != expectedAddress {
+			addCheck("Rocket Pool contracts", fmt.Errorf("RocketStorage reported %s as the rETH address, but it should be %s; your clients may be on the wrong network", address.Hex(), expectedAddress.Hex()), "")
+		} else {
+			addCheck("Rocket Pool contracts", nil, fmt.Sprintf("rETH address is %s", address.Hex()))
+		}
+	}
+
+	return &response, nil
+
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// A container the config expects to exist
+type expectedContainer struct {
+	name       string
+	image      string
+	ports      []uint16
+	dataMounts map[string]string
+}
+
+// Checks that the Docker containers match what the config expects
+func validateContainers(c *cli.Context) (*api.ValidateContainersResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.IsNativeMode {
+		return nil, fmt.Errorf("Container validation is not available in Native mode.")
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ValidateContainersResponse{
+		Containers: []api.ContainerValidation{},
+	}
+
+	// Get all containers
+	containers, err := d.ContainerList(context.Background(), types.ContainerListOptions{All: true})
+	if err != nil {
+		return nil, fmt.Errorf("Could not get docker containers: %w", err)
+	}
+	containerMap := map[string]types.Container{}
+	for _, container := range containers {
+		for _, name := range container.Names {
+			containerMap[name] = container
+		}
+	}
+
+	// Check each of the expected containers
+	for _, expected := range getExpectedContainers(cfg) {
+		validation := api.ContainerValidation{
+			Name:          expected.name,
+			ExpectedImage: expected.image,
+			Issues:        []string{},
+		}
+
+		container, exists := containerMap["/"+expected.name]
+		if !exists {
+			validation.Issues = append(validation.Issues, "container does not exist")
+			response.Containers = append(response.Containers, validation)
+			continue
+		}
+		validation.Exists = true
+		validation.Running = (container.State == "running")
+		validation.ActualImage = container.Image
+		if !validation.Running {
+			validation.Issues = append(validation.Issues, fmt.Sprintf("container is %s", container.State))
+		}
+		if expected.image != "" && container.Image != expected.image {
+			validation.Issues = append(validation.Issues, fmt.Sprintf("container is using image %s instead of %s", container.Image, expected.image))
+		}
+
+		// Check the published ports
+		for _, port := range expected.ports {
+			found := false
+			for _, containerPort := range container.Ports {
+				if containerPort.PublicPort == port {
+					found = true
+					break
+				}
+			}
+			if !found {
+				validation.Issues = append(validation.Issues, fmt.Sprintf("port %d is not published", port))
+			}
+		}
+
+		// Check the volume mounts
+		for destination, source := range expected.dataMounts {
+			found := false
+			for _, mount := range container.Mounts {
+				if mount.Destination == destination {
+					found = true
+					if mount.Source != source {
+						validation.Issues = append(validation.Issues, fmt.Sprintf("%s is mounted from %s instead of %s", destination, mount.Source, source))
+					}
+					break
+				}
+			}
+			if !found {
+				validation.Issues = append(validation.Issues, fmt.Sprintf("%s is not mounted", destination))
+			}
+		}
+
+		response.Containers = append(response.Containers, validation)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the containers the config expects to exist, along with their images, published ports, and data mounts
+func getExpectedContainers(cfg *config.RocketPoolConfig) []expectedContainer {
+
+	envVars := cfg.GenerateEnvironmentVariables()
+	prefix := cfg.Smartnode.ProjectName.Value.(string) + "_"
+	dataPath := cfg.Smartnode.DataPath.Value.(string)
+	daemonMounts := map[string]string{
+		config.DaemonDataPath: dataPath,
+	}
+
+	expected := []expectedContainer{
+		{name: prefix + config.ApiContainerName, image: envVars["SMARTNODE_IMAGE"], dataMounts: daemonMounts},
+		{name: prefix + config.NodeContainerName, image: envVars["SMARTNODE_IMAGE"], dataMounts: daemonMounts},
+		{name: prefix + config.WatchtowerContainerName, image: envVars["SMARTNODE_IMAGE"], dataMounts: daemonMounts},
+	}
+
+	// Execution client
+	if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		expected = append(expected, expectedContainer{
+			name:  prefix + config.Eth1ContainerName,
+			image: envVars["EC_CONTAINER_TAG"],
+			ports: []uint16{cfg.ExecutionCommon.P2pPort.Value.(uint16)},
+		})
+	}
+
+	// Consensus and validator clients
+	consensusClient, consensusMode := cfg.GetSelectedConsensusClient()
+	if consensusMode == cfgtypes.Mode_Local {
+		expected = append(expected, expectedContainer{
+			name:  prefix + config.Eth2ContainerName,
+			image: envVars["BN_CONTAINER_TAG"],
+			ports: []uint16{cfg.ConsensusCommon.P2pPort.Value.(uint16)},
+		})
+	}
+	if consensusMode == cfgtypes.Mode_External || consensusClient != cfgtypes.ConsensusClient_Nimbus {
+		// Nimbus runs its validator client inside the beacon node container
+		expected = append(expected, expectedContainer{
+			name:  prefix + config.ValidatorContainerName,
+			image: envVars["VC_CONTAINER_TAG"],
+		})
+	}
+
+	// Metrics
+	if cfg.EnableMetrics.Value == true {
+		expected = append(expected,
+			expectedContainer{
+				name:  prefix + config.GrafanaContainerName,
+				image: envVars["GRAFANA_CONTAINER_TAG"],
+				ports: []uint16{cfg.Grafana.Port.Value.(uint16)},
+			},
+			expectedContainer{
+				name:  prefix + config.PrometheusContainerName,
+				image: envVars["PROMETHEUS_CONTAINER_TAG"],
+			},
+			expectedContainer{
+				name:  prefix + config.ExporterContainerName,
+				image: envVars["EXPORTER_CONTAINER_TAG"],
+			},
+		)
+	}
+
+	// MEV-Boost
+	if cfg.EnableMevBoost.Value == true && cfg.MevBoost.Mode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		expected = append(expected, expectedContainer{
+			name:  prefix + config.MevBoostContainerName,
+			image: envVars["MEV_BOOST_CONTAINER_TAG"],
+		})
+	}
+
+	return expected
+
+}
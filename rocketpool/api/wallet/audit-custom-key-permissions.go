@@ -0,0 +1,88 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The permissions recommended for the custom key directory and the keystores in it
+const (
+	customKeyDirMode  os.FileMode = 0700
+	customKeyFileMode os.FileMode = 0600
+)
+
+// Check that the custom key directory and its keystores can't be read by the group or by other users, optionally
+// tightening the permissions of anything that can
+func auditCustomKeyPermissions(c *cli.Context, fix bool) (*api.AuditCustomKeyPermissionsResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	customKeyDir := cfg.Smartnode.GetCustomKeyPath()
+	response := api.AuditCustomKeyPermissionsResponse{
+		CustomKeyPath: customKeyDir,
+		Issues:        []api.CustomKeyPermissionIssue{},
+	}
+
+	// Check the directory itself
+	dirInfo, err := os.Stat(customKeyDir)
+	if os.IsNotExist(err) {
+		return &response, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error checking the custom key directory: %w", err)
+	}
+	response.DirectoryExists = true
+	checkCustomKeyPermissions(&response, customKeyDir, dirInfo.Mode().Perm(), customKeyDirMode, fix)
+
+	// Check each file in it
+	dirEntries, err := os.ReadDir(customKeyDir)
+	if err != nil {
+		return nil, fmt.Errorf("error enumerating custom keystores: %w", err)
+	}
+	for _, file := range dirEntries {
+		if !file.Type().IsRegular() {
+			continue
+		}
+		path := filepath.Join(customKeyDir, file.Name())
+		info, err := file.Info()
+		if err != nil {
+			return nil, fmt.Errorf("error checking custom keystore %s: %w", file.Name(), err)
+		}
+		checkCustomKeyPermissions(&response, path, info.Mode().Perm(), customKeyFileMode, fix)
+	}
+
+	return &response, nil
+
+}
+
+// Record an issue if the path is accessible to the group or to other users, and tighten it if requested
+func checkCustomKeyPermissions(response *api.AuditCustomKeyPermissionsResponse, path string, mode os.FileMode, recommended os.FileMode, fix bool) {
+	if mode&0077 == 0 {
+		return
+	}
+	issue := api.CustomKeyPermissionIssue{
+		Path:            path,
+		Mode:            fmt.Sprintf("%04o", mode),
+		RecommendedMode: fmt.Sprintf("%04o", recommended),
+	}
+	if fix {
+		err := os.Chmod(path, mode&recommended)
+		if err != nil {
+			issue.Error = err.Error()
+		} else {
+			issue.Fixed = true
+		}
+	}
+	response.Issues = append(response.Issues, issue)
+}
@@ -262,10 +262,171 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "export-keystores",
+				Usage:     "Copy the node wallet keystore and the custom validator keystores into a backup directory without deleting anything",
+				UsageText: "rocketpool api wallet export-keystores [options] backup-dir",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "force",
+						Usage: "Overwrite files that already exist in the backup directory",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(exportKeystores(c, c.Args().Get(0), c.Bool("force")))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-purge-token",
+				Usage:     "Get a one-time token that must be provided to purge",
+				UsageText: "rocketpool api wallet get-purge-token",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getPurgeToken(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "purge",
 				Usage:     "Deletes your node wallet, your validator keys, and restarts your Validator Client while preserving your chain data. WARNING: Only use this if you want to stop validating with this machine!",
-				UsageText: "rocketpool api wallet purge",
+				UsageText: "rocketpool api wallet purge [--dry-run | token]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Report what would be deleted without deleting anything or restarting the Validator Client; no token is needed",
+					},
+					cli.StringFlag{
+						Name:  "backup-path",
+						Usage: "Back up the node wallet to this path, encrypted with the backup password, before deleting anything",
+					},
+					cli.StringFlag{
+						Name:  "backup-password",
+						Usage: "The password to encrypt the wallet backup with",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Dry runs don't need a token
+					if c.Bool("dry-run") {
+						if err := cliutils.ValidateArgCount(c, 0); err != nil {
+							return err
+						}
+						api.PrintResponse(purge(c, "", "", "", true))
+						return nil
+					}
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(purge(c, c.Args().Get(0), c.String("backup-path"), c.String("backup-password"), false))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "test-custom-key-passwords",
+				Aliases:   []string{"c"},
+				Usage:     "Check whether each custom keystore can be decrypted with the node password and with its entry in the custom key password file",
+				UsageText: "rocketpool api wallet test-custom-key-passwords",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(testCustomKeyPasswords(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "encrypt-custom-keys",
+				Usage:     "Encrypt each plain custom keystore with the node password, on top of its own password",
+				UsageText: "rocketpool api wallet encrypt-custom-keys",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(encryptCustomKeys(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "reencrypt-custom-keys",
+				Usage:     "Re-encrypt every custom keystore with a new password; they must all decrypt with the old password",
+				UsageText: "rocketpool api wallet reencrypt-custom-keys old-password new-password",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(reencryptCustomKeys(c, c.Args().Get(0), c.Args().Get(1)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "audit-custom-key-permissions",
+				Usage:     "Check that the custom key directory and its keystores aren't readable by other users, optionally tightening their permissions",
+				UsageText: "rocketpool api wallet audit-custom-key-permissions fix",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					fix, err := cliutils.ValidateBool("fix", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(auditCustomKeyPermissions(c, fix))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "verify-custom-keys",
+				Aliases:   []string{"v"},
+				Usage:     "Restart the Validator client and check that it loaded every custom key in the validator keystores",
+				UsageText: "rocketpool api wallet verify-custom-keys",
 				Action: func(c *cli.Context) error {
 
 					// Validate args
@@ -274,11 +435,12 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 					}
 
 					// Run
-					api.PrintResponse(purge(c))
+					api.PrintResponse(verifyCustomKeysLoaded(c))
 					return nil
 
 				},
 			},
+
 			{
 				Name:      "estimate-gas-set-ens-name",
 				Usage:     "Estimate the gas required to set the name for the node wallet's ENS reverse record",
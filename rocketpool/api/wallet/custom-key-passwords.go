@@ -0,0 +1,162 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
+	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
+)
+
+// Try to decrypt each custom keystore with the node password and with its entry in the custom key password file
+func testCustomKeyPasswords(c *cli.Context) (*api.TestCustomKeyPasswordsResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	pm, err := services.GetPasswordManager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TestCustomKeyPasswordsResponse{
+		Keys: []api.CustomKeyPasswordCheck{},
+	}
+
+	// Get the custom keystore files
+	customKeyDir := cfg.Smartnode.GetCustomKeyPath()
+	files, err := os.ReadDir(customKeyDir)
+	if os.IsNotExist(err) {
+		return &response, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error enumerating custom keystores: %w", err)
+	}
+	if len(files) == 0 {
+		return &response, nil
+	}
+
+	// Get the node password
+	nodePassword, err := pm.GetPassword()
+	if err != nil {
+		return nil, fmt.Errorf("error loading node password: %w", err)
+	}
+
+	// Get the custom key passwords if they've been provided
+	passwords, err := getCustomKeyPasswords(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize the BLS library
+	err = eth2types.InitBLS()
+	if err != nil {
+		return nil, fmt.Errorf("error initializing BLS: %w", err)
+	}
+
+	// Check every custom key
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		check := api.CustomKeyPasswordCheck{
+			File: file.Name(),
+		}
+
+		// Read and deserialize the keystore
+		bytes, err := os.ReadFile(filepath.Join(customKeyDir, file.Name()))
+		if err != nil {
+			check.Error = fmt.Sprintf("error reading custom keystore: %s", err.Error())
+			response.Keys = append(response.Keys, check)
+			continue
+		}
+		keystore, err := walletutils.ParseCustomKeystore(bytes, file.Name(), nodePassword)
+		if err != nil {
+			check.Error = err.Error()
+			response.Keys = append(response.Keys, check)
+			continue
+		}
+		check.Pubkey = keystore.Pubkey
+
+		// Try the node password
+		var mismatch *walletutils.PubkeyMismatchError
+		_, err = walletutils.DecryptCustomKeystore(keystore, file.Name(), nodePassword)
+		check.DecryptsWithNodePassword = (err == nil)
+		if errors.As(err, &mismatch) {
+			check.PubkeyMismatch = true
+			check.Error = err.Error()
+		}
+
+		// Try the password file entry
+		formattedPubkey := strings.ToUpper(hexutils.RemovePrefix(keystore.Pubkey.Hex()))
+		password, exists := passwords[formattedPubkey]
+		check.HasPasswordFileEntry = exists
+		if exists {
+			_, err = walletutils.DecryptCustomKeystore(keystore, file.Name(), password)
+			check.DecryptsWithPasswordEntry = (err == nil)
+			if errors.As(err, &mismatch) {
+				check.PubkeyMismatch = true
+			}
+			if err != nil {
+				check.Error = err.Error()
+			}
+		}
+
+		response.Keys = append(response.Keys, check)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the custom key passwords from the password file, keyed by uppercase pubkey without a prefix; the file is optional
+func getCustomKeyPasswords(cfg *config.RocketPoolConfig) (map[string]string, error) {
+	passwords := map[string]string{}
+	fileBytes, err := os.ReadFile(cfg.Smartnode.GetCustomKeyPasswordFilePath())
+	if os.IsNotExist(err) {
+		return passwords, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading custom keystore password file: %w", err)
+	}
+	err = yaml.Unmarshal(fileBytes, &passwords)
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling custom keystore password file: %w", err)
+	}
+	return passwords, nil
+}
+
+// Check whether a custom keystore decrypts with any of the known passwords to a key for a different validator than it claims.
+// Keystores that can't be decrypted at all are left to the password check.
+func findCustomKeystorePubkeyMismatch(keystore api.ValidatorKeystore, name string, nodePassword string, passwords map[string]string) *walletutils.PubkeyMismatchError {
+	candidates := []string{}
+	if nodePassword != "" {
+		candidates = append(candidates, nodePassword)
+	}
+	formattedPubkey := strings.ToUpper(hexutils.RemovePrefix(keystore.Pubkey.Hex()))
+	if password, exists := passwords[formattedPubkey]; exists {
+		candidates = append(candidates, password)
+	}
+	for _, password := range candidates {
+		var mismatch *walletutils.PubkeyMismatchError
+		err := walletutils.VerifyCustomKeystore(keystore, name, password)
+		if errors.As(err, &mismatch) {
+			return mismatch
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package wallet
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
+)
+
+// Wrap every plain custom keystore in the node-level encryption layer, keyed by the node password.
+// Every keystore is encrypted and checked in memory before any file is replaced.
+func encryptCustomKeys(c *cli.Context) (*api.EncryptCustomKeysResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	pm, err := services.GetPasswordManager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.EncryptCustomKeysResponse{
+		EncryptedFiles:        []string{},
+		AlreadyEncryptedFiles: []string{},
+		SkippedFiles:          []string{},
+	}
+
+	// Get the custom keystore files
+	customKeyDir := cfg.Smartnode.GetCustomKeyPath()
+	dirEntries, err := os.ReadDir(customKeyDir)
+	if os.IsNotExist(err) {
+		return &response, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error enumerating custom keystores: %w", err)
+	}
+
+	// Get the node password
+	if !pm.IsPasswordSet() {
+		return nil, fmt.Errorf("the node password must be set to encrypt custom keystores")
+	}
+	nodePassword, err := pm.GetPassword()
+	if err != nil {
+		return nil, fmt.Errorf("error loading node password: %w", err)
+	}
+
+	// Encrypt each plain keystore
+	encrypted := map[string][]byte{}
+	for _, file := range dirEntries {
+		if file.IsDir() {
+			continue
+		}
+		fileBytes, err := os.ReadFile(filepath.Join(customKeyDir, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error reading custom keystore %s: %w", file.Name(), err)
+		}
+		if walletutils.IsEncryptedCustomKeystore(fileBytes) {
+			response.AlreadyEncryptedFiles = append(response.AlreadyEncryptedFiles, file.Name())
+			continue
+		}
+		_, err = walletutils.ParseCustomKeystore(fileBytes, file.Name(), nodePassword)
+		if err != nil {
+			response.SkippedFiles = append(response.SkippedFiles, file.Name())
+			continue
+		}
+
+		envelopeBytes, err := walletutils.EncryptCustomKeystore(fileBytes, nodePassword)
+		if err != nil {
+			return nil, fmt.Errorf("error encrypting custom keystore %s: %w", file.Name(), err)
+		}
+		unwrappedBytes, err := walletutils.UnwrapCustomKeystore(envelopeBytes, file.Name(), nodePassword)
+		if err != nil || !bytes.Equal(unwrappedBytes, fileBytes) {
+			return nil, fmt.Errorf("custom keystore %s didn't decrypt to the original after encrypting it; no files were changed", file.Name())
+		}
+		encrypted[file.Name()] = envelopeBytes
+	}
+
+	// Replace the files
+	for _, file := range dirEntries {
+		envelopeBytes, exists := encrypted[file.Name()]
+		if !exists {
+			continue
+		}
+		path := filepath.Join(customKeyDir, file.Name())
+		err = files.WriteFileAtomic(path, envelopeBytes, wallet.FileMode)
+		if err != nil {
+			return nil, fmt.Errorf("error saving encrypted custom keystore %s: %w", file.Name(), err)
+		}
+		response.EncryptedFiles = append(response.EncryptedFiles, file.Name())
+	}
+
+	return &response, nil
+
+}
@@ -0,0 +1,132 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
+)
+
+// The name of the node wallet keystore in an export
+const exportedWalletFilename string = "wallet"
+
+// The folder custom keystores are put in within an export
+const exportedCustomKeysFolder string = "custom-keys"
+
+// A file to write during an export
+type exportFile struct {
+	name     string
+	contents []byte
+}
+
+// Copy the node wallet keystore and any custom validator keystores into a backup directory. Nothing is deleted and
+// the Validator client isn't touched; existing files in the backup directory are only replaced if force is set.
+func exportKeystores(c *cli.Context, backupDir string, force bool) (*api.ExportKeystoresResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	pm, err := services.GetPasswordManager(c)
+	if err != nil {
+		return nil, err
+	}
+	nodePassword, err := pm.GetPassword()
+	if err != nil {
+		return nil, fmt.Errorf("error loading node password: %w", err)
+	}
+
+	// Response
+	response := api.ExportKeystoresResponse{
+		BackupDir:    backupDir,
+		WrittenFiles: []string{},
+	}
+
+	// Serialize the wallet keystore; it stays encrypted with the node password
+	walletString, err := w.String()
+	if err != nil {
+		return nil, err
+	}
+	exports := []exportFile{{
+		name:     exportedWalletFilename,
+		contents: []byte(walletString),
+	}}
+
+	// Get the custom keystores, skipping anything that isn't one. Encrypted ones are exported as plain EIP-2335 keystores
+	// so they can be used without the node password.
+	customKeyDir := cfg.Smartnode.GetCustomKeyPath()
+	dirEntries, err := os.ReadDir(customKeyDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error enumerating custom keystores: %w", err)
+	}
+	for _, file := range dirEntries {
+		if file.IsDir() {
+			continue
+		}
+		bytes, err := os.ReadFile(filepath.Join(customKeyDir, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error reading custom keystore %s: %w", file.Name(), err)
+		}
+		bytes, err = walletutils.UnwrapCustomKeystore(bytes, file.Name(), nodePassword)
+		if err != nil {
+			return nil, err
+		}
+		keystore := api.ValidatorKeystore{}
+		if err := json.Unmarshal(bytes, &keystore); err != nil {
+			continue
+		}
+		exports = append(exports, exportFile{
+			name:     filepath.Join(exportedCustomKeysFolder, file.Name()),
+			contents: bytes,
+		})
+	}
+
+	// Check for existing files before writing anything, so a refused export doesn't leave a partial copy behind
+	if !force {
+		existing := []string{}
+		for _, export := range exports {
+			_, err := os.Stat(filepath.Join(backupDir, export.name))
+			if err == nil {
+				existing = append(existing, export.name)
+			} else if !os.IsNotExist(err) {
+				return nil, fmt.Errorf("error checking for an existing %s in the backup directory: %w", export.name, err)
+			}
+		}
+		if len(existing) > 0 {
+			return nil, fmt.Errorf("the backup directory already contains %s; use --force to overwrite them", strings.Join(existing, ", "))
+		}
+	}
+
+	// Write the files
+	for _, export := range exports {
+		path := filepath.Join(backupDir, export.name)
+		err = os.MkdirAll(filepath.Dir(path), 0700)
+		if err != nil {
+			return nil, fmt.Errorf("error creating backup directory %s: %w", filepath.Dir(path), err)
+		}
+		err = os.WriteFile(path, export.contents, wallet.FileMode)
+		if err != nil {
+			return nil, fmt.Errorf("error writing %s: %w", path, err)
+		}
+		response.WrittenFiles = append(response.WrittenFiles, export.name)
+	}
+
+	return &response, nil
+
+}
@@ -1,15 +1,108 @@
 package wallet
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"time"
 
+	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/rocket-pool/smartnode/shared/utils/validator"
+	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
 	"github.com/urfave/cli"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
 )
 
-func purge(c *cli.Context) (*api.PurgeResponse, error) {
+// How long a purge confirmation token stays valid
+const purgeTokenLifetime time.Duration = 5 * time.Minute
+
+// The file the current purge confirmation token is stored in
+const purgeTokenFilename string = "rocketpool-purge-token"
+
+type purgeToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Create a one-time token that has to be presented to purge, so it can't be run by accident in a single call
+func getPurgeToken(c *cli.Context) (*api.GetPurgeTokenResponse, error) {
+
+	// Response
+	response := api.GetPurgeTokenResponse{}
+
+	// Generate the token
+	tokenBytes := make([]byte, 16)
+	_, err := rand.Read(tokenBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error generating purge token: %w", err)
+	}
+	token := purgeToken{
+		Token:     hex.EncodeToString(tokenBytes),
+		ExpiresAt: time.Now().Add(purgeTokenLifetime),
+	}
+
+	// Save it, replacing any earlier token
+	tokenFileBytes, err := json.Marshal(token)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing purge token: %w", err)
+	}
+	err = os.WriteFile(filepath.Join(os.TempDir(), purgeTokenFilename), tokenFileBytes, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("error saving purge token: %w", err)
+	}
+
+	response.Token = token.Token
+	response.ExpiresAt = token.ExpiresAt
+	return &response, nil
+
+}
+
+// Check the provided purge token against the saved one. The saved token is removed either way so it can only be tried once.
+func consumePurgeToken(token string) error {
+	tokenPath := filepath.Join(os.TempDir(), purgeTokenFilename)
+	tokenFileBytes, err := os.ReadFile(tokenPath)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("no purge token has been requested; get one first with `rocketpool api wallet get-purge-token`")
+	}
+	if err != nil {
+		return fmt.Errorf("error reading purge token: %w", err)
+	}
+	err = os.Remove(tokenPath)
+	if err != nil {
+		return fmt.Errorf("error removing purge token: %w", err)
+	}
+
+	var savedToken purgeToken
+	err = json.Unmarshal(tokenFileBytes, &savedToken)
+	if err != nil {
+		return fmt.Errorf("error deserializing purge token: %w", err)
+	}
+	if time.Now().After(savedToken.ExpiresAt) {
+		return fmt.Errorf("the purge token expired at %s; please request a new one", savedToken.ExpiresAt.Format(time.RFC3339))
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(savedToken.Token)) != 1 {
+		return fmt.Errorf("the purge token is incorrect; please request a new one")
+	}
+	return nil
+}
+
+// Delete the node wallet, its password, the validator keys, and the custom keystores, then restart the VC.
+// A dry run finds everything the same way but doesn't delete, back up, or restart anything, and doesn't need a token.
+func purge(c *cli.Context, token string, backupPath string, backupPassword string, dryRun bool) (*api.PurgeResponse, error) {
+
+	// Make sure this purge was confirmed before doing anything
+	if !dryRun {
+		err := consumePurgeToken(token)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	cfg, err := services.GetConfig(c)
 	if err != nil {
@@ -36,18 +129,127 @@ func purge(c *cli.Context) (*api.PurgeResponse, error) {
 		return nil, err
 	}
 
-	response := api.PurgeResponse{}
+	response := api.PurgeResponse{
+		DryRun:                  dryRun,
+		DeletedValidatorPubkeys: []string{},
+		DeletedCustomKeystores:  []string{},
+		SkippedCustomKeystores:  []api.SkippedCustomKeystore{},
+		Warnings:                []string{},
+	}
+
+	// Back up the wallet before anything is deleted, and don't touch anything if that fails
+	if backupPath != "" && !dryRun {
+		err = w.SaveBackup(backupPath, backupPassword)
+		if err != nil {
+			return nil, fmt.Errorf("error backing up wallet to %s, nothing has been deleted: %w", backupPath, err)
+		}
+		response.BackupCreated = true
+		response.BackupPath = backupPath
+	}
 
-	// Stop the VC to unlock keystores and slashing DBs
-	err = validator.StopValidator(cfg, bc, nil, d)
-	if err != nil {
-		return nil, fmt.Errorf("error stopping validator client: %w", err)
+	// Get the pubkeys of the validator keys derived from the wallet, which go away with it
+	pubkeys := []string{}
+	if w.IsInitialized() {
+		keyCount, err := w.GetValidatorKeyCount()
+		if err != nil {
+			return nil, fmt.Errorf("error getting validator key count: %w", err)
+		}
+		for i := uint(0); i < keyCount; i++ {
+			key, err := w.GetValidatorKeyAt(i)
+			if err != nil {
+				return nil, fmt.Errorf("error getting validator key %d: %w", i, err)
+			}
+			pubkeys = append(pubkeys, types.BytesToValidatorPubkey(key.PublicKey().Marshal()).Hex())
+		}
 	}
 
-	// Delete the VC directories
-	err = w.DeleteValidatorStores()
+	// Find the custom keystores
+	customKeyDir := cfg.Smartnode.GetCustomKeyPath()
+	dirEntries, err := os.ReadDir(customKeyDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error enumerating custom keystores: %w", err)
+	}
+	// Get the passwords needed to check that each custom keystore is really for the pubkey it lists
+	err = eth2types.InitBLS()
 	if err != nil {
-		return nil, fmt.Errorf("error deleting validator storage: %w", err)
+		return nil, fmt.Errorf("error initializing BLS: %w", err)
+	}
+	passwords, err := getCustomKeyPasswords(cfg)
+	if err != nil {
+		return nil, err
+	}
+	nodePassword := ""
+	if pm.IsPasswordSet() {
+		nodePassword, err = pm.GetPassword()
+		if err != nil {
+			return nil, fmt.Errorf("error loading node password: %w", err)
+		}
+	}
+
+	customKeyFiles := []string{}
+	firstFiles := map[types.ValidatorPubkey]string{}
+	for _, file := range dirEntries {
+		if file.IsDir() {
+			continue
+		}
+
+		// Leave files that can't be read as keystores alone instead of aborting, since they can't be loaded by the VC anyway
+		bytes, err := os.ReadFile(filepath.Join(customKeyDir, file.Name()))
+		if err != nil {
+			response.SkippedCustomKeystores = append(response.SkippedCustomKeystores, api.SkippedCustomKeystore{File: file.Name(), Error: err.Error()})
+			continue
+		}
+		keystore, err := walletutils.ParseCustomKeystore(bytes, file.Name(), nodePassword)
+		if err != nil {
+			response.SkippedCustomKeystores = append(response.SkippedCustomKeystores, api.SkippedCustomKeystore{File: file.Name(), Error: err.Error()})
+			continue
+		}
+		customKeyFiles = append(customKeyFiles, file.Name())
+
+		// Flag keystores whose private key doesn't match their pubkey, so the report of deleted keys isn't trusted blindly
+		if mismatch := findCustomKeystorePubkeyMismatch(keystore, file.Name(), nodePassword, passwords); mismatch != nil {
+			response.Warnings = append(response.Warnings, mismatch.Error())
+		}
+
+		// Flag keystores for the same validator, which usually come from importing a key more than once
+		if firstFile, exists := firstFiles[keystore.Pubkey]; exists {
+			response.Warnings = append(response.Warnings, fmt.Sprintf("custom keystore %s has the same pubkey (%s) as %s", file.Name(), keystore.Pubkey.Hex(), firstFile))
+		} else {
+			firstFiles[keystore.Pubkey] = file.Name()
+		}
+	}
+
+	// The VC only needs to be stopped and restarted if it has keys that are about to be removed
+	changed := len(pubkeys) > 0 || len(customKeyFiles) > 0
+
+	// Dry runs stop here, reporting what would have been removed
+	if dryRun {
+		response.DeletedValidatorPubkeys = pubkeys
+		response.DeletedCustomKeystores = customKeyFiles
+		response.ValidatorRestarted = changed
+		return &response, nil
+	}
+	if changed {
+		// Stop the VC to unlock keystores and slashing DBs
+		err = validator.StopValidator(cfg, bc, nil, d)
+		if err != nil {
+			return nil, fmt.Errorf("error stopping validator client: %w", err)
+		}
+
+		// Delete the VC directories
+		err = w.DeleteValidatorStores()
+		if err != nil {
+			return nil, fmt.Errorf("error deleting validator storage: %w", err)
+		}
+
+		// Delete the custom keystores
+		for _, file := range customKeyFiles {
+			err = os.Remove(filepath.Join(customKeyDir, file))
+			if err != nil {
+				return nil, fmt.Errorf("error deleting custom keystore %s: %w", file, err)
+			}
+			response.DeletedCustomKeystores = append(response.DeletedCustomKeystores, file)
+		}
 	}
 
 	// Delete the wallet and password
@@ -55,15 +257,19 @@ func purge(c *cli.Context) (*api.PurgeResponse, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error deleting wallet: %w", err)
 	}
+	response.DeletedValidatorPubkeys = pubkeys
 	err = pm.DeletePassword()
 	if err != nil {
 		return nil, fmt.Errorf("error deleting password: %w", err)
 	}
 
 	// Restart the VC once cleanup is done
-	err = validator.RestartValidator(cfg, bc, nil, d)
-	if err != nil {
-		return nil, fmt.Errorf("error restarting validator client: %w", err)
+	if changed {
+		err = validator.RestartValidator(cfg, bc, nil, d)
+		if err != nil {
+			return nil, fmt.Errorf("error restarting validator client: %w", err)
+		}
+		response.ValidatorRestarted = true
 	}
 
 	return &response, nil
@@ -0,0 +1,128 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
+	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
+)
+
+// Re-encrypt every custom keystore with a new password; they all have to decrypt with the old one. Keystores wrapped in
+// the node-level encryption layer are unwrapped with the old password and wrapped again with the new one. Every keystore
+// is re-encrypted and checked in memory before any file is replaced, so a wrong old password doesn't change anything.
+func reencryptCustomKeys(c *cli.Context, oldPassword string, newPassword string) (*api.ReencryptCustomKeysResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ReencryptCustomKeysResponse{
+		ReencryptedFiles: []string{},
+		SkippedFiles:     []string{},
+	}
+
+	// Get the custom keystore files
+	customKeyDir := cfg.Smartnode.GetCustomKeyPath()
+	dirEntries, err := os.ReadDir(customKeyDir)
+	if os.IsNotExist(err) {
+		return &response, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error enumerating custom keystores: %w", err)
+	}
+
+	// Re-encrypt each keystore
+	reencrypted := map[string][]byte{}
+	pubkeys := []string{}
+	for _, file := range dirEntries {
+		if file.IsDir() {
+			continue
+		}
+		fileBytes, err := os.ReadFile(filepath.Join(customKeyDir, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error reading custom keystore %s: %w", file.Name(), err)
+		}
+		isEncrypted := walletutils.IsEncryptedCustomKeystore(fileBytes)
+		keystoreBytes, err := walletutils.UnwrapCustomKeystore(fileBytes, file.Name(), oldPassword)
+		if err != nil {
+			return nil, fmt.Errorf("%w; no files were changed", err)
+		}
+		var keystore api.ValidatorKeystore
+		err = json.Unmarshal(keystoreBytes, &keystore)
+		if err != nil || keystore.Crypto == nil {
+			response.SkippedFiles = append(response.SkippedFiles, file.Name())
+			continue
+		}
+		newBytes, err := walletutils.ReencryptCustomKeystore(keystoreBytes, file.Name(), oldPassword, newPassword)
+		if err != nil {
+			return nil, fmt.Errorf("%w; no files were changed", err)
+		}
+		if isEncrypted {
+			newBytes, err = walletutils.EncryptCustomKeystore(newBytes, newPassword)
+			if err != nil {
+				return nil, fmt.Errorf("error encrypting custom keystore %s: %w; no files were changed", file.Name(), err)
+			}
+			_, err = walletutils.ParseCustomKeystore(newBytes, file.Name(), newPassword)
+			if err != nil {
+				return nil, fmt.Errorf("custom keystore %s didn't decrypt with the new password after encrypting it; no files were changed", file.Name())
+			}
+		}
+		reencrypted[file.Name()] = newBytes
+		pubkeys = append(pubkeys, strings.ToUpper(hexutils.RemovePrefix(keystore.Pubkey.Hex())))
+	}
+
+	// Update any entries for the re-encrypted keystores in the password file
+	passwords, err := getCustomKeyPasswords(cfg)
+	if err != nil {
+		return nil, err
+	}
+	updatedEntries := 0
+	for _, pubkey := range pubkeys {
+		if _, exists := passwords[pubkey]; exists {
+			passwords[pubkey] = newPassword
+			updatedEntries++
+		}
+	}
+
+	// Replace the files
+	for _, file := range dirEntries {
+		newBytes, exists := reencrypted[file.Name()]
+		if !exists {
+			continue
+		}
+		path := filepath.Join(customKeyDir, file.Name())
+		err = files.WriteFileAtomic(path, newBytes, wallet.FileMode)
+		if err != nil {
+			return nil, fmt.Errorf("error saving re-encrypted custom keystore %s: %w", file.Name(), err)
+		}
+		response.ReencryptedFiles = append(response.ReencryptedFiles, file.Name())
+	}
+	if updatedEntries > 0 {
+		passwordBytes, err := yaml.Marshal(passwords)
+		if err != nil {
+			return nil, fmt.Errorf("error serializing custom keystore password file: %w", err)
+		}
+		err = files.WriteFileAtomic(cfg.Smartnode.GetCustomKeyPasswordFilePath(), passwordBytes, wallet.FileMode)
+		if err != nil {
+			return nil, fmt.Errorf("error saving custom keystore password file: %w", err)
+		}
+		response.UpdatedPasswordEntries = updatedEntries
+	}
+
+	return &response, nil
+
+}
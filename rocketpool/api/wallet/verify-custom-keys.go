@@ -0,0 +1,120 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+	walletutils "github.com/rocket-pool/smartnode/shared/utils/wallet"
+)
+
+// How long to wait for the Validator client to come back up after restarting it
+const validatorReadyTimeout time.Duration = 3 * time.Minute
+
+// Restart the VC, then check that it loaded every custom key that's still in the validator keystores
+func verifyCustomKeysLoaded(c *cli.Context) (*api.VerifyCustomKeysLoadedResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	d, err := services.GetDocker(c)
+	if err != nil {
+		return nil, err
+	}
+	pm, err := services.GetPasswordManager(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure the keymanager API is configured
+	keymanagerUrl := cfg.Smartnode.KeymanagerApiUrl.Value.(string)
+	keymanagerTokenPath := cfg.Smartnode.KeymanagerApiTokenPath.Value.(string)
+	if keymanagerUrl == "" || keymanagerTokenPath == "" {
+		return nil, fmt.Errorf("the keymanager API URL and token path must be set in the Smartnode settings to check which keys your Validator client has loaded")
+	}
+
+	// Response
+	response := api.VerifyCustomKeysLoadedResponse{
+		Keys:            []api.CustomKeyLoadStatus{},
+		UnreadableFiles: []string{},
+	}
+
+	// Encrypted custom keystores need the node password
+	nodePassword := ""
+	if pm.IsPasswordSet() {
+		nodePassword, err = pm.GetPassword()
+		if err != nil {
+			return nil, fmt.Errorf("error loading node password: %w", err)
+		}
+	}
+
+	// Get the custom keystore files
+	customKeyDir := cfg.Smartnode.GetCustomKeyPath()
+	files, err := os.ReadDir(customKeyDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error enumerating custom keystores: %w", err)
+	}
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		bytes, err := os.ReadFile(filepath.Join(customKeyDir, file.Name()))
+		if err != nil {
+			response.UnreadableFiles = append(response.UnreadableFiles, file.Name())
+			continue
+		}
+		keystore, err := walletutils.ParseCustomKeystore(bytes, file.Name(), nodePassword)
+		if err != nil {
+			response.UnreadableFiles = append(response.UnreadableFiles, file.Name())
+			continue
+		}
+
+		// Keys that aren't in the validator keystores anymore (e.g. after a purge) aren't expected to be loaded
+		_, err = w.LoadValidatorKey(keystore.Pubkey)
+		response.Keys = append(response.Keys, api.CustomKeyLoadStatus{
+			File:             file.Name(),
+			Pubkey:           keystore.Pubkey,
+			InValidatorStore: (err == nil),
+		})
+	}
+
+	// Restart the VC and wait for it to come back up
+	err = validator.RestartValidator(cfg, bc, nil, d)
+	if err != nil {
+		return nil, fmt.Errorf("error restarting validator client: %w", err)
+	}
+	loadedPubkeys, err := validator.WaitForLoadedKeystores(keymanagerUrl, keymanagerTokenPath, validatorReadyTimeout)
+	if err != nil {
+		return nil, err
+	}
+	response.LoadedKeyCount = len(loadedPubkeys)
+
+	// Check each custom key against the loaded ones
+	loaded := map[types.ValidatorPubkey]bool{}
+	for _, pubkey := range loadedPubkeys {
+		loaded[pubkey] = true
+	}
+	for i := range response.Keys {
+		response.Keys[i].Loaded = loaded[response.Keys[i].Pubkey]
+	}
+
+	return &response, nil
+
+}
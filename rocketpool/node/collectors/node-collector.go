@@ -68,6 +68,9 @@ type NodeCollector struct {
 	// The unclaimed ETH rewards from the smoothing pool
 	unclaimedEthRewards *prometheus.Desc
 
+	// The number of past rewards intervals with rewards the node hasn't claimed yet
+	unclaimedIntervals *prometheus.Desc
+
 	// The Rocket Pool contract manager
 	rp *rocketpool.RocketPool
 
@@ -164,6 +167,10 @@ func NewNodeCollector(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress c
 			"The unclaimed ETH rewards from the smoothing pool",
 			nil, nil,
 		),
+		unclaimedIntervals: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "unclaimed_reward_intervals"),
+			"The number of past rewards intervals with rewards the node hasn't claimed yet",
+			nil, nil,
+		),
 		rp:               rp,
 		bc:               bc,
 		nodeAddress:      nodeAddress,
@@ -187,6 +194,7 @@ func (collector *NodeCollector) Describe(channel chan<- *prometheus.Desc) {
 	channel <- collector.unclaimedRewards
 	channel <- collector.claimedEthRewards
 	channel <- collector.unclaimedEthRewards
+	channel <- collector.unclaimedIntervals
 }
 
 // Collect the latest metric values and pass them to Prometheus
@@ -212,6 +220,7 @@ func (collector *NodeCollector) Collect(channel chan<- prometheus.Metric) {
 	var beaconHead beacon.BeaconHead
 	unclaimedEthRewards := float64(0)
 	unclaimedRplRewards := float64(0)
+	unclaimedIntervals := float64(0)
 
 	// Get the total staked RPL
 	wg.Go(func() error {
@@ -287,6 +296,9 @@ func (collector *NodeCollector) Collect(channel chan<- prometheus.Metric) {
 			if intervalInfo.NodeExists {
 				unclaimedRplWei.Add(unclaimedRplWei, &intervalInfo.CollateralRplAmount.Int)
 				unclaimedEthWei.Add(unclaimedEthWei, &intervalInfo.SmoothingPoolEthAmount.Int)
+				if intervalInfo.CollateralRplAmount.Sign() > 0 || intervalInfo.ODaoRplAmount.Sign() > 0 || intervalInfo.SmoothingPoolEthAmount.Sign() > 0 {
+					unclaimedIntervals++
+				}
 			}
 		}
 
@@ -484,4 +496,6 @@ func (collector *NodeCollector) Collect(channel chan<- prometheus.Metric) {
 		collector.unclaimedEthRewards, prometheus.GaugeValue, unclaimedEthRewards)
 	channel <- prometheus.MustNewConstMetric(
 		collector.claimedEthRewards, prometheus.GaugeValue, collector.cumulativeClaimedEthRewards)
+	channel <- prometheus.MustNewConstMetric(
+		collector.unclaimedIntervals, prometheus.GaugeValue, unclaimedIntervals)
 }
@@ -1,10 +1,14 @@
 package node
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/docker/docker/client"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/rewards"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/urfave/cli"
@@ -15,6 +19,7 @@ import (
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
@@ -75,20 +80,20 @@ func (d *downloadRewardsTrees) run() error {
 		return err
 	}
 
-	// Check if the user opted into downloading rewards files
-	if d.cfg.Smartnode.RewardsTreeMode.Value.(cfgtypes.RewardsMode) != cfgtypes.RewardsMode_Download {
-		return nil
-	}
-
-	// Log
-	d.log.Println("Checking for new rewards tree files to download...")
-
 	// Get node account
 	nodeAccount, err := d.w.GetNodeAccount()
 	if err != nil {
 		return err
 	}
 
+	// Check if the user opted into downloading rewards files; generated trees can still go into the watch file
+	if d.cfg.Smartnode.RewardsTreeMode.Value.(cfgtypes.RewardsMode) != cfgtypes.RewardsMode_Download {
+		return d.updateRewardsWatchFile(nodeAccount.Address)
+	}
+
+	// Log
+	d.log.Println("Checking for new rewards tree files to download...")
+
 	// Get the current interval
 	currentIndexBig, err := rewards.GetRewardIndex(d.rp, nil)
 	if err != nil {
@@ -100,7 +105,7 @@ func (d *downloadRewardsTrees) run() error {
 	missingIntervals := []uint64{}
 	for i := uint64(0); i < currentIndex; i++ {
 		// Check if the tree file exists
-		treeFilePath := d.cfg.Smartnode.GetRewardsTreePath(i, true)
+		treeFilePath := d.cfg.Smartnode.GetExistingRewardsTreePath(i, true)
 		_, err = os.Stat(treeFilePath)
 		if os.IsNotExist(err) {
 			d.log.Printlnf("You are missing the rewards tree file for interval %d.", i)
@@ -111,7 +116,7 @@ func (d *downloadRewardsTrees) run() error {
 	}
 
 	if len(missingIntervals) == 0 {
-		return nil
+		return d.updateRewardsWatchFile(nodeAccount.Address)
 	}
 
 	// Download missing intervals
@@ -121,6 +126,16 @@ func (d *downloadRewardsTrees) run() error {
 		if err != nil {
 			return fmt.Errorf("error getting interval %d info: %w", missingInterval, err)
 		}
+		mirrorUrl := d.cfg.Smartnode.RewardsTreeMirrorUrl.Value.(string)
+		if mirrorUrl != "" {
+			err = d.downloadRewardsFileFromMirror(mirrorUrl, missingInterval)
+			if err == nil {
+				fmt.Println("done!")
+				continue
+			}
+			fmt.Println()
+			d.log.Printlnf("WARNING: couldn't download interval %d from the mirror, trying IPFS instead: %s", missingInterval, err.Error())
+		}
 		err = rprewards.DownloadRewardsFile(d.cfg, missingInterval, intervalInfo.CID, true)
 		if err != nil {
 			fmt.Println()
@@ -129,6 +144,48 @@ func (d *downloadRewardsTrees) run() error {
 		fmt.Println("done!")
 	}
 
+	return d.updateRewardsWatchFile(nodeAccount.Address)
+
+}
+
+// Download a rewards file from the configured mirror, which is only saved if it matches the canonical root
+func (d *downloadRewardsTrees) downloadRewardsFileFromMirror(mirrorUrl string, interval uint64) error {
+	rewardsTreePath := d.cfg.Smartnode.GetRewardsTreePath(interval, true)
+	fileUrl := strings.TrimSuffix(mirrorUrl, "/") + "/" + filepath.Base(rewardsTreePath)
+	rewardsFile, err := rprewards.LoadRewardsFileFromUrl(d.rp, d.cfg, interval, fileUrl)
+	if err != nil {
+		return err
+	}
+	fileBytes, err := json.Marshal(rewardsFile)
+	if err != nil {
+		return fmt.Errorf("error serializing interval %d file: %w", interval, err)
+	}
+	fileMode, err := d.cfg.Smartnode.GetRewardsTreeFileMode()
+	if err != nil {
+		return fmt.Errorf("error getting rewards tree file permissions: %w", err)
+	}
+	err = files.WriteFileAtomic(rewardsTreePath, fileBytes, fileMode)
+	if err != nil {
+		return fmt.Errorf("error saving interval %d file to %s: %w", interval, rewardsTreePath, err)
+	}
 	return nil
+}
+
+// Add any new intervals to the rewards watch file, if the user has created one
+func (d *downloadRewardsTrees) updateRewardsWatchFile(nodeAddress common.Address) error {
+	_, err := os.Stat(d.cfg.Smartnode.GetRewardsWatchFilePath(true))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error checking if the rewards watch file exists: %w", err)
+	}
 
+	added, err := rprewards.UpdateRewardsWatchFile(d.rp, d.cfg, nodeAddress)
+	if err != nil {
+		return fmt.Errorf("error updating rewards watch file: %w", err)
+	}
+	if len(added) > 0 {
+		d.log.Printlnf("Added intervals %v to the rewards watch file.", added)
+	}
+	return nil
 }
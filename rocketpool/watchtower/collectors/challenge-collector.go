@@ -0,0 +1,82 @@
+package collectors
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Represents the collector for the Oracle DAO challenge response metrics
+type ChallengeCollector struct {
+
+	// The number of challenges the watchtower has detected against this node or other members
+	challengesDetectedDesc *prometheus.Desc
+
+	// The number of challenge responses and decisions the watchtower has submitted
+	responsesSubmittedDesc *prometheus.Desc
+
+	// The number of challenge responses and decisions that failed
+	responseFailuresDesc *prometheus.Desc
+
+	// The time of the latest successful challenge response or decision
+	lastResponseTimeDesc *prometheus.Desc
+
+	// Counters
+	ChallengesDetected float64
+	ResponsesSubmitted float64
+	ResponseFailures   float64
+	LastResponseTime   float64
+
+	// Mutex
+	UpdateLock sync.Mutex
+}
+
+// Create a new ChallengeCollector instance
+func NewChallengeCollector() *ChallengeCollector {
+	subsystem := "challenge"
+	return &ChallengeCollector{
+		challengesDetectedDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "detected_total"),
+			"The number of challenges the watchtower has detected against this node or other members",
+			nil, nil,
+		),
+		responsesSubmittedDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "responses_submitted_total"),
+			"The number of challenge responses and decisions the watchtower has submitted",
+			nil, nil,
+		),
+		responseFailuresDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "response_failures_total"),
+			"The number of challenge responses and decisions that failed",
+			nil, nil,
+		),
+		lastResponseTimeDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_response_time"),
+			"The time of the latest successful challenge response or decision",
+			nil, nil,
+		),
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *ChallengeCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.challengesDetectedDesc
+	channel <- collector.responsesSubmittedDesc
+	channel <- collector.responseFailuresDesc
+	channel <- collector.lastResponseTimeDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *ChallengeCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Sync
+	collector.UpdateLock.Lock()
+	defer collector.UpdateLock.Unlock()
+
+	// Update all of the metrics
+	channel <- prometheus.MustNewConstMetric(
+		collector.challengesDetectedDesc, prometheus.CounterValue, collector.ChallengesDetected)
+	channel <- prometheus.MustNewConstMetric(
+		collector.responsesSubmittedDesc, prometheus.CounterValue, collector.ResponsesSubmitted)
+	channel <- prometheus.MustNewConstMetric(
+		collector.responseFailuresDesc, prometheus.CounterValue, collector.ResponseFailures)
+	channel <- prometheus.MustNewConstMetric(
+		collector.lastResponseTimeDesc, prometheus.GaugeValue, collector.LastResponseTime)
+
+}
@@ -0,0 +1,65 @@
+package collectors
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Represents the collector for the rewards tree generation metrics
+type RewardsTreeCollector struct {
+
+	// How long the collateral RPL calculation took for each interval
+	rplRewardsDuration *prometheus.HistogramVec
+
+	// How long building the Merkle tree took for each interval
+	merkleTreeDuration *prometheus.HistogramVec
+}
+
+// Create a new RewardsTreeCollector instance
+func NewRewardsTreeCollector() *RewardsTreeCollector {
+	subsystem := "rewards_tree"
+
+	// Generations range from seconds on small networks to hours on slow hardware
+	buckets := prometheus.ExponentialBuckets(1, 2, 16)
+	return &RewardsTreeCollector{
+		rplRewardsDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "rpl_rewards_duration_seconds",
+			Help:      "How long the collateral RPL calculation took for each interval",
+			Buckets:   buckets,
+		}, []string{"interval"}),
+		merkleTreeDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "merkle_tree_duration_seconds",
+			Help:      "How long building the Merkle tree took for each interval",
+			Buckets:   buckets,
+		}, []string{"interval"}),
+	}
+}
+
+// Record how long the phases of a finished generation took
+func (collector *RewardsTreeCollector) ObserveGeneration(index uint64, rplRewardsDuration time.Duration, merkleTreeDuration time.Duration) {
+	interval := strconv.FormatUint(index, 10)
+	if rplRewardsDuration > 0 {
+		collector.rplRewardsDuration.WithLabelValues(interval).Observe(rplRewardsDuration.Seconds())
+	}
+	if merkleTreeDuration > 0 {
+		collector.merkleTreeDuration.WithLabelValues(interval).Observe(merkleTreeDuration.Seconds())
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *RewardsTreeCollector) Describe(channel chan<- *prometheus.Desc) {
+	collector.rplRewardsDuration.Describe(channel)
+	collector.merkleTreeDuration.Describe(channel)
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *RewardsTreeCollector) Collect(channel chan<- prometheus.Metric) {
+	collector.rplRewardsDuration.Collect(channel)
+	collector.merkleTreeDuration.Collect(channel)
+}
@@ -0,0 +1,104 @@
+package watchtower
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// An unsigned response to a challenge, saved so it can be signed offline
+type unsignedChallengeResponse struct {
+	ChainID              *big.Int       `json:"chainId"`
+	From                 common.Address `json:"from"`
+	To                   common.Address `json:"to"`
+	Nonce                uint64         `json:"nonce"`
+	GasLimit             uint64         `json:"gasLimit"`
+	MaxFeePerGas         *big.Int       `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *big.Int       `json:"maxPriorityFeePerGas"`
+	Value                *big.Int       `json:"value"`
+	Data                 hexutil.Bytes  `json:"data"`
+	SigningHash          common.Hash    `json:"signingHash"`
+	UnsignedTransaction  hexutil.Bytes  `json:"unsignedTransaction"`
+}
+
+// Build the transaction that responds to a challenge against the node and save it without signing it
+func (t *respondChallenges) exportChallengeResponse(nodeAddress common.Address) error {
+
+	// Get the contract and the call data
+	actionsContract, err := t.rp.GetContract("rocketDAONodeTrustedActions", nil)
+	if err != nil {
+		return err
+	}
+	data, err := actionsContract.ABI.Pack("actionChallengeDecide", nodeAddress)
+	if err != nil {
+		return fmt.Errorf("Could not encode the challenge response: %w", err)
+	}
+
+	// Get the gas limit without touching the node's key
+	gasInfo, err := trustednode.EstimateDecideChallengeGas(t.rp, nodeAddress, &bind.TransactOpts{From: nodeAddress})
+	if err != nil {
+		return fmt.Errorf("Could not estimate the gas required to respond to the challenge: %w", err)
+	}
+
+	// Get the nonce
+	nonce, err := t.rp.Client.PendingNonceAt(context.Background(), nodeAddress)
+	if err != nil {
+		return fmt.Errorf("Could not get the node's nonce: %w", err)
+	}
+
+	// Build the transaction
+	chainID := big.NewInt(int64(t.cfg.Smartnode.GetChainID()))
+	txData := &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: eth.GweiToWei(WatchtowerMaxPriorityFee),
+		GasFeeCap: eth.GweiToWei(WatchtowerMaxFee),
+		Gas:       gasInfo.SafeGasLimit,
+		To:        actionsContract.Address,
+		Value:     big.NewInt(0),
+		Data:      data,
+	}
+	tx := types.NewTx(txData)
+	unsignedTx, err := tx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("Could not serialize the challenge response: %w", err)
+	}
+
+	// Save it
+	response := unsignedChallengeResponse{
+		ChainID:              chainID,
+		From:                 nodeAddress,
+		To:                   *actionsContract.Address,
+		Nonce:                nonce,
+		GasLimit:             txData.Gas,
+		MaxFeePerGas:         txData.GasFeeCap,
+		MaxPriorityFeePerGas: txData.GasTipCap,
+		Value:                txData.Value,
+		Data:                 data,
+		SigningHash:          types.LatestSignerForChainID(chainID).Hash(tx),
+		UnsignedTransaction:  unsignedTx,
+	}
+	bytes, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Could not serialize the challenge response: %w", err)
+	}
+	path := t.cfg.Smartnode.GetUnsignedChallengeResponsePath(true)
+	err = files.WriteFileAtomic(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("Could not save the challenge response to %s: %w", path, err)
+	}
+
+	t.log.Printlnf("Saved the unsigned challenge response to %s (nonce %d). Sign it offline with the node's key, then broadcast it with `rocketpool odao broadcast-challenge-response`.", path, nonce)
+	return nil
+
+}
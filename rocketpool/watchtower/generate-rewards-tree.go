@@ -3,10 +3,13 @@ package watchtower
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"math/big"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -19,29 +22,57 @@ import (
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/rocket-pool/rocketpool-go/rewards"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+	"github.com/rocket-pool/smartnode/shared/utils/ipfs"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/sys"
 	"github.com/urfave/cli"
 )
 
+// How often to check whether tree generation has finished while shutting down
+const shutdownPollInterval time.Duration = time.Second
+
 // Generate rewards Merkle Tree task
 type generateRewardsTree struct {
-	c         *cli.Context
-	log       log.ColorLogger
-	errLog    log.ColorLogger
-	cfg       *config.RocketPoolConfig
-	rp        *rocketpool.RocketPool
-	ec        rocketpool.ExecutionClient
-	bc        beacon.Client
-	lock      *sync.Mutex
-	isRunning bool
+	c           *cli.Context
+	log         log.ColorLogger
+	errLog      log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	rp          *rocketpool.RocketPool
+	ec          *services.ExecutionClientManager
+	bc          *services.BeaconClientManager
+	notifier    notifications.Notifier
+	lock        *sync.Mutex
+	isRunning   bool
+	stopping    bool
+	index       uint64
+	outputDir   string
+	failed      bool
+	failure     error
+	beaconCache *rprewards.BeaconCache
+	coll        *collectors.RewardsTreeCollector
+	status      rprewards.GenerationStatus
+}
+
+// A generation, dry run, or verification request, or a checkpoint to resume from, waiting for its turn
+type rewardsTreeJob struct {
+	indices []uint64
+	verify  bool
+	dryRun  bool
+	resume  bool
+	path    string
 }
 
 // Create generate rewards Merkle Tree task
-func newGenerateRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*generateRewardsTree, error) {
+func newGenerateRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, coll *collectors.RewardsTreeCollector) (*generateRewardsTree, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -61,6 +92,12 @@ func newGenerateRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger
 		return nil, err
 	}
 
+	notifier, err := notifications.NewNotifier(cfg)
+	if err != nil {
+		logger.Printlnf("WARNING: notifications are disabled: %s", err.Error())
+		notifier = notifications.NewNoopNotifier()
+	}
+
 	lock := &sync.Mutex{}
 	generator := &generateRewardsTree{
 		c:         c,
@@ -70,8 +107,15 @@ func newGenerateRewardsTree(c *cli.Context, logger log.ColorLogger, errorLogger
 		ec:        ec,
 		bc:        bc,
 		rp:        rp,
+		notifier:  notifier,
 		lock:      lock,
 		isRunning: false,
+		coll:      coll,
+	}
+
+	// Make sure times will be interpreted properly
+	if problem := sys.CheckTimezoneDatabase(); problem != "" {
+		logger.Printlnf("WARNING: %s. All rewards tree timestamps will be logged in UTC.", problem)
 	}
 
 	return generator, nil
@@ -83,6 +127,10 @@ func (t *generateRewardsTree) run() error {
 
 	// Check if rewards generation is already running
 	t.lock.Lock()
+	if t.stopping {
+		t.lock.Unlock()
+		return nil
+	}
 	if t.isRunning {
 		t.log.Println("Tree generation is already running.")
 		t.lock.Unlock()
@@ -103,54 +151,539 @@ func (t *generateRewardsTree) run() error {
 		return fmt.Errorf("Error enumerating files in watchtower storage directory: %w", err)
 	}
 
+	// Check for generation that's still running in another process, or that was running before a restart
+	lockedIndex, locked, err := t.checkGenerationLocks(requestDir, files)
+	if err != nil {
+		return err
+	}
+	if locked {
+		t.log.Printlnf("Tree generation for interval %d is already running in another process.", lockedIndex)
+		return nil
+	}
+
+	jobs := []rewardsTreeJob{}
 	for _, file := range files {
 		filename := file.Name()
-		if strings.HasSuffix(filename, config.RegenerateRewardsTreeRequestSuffix) && !file.IsDir() {
-			// Get the index
-			indexString := strings.TrimSuffix(filename, config.RegenerateRewardsTreeRequestSuffix)
+		if file.IsDir() {
+			continue
+		}
+
+		// Check if this is a generation, dry run, or determinism verification request, or a checkpoint left by an interrupted run
+		var suffix string
+		verify := false
+		dryRun := false
+		resume := false
+		if strings.HasSuffix(filename, config.RegenerateRewardsTreeRequestSuffix) {
+			suffix = config.RegenerateRewardsTreeRequestSuffix
+		} else if strings.HasSuffix(filename, config.VerifyRewardsTreeRequestSuffix) {
+			suffix = config.VerifyRewardsTreeRequestSuffix
+			verify = true
+		} else if strings.HasSuffix(filename, config.RegenerateRewardsTreeDryRunSuffix) {
+			suffix = config.RegenerateRewardsTreeDryRunSuffix
+			dryRun = true
+		} else if strings.HasSuffix(filename, config.RewardsTreeCheckpointSuffix) {
+			suffix = config.RewardsTreeCheckpointSuffix
+			resume = true
+		} else {
+			continue
+		}
+
+		// Get the index; generation requests can also name an inclusive range of intervals
+		indexString := strings.TrimSuffix(filename, suffix)
+		var indices []uint64
+		if suffix == config.RegenerateRewardsTreeRequestSuffix {
+			indices, err = config.ParseRewardsTreeRequestIntervals(indexString)
+			if err != nil {
+				return fmt.Errorf("Error parsing intervals from [%s]: %w", filename, err)
+			}
+		} else {
 			index, err := strconv.ParseUint(indexString, 0, 64)
 			if err != nil {
 				return fmt.Errorf("Error parsing index from [%s]: %w", filename, err)
 			}
+			indices = []uint64{index}
+		}
+		jobs = append(jobs, rewardsTreeJob{
+			indices: indices,
+			verify:  verify,
+			dryRun:  dryRun,
+			resume:  resume,
+			path:    filepath.Join(requestDir, filename),
+		})
+	}
+	if len(jobs) == 0 {
+		return nil
+	}
+
+	// Take up to a batch of the requests, lowest interval first; the rest wait for a later tick
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].indices[0] < jobs[j].indices[0]
+	})
+	jobs = dropCoveredCheckpoints(jobs)
+	batchSize := int(t.cfg.Smartnode.RewardsTreeRequestBatchSize.Value.(uint64))
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	if len(jobs) > batchSize {
+		t.log.Printlnf("Found %d rewards tree requests, processing %d of them now and leaving the rest for later.", len(jobs), batchSize)
+		jobs = jobs[:batchSize]
+	}
+
+	// Generate the rewards trees one request at a time
+	t.lock.Lock()
+	t.isRunning = true
+	t.index = jobs[0].indices[0]
+	t.lock.Unlock()
+	go t.processJobs(jobs)
+
+	return nil
+}
+
+// Drop checkpoints for intervals that a regeneration request in the batch will cover anyway, since that request will
+// resume from the checkpoint itself
+func dropCoveredCheckpoints(jobs []rewardsTreeJob) []rewardsTreeJob {
+	covered := map[uint64]bool{}
+	for _, job := range jobs {
+		if !job.resume && !job.verify && !job.dryRun {
+			for _, index := range job.indices {
+				covered[index] = true
+			}
+		}
+	}
+	filtered := make([]rewardsTreeJob, 0, len(jobs))
+	for _, job := range jobs {
+		if job.resume && covered[job.indices[0]] {
+			continue
+		}
+		filtered = append(filtered, job)
+	}
+	return filtered
+}
+
+// Process queued requests in order. Each request file is only read and deleted when its turn comes, so requests that
+// haven't started yet survive a restart.
+func (t *generateRewardsTree) processJobs(jobs []rewardsTreeJob) {
+	// Share Beacon queries between all of the intervals in this batch
+	t.beaconCache = rprewards.NewBeaconCache(t.bc, rprewards.DefaultBeaconCacheSlots)
+
+	for i, job := range jobs {
+		if t.isStopping() {
+			t.log.Println("The watchtower is shutting down; leaving the remaining requests for the next start.")
+			break
+		}
+		if len(jobs) > 1 {
+			t.log.Printlnf("Processing rewards tree request %d of %d in this batch.", i+1, len(jobs))
+		}
 
-			// Delete the file
-			path := filepath.Join(requestDir, filename)
-			err = os.Remove(path)
+		// Read the options from the request, then delete it; checkpoints are kept so generation can pick up from them
+		index := job.indices[0]
+		request := config.RewardsTreeRequest{}
+		if job.resume {
+			t.log.Printlnf("Found a checkpoint from an interrupted run for interval %d, resuming generation.", index)
+		} else {
+			var requestErr error
+			request, requestErr = readRewardsTreeRequest(job.path)
+			err := os.Remove(job.path)
 			if err != nil {
-				return fmt.Errorf("Error removing request file [%s]: %w", path, err)
+				t.errLog.Printlnf("Error removing request file [%s]: %s", job.path, err.Error())
+				continue
 			}
 
-			// Generate the rewards tree
-			t.lock.Lock()
-			t.isRunning = true
-			t.lock.Unlock()
-			go t.generateRewardsTree(index)
+			// Skip the request rather than fall back to the defaults, which could overwrite files the user wanted to keep
+			if requestErr != nil {
+				t.errLog.Printlnf("Error reading the options from request file [%s], skipping the request: %s", job.path, requestErr.Error())
+				continue
+			}
+		}
+
+		t.generateRewardsTrees(job.indices, job.verify, job.dryRun, request)
+	}
 
-			// Return after the first request, do others at other intervals
-			return nil
+	t.beaconCache = nil
+	t.lock.Lock()
+	t.isRunning = false
+	t.lock.Unlock()
+}
+
+// Generate the rewards trees for each of the provided intervals in order, continuing past any that fail
+func (t *generateRewardsTree) generateRewardsTrees(indices []uint64, verify bool, dryRun bool, request config.RewardsTreeRequest) {
+	succeeded := []uint64{}
+	failed := []uint64{}
+	for i, index := range indices {
+		if t.isStopping() {
+			t.log.Printlnf("The watchtower is shutting down; skipping the rest of the requested range from interval %d.", index)
+			break
+		}
+		if len(indices) > 1 {
+			t.log.Printlnf("Processing interval %d (%d of %d in the requested range).", index, i+1, len(indices))
+		}
+		t.lock.Lock()
+		t.index = index
+		t.failed = false
+		t.failure = nil
+		t.outputDir = request.OutputDir
+		t.lock.Unlock()
+		t.updateStatus(func(status *rprewards.GenerationStatus) {
+			*status = rprewards.GenerationStatus{
+				Running:   true,
+				Interval:  index,
+				Stage:     rprewards.GenerationStage_Calculating,
+				StartTime: time.Now().UTC(),
+			}
+		})
+
+		t.generateRewardsTree(index, verify, dryRun, request)
+
+		t.lock.Lock()
+		indexFailed := t.failed
+		failure := t.failure
+		if indexFailed {
+			failed = append(failed, index)
+		} else {
+			succeeded = append(succeeded, index)
 		}
+		t.lock.Unlock()
+		t.updateStatus(func(status *rprewards.GenerationStatus) {
+			status.Running = false
+			if indexFailed {
+				status.Stage = rprewards.GenerationStage_Failed
+				if failure != nil {
+					status.FailureKind = rprewards.GetGenerationErrorKind(failure)
+					status.FailureMessage = failure.Error()
+				}
+			} else {
+				status.Stage = rprewards.GenerationStage_Finished
+				status.Progress = 100
+			}
+		})
+	}
+
+	// Summarize ranges, since the individual results are spread throughout the logs
+	if len(indices) > 1 {
+		summary := fmt.Sprintf("Finished intervals %d to %d: %d succeeded %v, %d failed %v.", indices[0], indices[len(indices)-1], len(succeeded), succeeded, len(failed), failed)
+		t.log.Println(summary)
+		eventType := notifications.EventType_TreeGenerated
+		if len(failed) > 0 {
+			eventType = notifications.EventType_TreeGenerationFailed
+		}
+		t.sendNotification(notifications.NewEvent(t.cfg, eventType,
+			fmt.Sprintf("Rewards trees for intervals %d to %d processed", indices[0], indices[len(indices)-1]),
+			summary))
+	}
+}
+
+// Generate the tree again at the execution blocks on either side of the snapshot block, since timestamp rounding can
+// leave the snapshot off by one. Returns the tree, block header, and phase durations of the block that produced the
+// canonical root, or nil if neither did. An error means a generator couldn't be set up at all.
+func (t *generateRewardsTree) findAdjacentBlockTree(rp *rocketpool.RocketPool, index uint64, generationPrefix string, rewardsEvent rewards.RewardsEvent, elBlockHeader *types.Header, dryRun bool, request config.RewardsTreeRequest) (*rprewards.RewardsFile, *types.Header, map[rprewards.GenerationPhase]time.Duration, error) {
+	for _, offset := range []int64{-1, 1} {
+		blockNumber := big.NewInt(0).Add(elBlockHeader.Number, big.NewInt(offset))
+		header, err := t.ec.HeaderByNumber(context.Background(), blockNumber)
+		if err != nil {
+			t.log.Printlnf("%s WARNING: couldn't get execution block %s: %s", generationPrefix, blockNumber.String(), err.Error())
+			continue
+		}
+
+		treegen, phaseDurations, err := t.newTreeGenerator(rp, index, generationPrefix, rewardsEvent, header, dryRun, request)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		t.log.Printlnf("%s Generating the tree at execution block %s...", generationPrefix, blockNumber.String())
+		start := time.Now()
+		rewardsFile, err := treegen.GenerateTree()
+		if err != nil {
+			t.log.Printlnf("%s WARNING: couldn't generate the tree at block %s: %s", generationPrefix, blockNumber.String(), err.Error())
+			continue
+		}
+		t.log.Printlnf("%s Finished block %s in %s", generationPrefix, blockNumber.String(), time.Since(start).String())
+		root := common.BytesToHash(rewardsFile.MerkleTree.Root())
+		if root == rewardsEvent.MerkleRoot {
+			return rewardsFile, header, phaseDurations, nil
+		}
+		t.log.Printlnf("%s Execution block %s produced a root of %s.", generationPrefix, blockNumber.String(), root.Hex())
 	}
+	return nil, nil, nil, nil
+}
 
+// Create a tree generator for the interval at the provided EL block, with every option the watchtower uses applied.
+// The returned map is filled with the duration of each generation phase as the tree is built.
+func (t *generateRewardsTree) newTreeGenerator(rp *rocketpool.RocketPool, index uint64, generationPrefix string, rewardsEvent rewards.RewardsEvent, elBlockHeader *types.Header, dryRun bool, request config.RewardsTreeRequest) (*rprewards.TreeGenerator, map[rprewards.GenerationPhase]time.Duration, error) {
+	treegen, err := rprewards.NewTreeGenerator(t.log, generationPrefix, rp, t.cfg, t.bc, index, rewardsEvent.IntervalStartTime.UTC(), rewardsEvent.IntervalEndTime.UTC(), rewardsEvent.ConsensusBlock.Uint64(), elBlockHeader, rewardsEvent.IntervalsPassed.Uint64())
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error creating Merkle tree generator: %w", err)
+	}
+	if request.Threads > 0 {
+		err = treegen.SetThreadCount(int(request.Threads))
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error overriding the thread count: %w", err)
+		}
+	}
+	if t.beaconCache != nil {
+		err = treegen.SetBeaconCache(t.beaconCache)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error setting the Beacon cache: %w", err)
+		}
+	}
+	if t.cfg.Smartnode.RewardsTreeMinipoolDetail.Value == true {
+		err = treegen.SetIncludeMinipoolRewards(true)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error enabling the per-minipool rewards: %w", err)
+		}
+	}
+	estimator := newGenerationEstimator()
+	err = treegen.SetProgressCallback(func(processed int, total int) {
+		t.updateStatus(func(status *rprewards.GenerationStatus) {
+			status.Progress = float64(processed) * 100 / float64(total)
+		})
+		remaining, ok := estimator.update(processed, total)
+		if !ok {
+			t.log.Printlnf("%s Processed %d/%d nodes (%d%%)", generationPrefix, processed, total, processed*100/total)
+			return
+		}
+		eta := time.Now().Add(remaining)
+		t.log.PrintlnfWithFields(map[string]interface{}{
+			"processed":        processed,
+			"total":            total,
+			"remainingSeconds": int64(remaining.Seconds()),
+			"eta":              eta.UTC().Format(time.RFC3339),
+		}, "%s Processed %d/%d nodes (%d%%), about %s remaining (ETA %s)", generationPrefix, processed, total, processed*100/total, remaining.Round(time.Second), eta.Format(time.Stamp))
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error setting the progress callback: %w", err)
+	}
+	if request.NodeAddress != nil {
+		err = treegen.SetNodeFilter(*request.NodeAddress)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error setting the node filter: %w", err)
+		}
+	}
+	phaseDurations := map[rprewards.GenerationPhase]time.Duration{}
+	err = treegen.SetPhaseCallback(func(phase rprewards.GenerationPhase, duration time.Duration) {
+		phaseDurations[phase] = duration
+		if phase == rprewards.GenerationPhase_EthRewards {
+			t.updateStatus(func(status *rprewards.GenerationStatus) {
+				status.Stage = rprewards.GenerationStage_BuildingTree
+			})
+		}
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error setting the phase callback: %w", err)
+	}
+	if !dryRun && request.NodeAddress == nil {
+		err = treegen.SetCheckpointPath(t.cfg.Smartnode.GetRewardsTreeCheckpointPath(index, true))
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error setting the checkpoint path: %w", err)
+		}
+	}
+	if t.cfg.Smartnode.RewardsTreeIncremental.Value == true {
+		// Only full runs that write files save a snapshot, but any run can build on the previous one
+		previousSnapshotPath := ""
+		if index > 0 {
+			previousSnapshotPath = t.cfg.Smartnode.GetRewardsTreeNodeSnapshotPath(index-1, true)
+		}
+		snapshotPath := ""
+		if !dryRun && request.NodeAddress == nil {
+			snapshotPath = t.cfg.Smartnode.GetRewardsTreeNodeSnapshotPath(index, true)
+		}
+		err = treegen.SetNodeSnapshotPaths(previousSnapshotPath, snapshotPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("Error setting the node snapshot paths: %w", err)
+		}
+	}
+	return treegen, phaseDurations, nil
+}
+
+// Compare the Beacon config against the fallback client's, since a misconfigured Beacon Node would derive the wrong EL block
+func (t *generateRewardsTree) crossCheckEth2Config(eth2Config beacon.Eth2Config, generationPrefix string) error {
+	fallbackConfig, hasFallback, err := t.bc.GetFallbackEth2Config()
+	if err != nil {
+		return err
+	}
+	if !hasFallback {
+		t.log.Printlnf("%s WARNING: Beacon config cross-checking is enabled but there is no fallback client to check against; skipping it.", generationPrefix)
+		return nil
+	}
+	if fallbackConfig.GenesisTime != eth2Config.GenesisTime {
+		return fmt.Errorf("the Beacon Node's genesis time (%d) doesn't match the fallback's (%d)", eth2Config.GenesisTime, fallbackConfig.GenesisTime)
+	}
+	if fallbackConfig.SecondsPerSlot != eth2Config.SecondsPerSlot {
+		return fmt.Errorf("the Beacon Node's slot duration (%ds) doesn't match the fallback's (%ds)", eth2Config.SecondsPerSlot, fallbackConfig.SecondsPerSlot)
+	}
+	t.log.Printlnf("%s Beacon config matches the fallback client's.", generationPrefix)
 	return nil
 }
 
-func (t *generateRewardsTree) generateRewardsTree(index uint64) {
+// Check if the watchtower has started shutting down
+func (t *generateRewardsTree) isStopping() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.stopping
+}
+
+// Stop picking up new work and wait up to the timeout for the interval being generated to finish.
+// If it doesn't finish in time, remove the partially-written files it left behind.
+func (t *generateRewardsTree) shutdown(timeout time.Duration) {
+	t.lock.Lock()
+	t.stopping = true
+	running := t.isRunning
+	index := t.index
+	outputDir := t.outputDir
+	t.lock.Unlock()
+	if !running {
+		return
+	}
+
+	t.log.Printlnf("Waiting up to %s for the rewards tree generation for interval %d to finish before shutting down...", timeout, index)
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		t.lock.Lock()
+		running = t.isRunning
+		t.lock.Unlock()
+		if !running {
+			t.log.Println("Tree generation finished, shutting down.")
+			return
+		}
+		time.Sleep(shutdownPollInterval)
+	}
+
+	t.log.Printlnf("Tree generation for interval %d didn't finish in time; removing any partially-written files.", index)
+	dirs := []string{filepath.Dir(t.cfg.Smartnode.GetRewardsTreePath(index, true))}
+	if outputDir != "" {
+		dirs = append(dirs, outputDir)
+	}
+	for _, dir := range dirs {
+		removed, err := files.RemovePartialFiles(dir)
+		if err != nil {
+			t.log.Printlnf("WARNING: %s", err.Error())
+		}
+		for _, path := range removed {
+			t.log.Printlnf("Removed partial file %s", path)
+		}
+	}
+}
+
+// Update the generation status and publish it for the API to read
+func (t *generateRewardsTree) updateStatus(update func(status *rprewards.GenerationStatus)) {
+	t.lock.Lock()
+	update(&t.status)
+	t.status.Updated = time.Now().UTC()
+	status := t.status
+	t.lock.Unlock()
+
+	path := t.cfg.Smartnode.GetRewardsTreeGenerationStatusPath(true)
+	if err := rprewards.SaveGenerationStatus(path, status); err != nil {
+		t.log.Printlnf("WARNING: couldn't publish the generation status: %s", err.Error())
+	}
+}
+
+// Get the interval currently being generated, if there is one
+func (t *generateRewardsTree) getRunningIndex() (uint64, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.index, t.isRunning
+}
+
+func (t *generateRewardsTree) generateRewardsTree(index uint64, verify bool, dryRun bool, request config.RewardsTreeRequest) {
 	// Begin generation of the tree
 	generationPrefix := fmt.Sprintf("[Interval %d Tree]", index)
-	t.log.Printlnf("%s Starting generation of Merkle rewards tree for interval %d.", generationPrefix, index)
+
+	// Mark the interval as being generated until this returns, whether it succeeds or fails
+	if !dryRun && !verify && request.NodeAddress == nil {
+		releaseLock, err := t.acquireGenerationLock(index)
+		if err != nil {
+			t.log.Printlnf("%s WARNING: %s", generationPrefix, err.Error())
+		} else {
+			defer releaseLock()
+		}
+	}
+
+	if verify {
+		t.log.Printlnf("%s Starting determinism verification of the Merkle rewards tree for interval %d.", generationPrefix, index)
+	} else if dryRun {
+		t.log.Printlnf("%s Starting dry run generation of Merkle rewards tree for interval %d; no files will be written.", generationPrefix, index)
+	} else if request.ProofsOnly {
+		t.log.Printlnf("%s Starting to rebuild the Merkle proofs for interval %d from the existing tree file.", generationPrefix, index)
+	} else if request.NodeAddress != nil {
+		t.log.Printlnf("%s Starting to calculate the rewards of node %s for interval %d; no files will be written.", generationPrefix, request.NodeAddress.Hex(), index)
+	} else {
+		t.log.Printlnf("%s Starting generation of Merkle rewards tree for interval %d.", generationPrefix, index)
+	}
+
+	// Make sure the Beacon Node's genesis time is sane, since every block time depends on it
+	eth2Config, err := t.bc.GetEth2Config()
+	if err != nil {
+		t.handleError(fmt.Errorf("%s Error getting Beacon config: %w", generationPrefix, rprewards.NewGenerationError(rprewards.ErrBeaconUnavailable, err)))
+		return
+	}
+	err = rprewards.ValidateGenesisTime(t.cfg, eth2Config.GenesisTime)
+	if err != nil {
+		t.handleError(fmt.Errorf("%s ***ERROR*** Refusing to generate the tree because %w", generationPrefix, err))
+		return
+	}
+	t.log.Printlnf("%s Beacon genesis time is %s", generationPrefix, sys.FormatUTC(time.Unix(int64(eth2Config.GenesisTime), 0)))
+	if t.cfg.Smartnode.RewardsTreeCrossCheckBeaconConfig.Value == true {
+		err = t.crossCheckEth2Config(eth2Config, generationPrefix)
+		if err != nil {
+			t.handleError(fmt.Errorf("%s ***ERROR*** Refusing to generate the tree because %w", generationPrefix, err))
+			return
+		}
+	}
 
 	// Find the event for this interval
 	rewardsEvent, err := rprewards.GetRewardSnapshotEvent(t.rp, t.cfg, index)
+	if errors.Is(err, rprewards.ErrIntervalNotFound) {
+		t.handleError(fmt.Errorf("%s Interval %d doesn't exist on-chain yet, so there is no tree to generate; wait until the Oracle DAO has submitted it: %w", generationPrefix, index, err))
+		return
+	}
 	if err != nil {
-		t.handleError(fmt.Errorf("%s Error getting event for interval %d: %w", generationPrefix, index, err))
+		t.handleError(fmt.Errorf("%s Error searching for the event for interval %d; check that your Execution client is synced and reachable: %w", generationPrefix, index, err))
 		return
 	}
 	t.log.Printlnf("%s Found snapshot event: Beacon block %s, execution block %s", generationPrefix, rewardsEvent.ConsensusBlock.String(), rewardsEvent.ExecutionBlock.String())
+	t.log.Printlnf("%s Interval runs from %s to %s", generationPrefix, sys.FormatUTC(rewardsEvent.IntervalStartTime), sys.FormatUTC(rewardsEvent.IntervalEndTime))
+
+	// Don't replace a tree that's already known to be good unless that was asked for explicitly
+	if !dryRun && !verify && !request.ProofsOnly && request.NodeAddress == nil && request.OutputDir == "" && !request.Force {
+		treePath := t.cfg.Smartnode.GetExistingRewardsTreePath(index, true)
+		existingFile, err := rprewards.LoadRewardsFile(treePath)
+		if err == nil && existingFile.Index == index && common.HexToHash(existingFile.MerkleRoot) == rewardsEvent.MerkleRoot {
+			t.log.Printlnf("%s The existing tree file %s already matches the canonical root of %s, so it won't be replaced. Request generation with --force to regenerate it anyway.", generationPrefix, treePath, rewardsEvent.MerkleRoot.Hex())
+
+			// There's nothing left to resume, so don't let a leftover checkpoint queue this interval again
+			checkpointPath := t.cfg.Smartnode.GetRewardsTreeCheckpointPath(index, true)
+			err = os.Remove(checkpointPath)
+			if err != nil && !os.IsNotExist(err) {
+				t.log.Printlnf("%s WARNING: couldn't remove checkpoint %s: %s", generationPrefix, checkpointPath, err.Error())
+			}
+			return
+		}
+	}
 
-	// Get the EL block
-	elBlockHeader, err := t.ec.HeaderByNumber(context.Background(), rewardsEvent.ExecutionBlock)
+	// Rebuilding the proofs only needs the canonical root, not any historical state
+	if request.ProofsOnly && !dryRun && !verify {
+		t.rebuildRewardsTreeProofs(index, generationPrefix, rewardsEvent, request)
+		return
+	}
+
+	// Get the EL block, using the pinned one instead of the event's if the request has one
+	elBlockNumber := rewardsEvent.ExecutionBlock
+	if request.ElBlock > 0 {
+		elBlockNumber = big.NewInt(0).SetUint64(request.ElBlock)
+		t.log.Printlnf("%s Using the requested execution block %d instead of the snapshot event's block %s.", generationPrefix, request.ElBlock, rewardsEvent.ExecutionBlock.String())
+	}
+	elBlockHeader, err := t.ec.HeaderByNumber(context.Background(), elBlockNumber)
 	if err != nil {
-		t.handleError(fmt.Errorf("%s Error getting execution block: %w", generationPrefix, err))
+		t.handleError(fmt.Errorf("%s Error getting execution block: %w", generationPrefix, rprewards.NewGenerationError(rprewards.ErrELBlockNotFound, err)))
+		return
+	}
+	elBlockTime := time.Unix(int64(elBlockHeader.Time), 0).UTC()
+	t.log.Printlnf("%s Execution block %d has a timestamp of %s", generationPrefix, elBlockHeader.Number.Uint64(), sys.FormatUTC(elBlockTime))
+
+	// Make sure the EC can actually serve historical calls before spending time on generation
+	err = eth1.CheckHistoricalCallSupport(t.ec, t.rp, t.cfg, elBlockHeader.Number)
+	if err != nil {
+		t.handleError(fmt.Errorf("%s %w", generationPrefix, rprewards.NewGenerationError(rprewards.ErrStateUnavailable, err)))
 		return
 	}
 
@@ -190,7 +723,7 @@ func (t *generateRewardsTree) generateRewardsTree(index uint64) {
 				}
 			} else {
 				// No archive node specified
-				t.handleError(fmt.Errorf("***ERROR*** Primary EC cannot retrieve state for historical block %d and the Archive EC is not specified.", elBlockHeader.Number.Uint64()))
+				t.handleError(rprewards.NewGenerationError(rprewards.ErrStateUnavailable, fmt.Errorf("***ERROR*** Primary EC cannot retrieve state for historical block %d and the Archive EC is not specified.", elBlockHeader.Number.Uint64())))
 				return
 			}
 
@@ -203,18 +736,40 @@ func (t *generateRewardsTree) generateRewardsTree(index uint64) {
 		return
 	}
 
+	// Make sure the client has the full state for the block, not just the contract storage
+	err = eth1.CheckStateAvailable(client, t.cfg, index, elBlockHeader.Number)
+	if err != nil {
+		t.handleError(fmt.Errorf("%s %w", generationPrefix, rprewards.NewGenerationError(rprewards.ErrStateUnavailable, err)))
+		return
+	}
+
+	// Throttle the EC calls made during the calculation if requested
+	maxRequestsPerSecond := t.cfg.Smartnode.RewardsTreeMaxRequestsPerSecond.Value.(uint64)
+	if maxRequestsPerSecond > 0 {
+		t.log.Printlnf("%s Limiting Execution client requests to %d per second.", generationPrefix, maxRequestsPerSecond)
+		client, err = rocketpool.NewRocketPool(rprewards.NewRateLimitedClient(client.Client, maxRequestsPerSecond), common.HexToAddress(t.cfg.Smartnode.GetStorageAddress()))
+		if err != nil {
+			t.handleError(fmt.Errorf("%s Error creating rate-limited Rocket Pool client: %w", generationPrefix, err))
+			return
+		}
+	}
+
 	// Generate the tree
-	t.generateRewardsTreeImpl(client, index, generationPrefix, rewardsEvent, elBlockHeader)
+	if verify {
+		t.verifyRewardsTreeImpl(client, index, generationPrefix, rewardsEvent, elBlockHeader, request)
+	} else {
+		t.generateRewardsTreeImpl(client, index, generationPrefix, rewardsEvent, elBlockHeader, dryRun, request)
+	}
 }
 
-// Implementation for rewards tree generation using a viable EC
-func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool, index uint64, generationPrefix string, rewardsEvent rewards.RewardsEvent, elBlockHeader *types.Header) {
+// Implementation for rewards tree generation using a viable EC. Dry runs only check the root and don't write any files.
+func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool, index uint64, generationPrefix string, rewardsEvent rewards.RewardsEvent, elBlockHeader *types.Header, dryRun bool, request config.RewardsTreeRequest) {
 
 	// Generate the rewards file
 	start := time.Now()
-	treegen, err := rprewards.NewTreeGenerator(t.log, generationPrefix, rp, t.cfg, t.bc, index, rewardsEvent.IntervalStartTime, rewardsEvent.IntervalEndTime, rewardsEvent.ConsensusBlock.Uint64(), elBlockHeader, rewardsEvent.IntervalsPassed.Uint64())
+	treegen, phaseDurations, err := t.newTreeGenerator(rp, index, generationPrefix, rewardsEvent, elBlockHeader, dryRun, request)
 	if err != nil {
-		t.handleError(fmt.Errorf("%s Error creating Merkle tree generator: %w", generationPrefix, err))
+		t.handleError(fmt.Errorf("%s %w", generationPrefix, err))
 		return
 	}
 	rewardsFile, err := treegen.GenerateTree()
@@ -225,16 +780,57 @@ func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool,
 	for address, network := range rewardsFile.InvalidNetworkNodes {
 		t.log.Printlnf("%s WARNING: Node %s has invalid network %d assigned! Using 0 (mainnet) instead.", generationPrefix, address.Hex(), network)
 	}
+	for address, reason := range rewardsFile.UndeterminedSmoothingPoolNodes {
+		t.log.Printlnf("%s WARNING: Node %s has a Smoothing Pool opt-in status that couldn't be determined, so its registration change time was used as-is: %s", generationPrefix, address.Hex(), reason)
+	}
 	t.log.Printlnf("%s Finished in %s", generationPrefix, time.Since(start).String())
 
+	// Single-node runs just report the node's rewards
+	if request.NodeAddress != nil {
+		t.reportNodeRewards(index, generationPrefix, rewardsFile, elBlockHeader, *request.NodeAddress)
+		return
+	}
+
 	// Validate the Merkle root
 	root := common.BytesToHash(rewardsFile.MerkleTree.Root())
+	if root != rewardsEvent.MerkleRoot && t.cfg.Smartnode.RewardsTreeRetryAdjacentBlocks.Value == true && request.ElBlock == 0 {
+		t.log.Printlnf("%s Your Merkle tree had a root of %s, but the canonical Merkle tree's root was %s. Retrying with the adjacent execution blocks...", generationPrefix, root.Hex(), rewardsEvent.MerkleRoot.Hex())
+		adjacentFile, adjacentHeader, adjacentPhaseDurations, err := t.findAdjacentBlockTree(rp, index, generationPrefix, rewardsEvent, elBlockHeader, dryRun, request)
+		if err != nil {
+			t.handleError(fmt.Errorf("%s %w", generationPrefix, err))
+			return
+		}
+		if adjacentFile != nil {
+			t.log.Printlnf("%s Execution block %d produced the canonical root instead of block %d; using that tree.", generationPrefix, adjacentHeader.Number.Uint64(), elBlockHeader.Number.Uint64())
+			rewardsFile = adjacentFile
+			phaseDurations = adjacentPhaseDurations
+			root = common.BytesToHash(rewardsFile.MerkleTree.Root())
+		} else {
+			t.log.Printlnf("%s Neither adjacent execution block produced the canonical root either.", generationPrefix)
+		}
+	}
+	t.coll.ObserveGeneration(index, phaseDurations[rprewards.GenerationPhase_RplRewards], phaseDurations[rprewards.GenerationPhase_MerkleTree])
 	if root != rewardsEvent.MerkleRoot {
 		t.log.Printlnf("%s WARNING: your Merkle tree had a root of %s, but the canonical Merkle tree's root was %s. This file will not be usable for claiming rewards.", generationPrefix, root.Hex(), rewardsEvent.MerkleRoot.Hex())
 	} else {
 		t.log.Printlnf("%s Your Merkle tree's root of %s matches the canonical root! You will be able to use this file for claiming rewards.", generationPrefix, rewardsFile.MerkleRoot)
 	}
 
+	// Dry runs stop here without touching the filesystem
+	if dryRun {
+		if root != rewardsEvent.MerkleRoot {
+			t.sendNotification(notifications.NewIntervalEvent(t.cfg, notifications.EventType_TreeRootMismatch, index,
+				fmt.Sprintf("Rewards tree dry run for interval %d produced the wrong root", index),
+				fmt.Sprintf("The dry run of the Merkle rewards tree for interval %d had a root of %s, but the canonical root is %s.", index, root.Hex(), rewardsEvent.MerkleRoot.Hex())))
+		} else {
+			t.sendNotification(notifications.NewIntervalEvent(t.cfg, notifications.EventType_TreeGenerated, index,
+				fmt.Sprintf("Rewards tree dry run for interval %d succeeded", index),
+				fmt.Sprintf("The dry run of the Merkle rewards tree for interval %d matched the canonical root of %s. No files were written.", index, root.Hex())))
+		}
+		t.log.Printlnf("%s Merkle tree dry run complete, no files were written.", generationPrefix)
+		return
+	}
+
 	// Create the JSON files
 	rewardsFile.MinipoolPerformanceFileCID = "---"
 	t.log.Printlnf("%s Saving JSON files...", generationPrefix)
@@ -250,30 +846,334 @@ func (t *generateRewardsTree) generateRewardsTreeImpl(rp *rocketpool.RocketPool,
 	}
 
 	// Write the files
-	path := t.cfg.Smartnode.GetRewardsTreePath(index, true)
+	fileMode, err := t.cfg.Smartnode.GetRewardsTreeFileMode()
+	if err != nil {
+		t.handleError(fmt.Errorf("%s Error getting rewards tree file permissions: %w", generationPrefix, err))
+		return
+	}
+	jsonPath := t.cfg.Smartnode.GetRewardsTreePath(index, true)
+	path := jsonPath
+	otherPath := t.cfg.Smartnode.GetRewardsTreeGzipPath(index, true)
+	if t.cfg.Smartnode.RewardsTreeGzip.Value == true {
+		path, otherPath = otherPath, path
+	}
 	minipoolPerformancePath := t.cfg.Smartnode.GetMinipoolPerformancePath(index, true)
-	err = ioutil.WriteFile(minipoolPerformancePath, minipoolPerformanceBytes, 0644)
+	if request.OutputDir != "" {
+		path = filepath.Join(request.OutputDir, filepath.Base(path))
+		otherPath = filepath.Join(request.OutputDir, filepath.Base(otherPath))
+		minipoolPerformancePath = filepath.Join(request.OutputDir, filepath.Base(minipoolPerformancePath))
+	}
+	err = files.WriteFileAtomic(minipoolPerformancePath, minipoolPerformanceBytes, fileMode)
 	if err != nil {
 		t.handleError(fmt.Errorf("%s Error saving minipool performance file to %s: %w", generationPrefix, minipoolPerformancePath, err))
 		return
 	}
-	err = ioutil.WriteFile(path, wrapperBytes, 0644)
+	err = t.saveRewardsTreeFile(generationPrefix, path, wrapperBytes, root, fileMode)
 	if err != nil {
-		t.handleError(fmt.Errorf("%s Error saving rewards file to %s: %w", generationPrefix, path, err))
+		t.handleError(fmt.Errorf("%s %w", generationPrefix, err))
 		return
 	}
 
-	t.log.Printlnf("%s Merkle tree generation complete!", generationPrefix)
-	t.lock.Lock()
-	t.isRunning = false
-	t.lock.Unlock()
+	// Remove the copy in the other format so an old file can't shadow the new one
+	err = os.Remove(otherPath)
+	if err != nil && !os.IsNotExist(err) {
+		t.log.Printlnf("%s WARNING: couldn't remove the old rewards file %s: %s", generationPrefix, otherPath, err.Error())
+	}
+
+	// Save the binary copy; the JSON file is the canonical one, so this failing isn't fatal
+	binaryPath := t.cfg.Smartnode.GetRewardsTreeBinaryPath(index, true)
+	if request.OutputDir != "" {
+		binaryPath = filepath.Join(request.OutputDir, filepath.Base(binaryPath))
+	}
+	err = rprewards.SaveRewardsFileBinary(rewardsFile, binaryPath, fileMode)
+	if err != nil {
+		t.log.Printlnf("%s WARNING: couldn't save the binary copy of the rewards file: %s", generationPrefix, err.Error())
+	}
+
+	// Save the summary for auditing
+	summaryPath := t.cfg.Smartnode.GetRewardsTreeSummaryPath(index, true)
+	if request.OutputDir != "" {
+		summaryPath = filepath.Join(request.OutputDir, filepath.Base(summaryPath))
+	}
+	err = rprewards.SaveRewardsSummary(rewardsFile, root == rewardsEvent.MerkleRoot, summaryPath, fileMode)
+	if err != nil {
+		t.log.Printlnf("%s WARNING: couldn't save the rewards summary: %s", generationPrefix, err.Error())
+	} else {
+		t.log.Printlnf("%s Saved the rewards summary to %s", generationPrefix, summaryPath)
+	}
+
+	// Save the withdrawal addresses next to the tree, since they can't go in the submitted file
+	withdrawalAddressesPath := t.cfg.Smartnode.GetRewardsTreeWithdrawalAddressesPath(index, true)
+	if request.OutputDir != "" {
+		withdrawalAddressesPath = filepath.Join(request.OutputDir, filepath.Base(withdrawalAddressesPath))
+	}
+	err = rprewards.SaveWithdrawalAddresses(rewardsFile, withdrawalAddressesPath, fileMode)
+	if err != nil {
+		t.log.Printlnf("%s WARNING: couldn't save the withdrawal addresses: %s", generationPrefix, err.Error())
+	}
+
+	// Clean up the checkpoint now that the tree has been saved
+	checkpointPath := t.cfg.Smartnode.GetRewardsTreeCheckpointPath(index, true)
+	err = os.Remove(checkpointPath)
+	if err != nil && !os.IsNotExist(err) {
+		t.log.Printlnf("%s WARNING: couldn't remove checkpoint %s: %s", generationPrefix, checkpointPath, err.Error())
+	}
+
+	// Upload the tree to IPFS if requested
+	cid := t.uploadTreeToIpfs(generationPrefix, jsonPath, wrapperBytes)
+	if cid != "" {
+		t.log.Printlnf("%s Merkle tree generation complete! Uploaded to IPFS with CID %s.", generationPrefix, cid)
+	} else {
+		t.log.Printlnf("%s Merkle tree generation complete!", generationPrefix)
+	}
+	t.sendNotification(notifications.NewIntervalEvent(t.cfg, notifications.EventType_TreeGenerated, index,
+		fmt.Sprintf("Rewards tree for interval %d generated", index),
+		fmt.Sprintf("The Merkle rewards tree for interval %d was generated with a root of %s and saved to %s.", index, root.Hex(), path)))
+
+}
+
+// Print the rewards a single node earned in the interval
+func (t *generateRewardsTree) reportNodeRewards(index uint64, generationPrefix string, rewardsFile *rprewards.RewardsFile, elBlockHeader *types.Header, nodeAddress common.Address) {
+	nodeRewards, exists := rewardsFile.NodeRewards[nodeAddress]
+	if !exists {
+		t.log.Printlnf("%s Node %s didn't earn any rewards in interval %d (as of EL block %d).", generationPrefix, nodeAddress.Hex(), index, elBlockHeader.Number.Uint64())
+		return
+	}
+	t.log.Printlnf("%s Rewards for node %s in interval %d (as of EL block %d):", generationPrefix, nodeAddress.Hex(), index, elBlockHeader.Number.Uint64())
+	t.log.Printlnf("%s     Collateral RPL:      %.6f RPL", generationPrefix, eth.WeiToEth(&nodeRewards.CollateralRpl.Int))
+	t.log.Printlnf("%s     Oracle DAO RPL:      %.6f RPL", generationPrefix, eth.WeiToEth(&nodeRewards.OracleDaoRpl.Int))
+	t.log.Printlnf("%s     Smoothing pool ETH:  %.6f ETH", generationPrefix, eth.WeiToEth(&nodeRewards.SmoothingPoolEth.Int))
+	if nodeRewards.WithdrawalAddress != nil {
+		t.log.Printlnf("%s     Sent to withdrawal address %s", generationPrefix, nodeRewards.WithdrawalAddress.Hex())
+	}
+	t.log.Printlnf("%s Single-node calculation complete, no files were written.", generationPrefix)
+}
+
+// Rebuild the Merkle proofs of an existing tree file from its reward amounts, saving it only if the rebuilt root matches
+// the canonical one
+func (t *generateRewardsTree) rebuildRewardsTreeProofs(index uint64, generationPrefix string, rewardsEvent rewards.RewardsEvent, request config.RewardsTreeRequest) {
+
+	// Load the amounts, falling back to the compressed copy if the main file is too damaged to read
+	start := time.Now()
+	sourcePath := t.cfg.Smartnode.GetExistingRewardsTreePath(index, true)
+	rewardsFile, err := rprewards.LoadRewardsFile(sourcePath)
+	if err != nil {
+		compressedPath := sourcePath + config.RewardsTreeIpfsExtension
+		var compressedErr error
+		rewardsFile, compressedErr = rprewards.LoadRewardsFile(compressedPath)
+		if compressedErr != nil {
+			t.handleError(fmt.Errorf("%s Error loading the existing tree file to rebuild the proofs from: %w", generationPrefix, err))
+			return
+		}
+		t.log.Printlnf("%s Couldn't read %s (%s), using the amounts in %s instead.", generationPrefix, sourcePath, err.Error(), compressedPath)
+	}
+	if rewardsFile.Index != index {
+		t.handleError(fmt.Errorf("%s ***ERROR*** The existing tree file is for interval %d, not %d.", generationPrefix, rewardsFile.Index, index))
+		return
+	}
+
+	// Rebuild the tree
+	err = rprewards.RebuildMerkleProofs(rewardsFile)
+	if err != nil {
+		t.handleError(fmt.Errorf("%s Error rebuilding the Merkle proofs: %w", generationPrefix, err))
+		return
+	}
+	t.log.Printlnf("%s Rebuilt the Merkle proofs for %d nodes in %s", generationPrefix, len(rewardsFile.NodeRewards), time.Since(start).String())
+
+	// Only replace the file if it's correct now; a wrong root means the amounts themselves are damaged
+	root := common.BytesToHash(rewardsFile.MerkleTree.Root())
+	if root != rewardsEvent.MerkleRoot {
+		t.handleError(fmt.Errorf("%s ***ERROR*** %w", generationPrefix, rprewards.NewGenerationError(rprewards.ErrRootMismatch, fmt.Errorf("The rebuilt tree had a root of %s, but the canonical root is %s, so the reward amounts in the file are wrong too. The file was left alone; generate the full tree to replace it.", root.Hex(), rewardsEvent.MerkleRoot.Hex()))))
+		return
+	}
+	t.log.Printlnf("%s The rebuilt tree's root of %s matches the canonical root!", generationPrefix, rewardsFile.MerkleRoot)
+
+	// Save the file
+	wrapperBytes, err := json.Marshal(rewardsFile)
+	if err != nil {
+		t.handleError(fmt.Errorf("%s Error serializing proof wrapper into JSON: %w", generationPrefix, err))
+		return
+	}
+	fileMode, err := t.cfg.Smartnode.GetRewardsTreeFileMode()
+	if err != nil {
+		t.handleError(fmt.Errorf("%s Error getting rewards tree file permissions: %w", generationPrefix, err))
+		return
+	}
+	path := sourcePath
+	if request.OutputDir != "" {
+		path = filepath.Join(request.OutputDir, filepath.Base(path))
+	}
+	err = t.saveRewardsTreeFile(generationPrefix, path, wrapperBytes, root, fileMode)
+	if err != nil {
+		t.handleError(fmt.Errorf("%s %w", generationPrefix, err))
+		return
+	}
+
+	t.log.Printlnf("%s Merkle proofs rebuilt and saved to %s.", generationPrefix, path)
+	t.sendNotification(notifications.NewIntervalEvent(t.cfg, notifications.EventType_TreeGenerated, index,
+		fmt.Sprintf("Rewards tree proofs for interval %d rebuilt", index),
+		fmt.Sprintf("The Merkle proofs for interval %d were rebuilt from the existing amounts with a root of %s and saved to %s.", index, root.Hex(), path)))
 
 }
 
+// Upload a generated tree to the configured IPFS node and get its CID. Returns an empty string if uploading is disabled
+// or fails, since the tree has already been saved locally.
+func (t *generateRewardsTree) uploadTreeToIpfs(generationPrefix string, path string, wrapperBytes []byte) string {
+	if t.cfg.Smartnode.UploadTreesToIpfs.Value != true {
+		return ""
+	}
+	apiUrl := t.cfg.Smartnode.IpfsApiUrl.Value.(string)
+	if apiUrl == "" {
+		t.log.Printlnf("%s WARNING: uploading trees to IPFS is enabled but no IPFS API URL is set, skipping the upload.", generationPrefix)
+		return ""
+	}
+
+	cid, err := ipfs.AddFile(apiUrl, path, wrapperBytes)
+	if err != nil {
+		t.log.Printlnf("%s WARNING: couldn't upload the tree to IPFS: %s", generationPrefix, err.Error())
+		return ""
+	}
+	return cid
+}
+
+// Save a serialized rewards tree to the provided path, gzipping it if the path has the gzip extension. Gzipped files are
+// read back afterwards to make sure they decompress to a tree with the same root.
+func (t *generateRewardsTree) saveRewardsTreeFile(generationPrefix string, path string, wrapperBytes []byte, root common.Hash, fileMode os.FileMode) error {
+	if !strings.HasSuffix(path, config.RewardsTreeGzipExtension) {
+		err := files.WriteFileAtomic(path, wrapperBytes, fileMode)
+		if err != nil {
+			return fmt.Errorf("Error saving rewards file to %s: %w", path, err)
+		}
+		return nil
+	}
+
+	err := rprewards.SaveRewardsFileGzip(wrapperBytes, path, fileMode)
+	if err != nil {
+		return err
+	}
+	savedFile, err := rprewards.LoadRewardsFile(path)
+	if err != nil {
+		return fmt.Errorf("Error reading back the compressed rewards file %s: %w", path, err)
+	}
+	savedRoot, err := rprewards.ComputeMerkleRoot(savedFile)
+	if err != nil {
+		return fmt.Errorf("Error computing the Merkle root of the compressed rewards file %s: %w", path, err)
+	}
+	if savedRoot != root {
+		os.Remove(path)
+		return rprewards.NewGenerationError(rprewards.ErrRootMismatch, fmt.Errorf("***ERROR*** The compressed rewards file %s has a root of %s instead of %s, so it was removed.", path, savedRoot.Hex(), root.Hex()))
+	}
+	t.log.Printlnf("%s Saved the compressed rewards file to %s.", generationPrefix, path)
+	return nil
+}
+
+// Read the options stored in a request file, making sure the output directory (if any) can be written to so it's
+// caught before spending time on generation
+func readRewardsTreeRequest(path string) (config.RewardsTreeRequest, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return config.RewardsTreeRequest{}, err
+	}
+	request, err := config.ParseRewardsTreeRequest(contents)
+	if err != nil {
+		return config.RewardsTreeRequest{}, err
+	}
+	if request.OutputDir != "" {
+		err = files.CheckDirWritable(request.OutputDir)
+		if err != nil {
+			return config.RewardsTreeRequest{}, fmt.Errorf("can't save to the requested output directory: %w", err)
+		}
+	}
+	return request, nil
+}
+
+// Look for generation lock files. Returns the interval of a live lock if there is one; locks that haven't been
+// refreshed within the timeout were left by a run that crashed, so they're deleted.
+func (t *generateRewardsTree) checkGenerationLocks(dir string, files []os.FileInfo) (uint64, bool, error) {
+	for _, file := range files {
+		filename := file.Name()
+		if file.IsDir() || !strings.HasSuffix(filename, config.RewardsTreeGeneratingSuffix) {
+			continue
+		}
+		path := filepath.Join(dir, filename)
+		index, err := strconv.ParseUint(strings.TrimSuffix(filename, config.RewardsTreeGeneratingSuffix), 0, 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("Error parsing index from [%s]: %w", filename, err)
+		}
+
+		age := time.Since(file.ModTime())
+		if age < config.RewardsTreeGeneratingLockTimeout {
+			return index, true, nil
+		}
+		t.log.Printlnf("WARNING: removing the stale generation lock for interval %d, which hasn't been updated in %s.", index, age.Round(time.Second))
+		err = os.Remove(path)
+		if err != nil && !os.IsNotExist(err) {
+			return 0, false, fmt.Errorf("Error removing stale generation lock [%s]: %w", path, err)
+		}
+	}
+	return 0, false, nil
+}
+
+// Create the lock file for an interval and keep it fresh until the returned release function is called
+func (t *generateRewardsTree) acquireGenerationLock(index uint64) (func(), error) {
+	path := t.cfg.Smartnode.GetRewardsTreeGeneratingPath(index, true)
+	contents := fmt.Sprintf("pid=%d\nstarted=%s\n", os.Getpid(), time.Now().UTC().Format(time.RFC3339))
+	err := os.WriteFile(path, []byte(contents), 0644)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create generation lock %s: %w", path, err)
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(config.RewardsTreeGeneratingLockTimeout / 4)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case now := <-ticker.C:
+				if err := os.Chtimes(path, now, now); err != nil {
+					t.log.Printlnf("WARNING: couldn't refresh generation lock %s: %s", path, err.Error())
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-done
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			t.log.Printlnf("WARNING: couldn't remove generation lock %s: %s", path, err.Error())
+		}
+	}, nil
+}
+
 func (t *generateRewardsTree) handleError(err error) {
 	t.errLog.Println(err)
 	t.errLog.Println("*** Rewards tree generation failed. ***")
 	t.lock.Lock()
-	t.isRunning = false
+	index := t.index
+	t.failed = true
+	t.failure = err
 	t.lock.Unlock()
+
+	// Checkpoints are only for resuming after a crash, so don't retry a failed run from one
+	checkpointPath := t.cfg.Smartnode.GetRewardsTreeCheckpointPath(index, true)
+	if err := os.Remove(checkpointPath); err != nil && !os.IsNotExist(err) {
+		t.errLog.Printlnf("WARNING: couldn't remove checkpoint %s: %s", checkpointPath, err.Error())
+	}
+
+	t.sendNotification(notifications.NewIntervalEvent(t.cfg, notifications.EventType_TreeGenerationFailed, index,
+		fmt.Sprintf("Rewards tree generation for interval %d failed", index),
+		err.Error()))
+}
+
+// Send a notification, logging any failures instead of interrupting generation
+func (t *generateRewardsTree) sendNotification(event notifications.Event) {
+	err := t.notifier.Notify(event)
+	if err != nil {
+		t.log.Printlnf("WARNING: Couldn't send notification: %s", err.Error())
+	}
 }
@@ -0,0 +1,53 @@
+package watchtower
+
+import "time"
+
+// The weight given to the newest rate sample in the moving average; lower values make the estimate steadier
+const generationRateSmoothing float64 = 0.3
+
+// Estimates how long the rest of a rewards tree generation will take from a moving average of its processing rate
+type generationEstimator struct {
+	lastProcessed int
+	lastTime      time.Time
+	rate          float64
+	hasSample     bool
+}
+
+// Create a new generation time estimator
+func newGenerationEstimator() *generationEstimator {
+	return &generationEstimator{
+		lastProcessed: -1,
+	}
+}
+
+// Record a progress report and get the estimated time remaining. Returns false if there isn't enough data for an
+// estimate yet, or if there's nothing left to process.
+func (e *generationEstimator) update(processed int, total int) (time.Duration, bool) {
+	now := time.Now()
+
+	// The first report is only a baseline, since a resumed run doesn't start from 0
+	if e.lastProcessed < 0 || processed < e.lastProcessed {
+		e.lastProcessed = processed
+		e.lastTime = now
+		return 0, false
+	}
+
+	elapsed := now.Sub(e.lastTime).Seconds()
+	if elapsed > 0 && processed > e.lastProcessed {
+		sample := float64(processed-e.lastProcessed) / elapsed
+		if e.hasSample {
+			e.rate = generationRateSmoothing*sample + (1-generationRateSmoothing)*e.rate
+		} else {
+			e.rate = sample
+			e.hasSample = true
+		}
+		e.lastProcessed = processed
+		e.lastTime = now
+	}
+
+	if !e.hasSample || e.rate <= 0 || processed >= total {
+		return 0, false
+	}
+	remaining := float64(total-processed) / e.rate
+	return time.Duration(remaining * float64(time.Second)), true
+}
@@ -0,0 +1,88 @@
+package watchtower
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// The last run of a single watchtower task
+type taskHeartbeat struct {
+	LastStart time.Time  `json:"lastStart"`
+	LastEnd   *time.Time `json:"lastEnd,omitempty"`
+	Running   bool       `json:"running"`
+}
+
+// The contents of the heartbeat file
+type heartbeatFile struct {
+	Updated            time.Time                 `json:"updated"`
+	GeneratingInterval *uint64                   `json:"generatingInterval,omitempty"`
+	Tasks              map[string]*taskHeartbeat `json:"tasks"`
+}
+
+// Records when each task starts and finishes in a file, so an external monitor can tell a task that's hung or stopped
+// ticking apart from one that's working
+type heartbeat struct {
+	path               string
+	getRunningInterval func() (uint64, bool)
+	lock               sync.Mutex
+	tasks              map[string]*taskHeartbeat
+}
+
+// Create a new heartbeat that writes to the provided path
+func newHeartbeat(path string, getRunningInterval func() (uint64, bool)) *heartbeat {
+	return &heartbeat{
+		path:               path,
+		getRunningInterval: getRunningInterval,
+		tasks:              map[string]*taskHeartbeat{},
+	}
+}
+
+// Record the start of a task's run
+func (h *heartbeat) start(task string) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.tasks[task] = &taskHeartbeat{
+		LastStart: time.Now().UTC(),
+		Running:   true,
+	}
+	return h.save()
+}
+
+// Record the end of a task's run
+func (h *heartbeat) end(task string) error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	beat, exists := h.tasks[task]
+	if !exists {
+		beat = &taskHeartbeat{}
+		h.tasks[task] = beat
+	}
+	now := time.Now().UTC()
+	beat.LastEnd = &now
+	beat.Running = false
+	return h.save()
+}
+
+// Write the heartbeat file; the caller must hold the lock
+func (h *heartbeat) save() error {
+	file := heartbeatFile{
+		Updated: time.Now().UTC(),
+		Tasks:   h.tasks,
+	}
+	if index, isRunning := h.getRunningInterval(); isRunning {
+		file.GeneratingInterval = &index
+	}
+	bytes, err := json.Marshal(file)
+	if err != nil {
+		return fmt.Errorf("error serializing heartbeat: %w", err)
+	}
+	err = files.WriteFileAtomic(h.path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error saving heartbeat to %s: %w", h.path, err)
+	}
+	return nil
+}
@@ -12,7 +12,7 @@ import (
 	"github.com/urfave/cli"
 )
 
-func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *collectors.ScrubCollector) error {
+func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *collectors.ScrubCollector, challengeCollector *collectors.ChallengeCollector, rewardsTreeCollector *collectors.RewardsTreeCollector) error {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -28,6 +28,8 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *co
 	// Set up Prometheus
 	registry := prometheus.NewRegistry()
 	registry.MustRegister(scrubCollector)
+	registry.MustRegister(challengeCollector)
+	registry.MustRegister(rewardsTreeCollector)
 	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
 
 	// Start the HTTP server
@@ -0,0 +1,180 @@
+package watchtower
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The minimum time between rewards tree pruning runs
+const rewardsTreePruneInterval time.Duration = 24 * time.Hour
+
+// Prune rewards trees task
+type pruneRewardsTrees struct {
+	c            *cli.Context
+	log          log.ColorLogger
+	cfg          *config.RocketPoolConfig
+	w            *wallet.Wallet
+	rp           *rocketpool.RocketPool
+	lastPrunedAt time.Time
+}
+
+// Create prune rewards trees task
+func newPruneRewardsTrees(c *cli.Context, logger log.ColorLogger) (*pruneRewardsTrees, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &pruneRewardsTrees{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		w:   w,
+		rp:  rp,
+	}, nil
+
+}
+
+// Delete the files of old intervals, keeping the most recent ones and any the node can still claim
+func (t *pruneRewardsTrees) run() error {
+
+	// Check if pruning is enabled and due
+	retentionCount := t.cfg.Smartnode.RewardsTreeRetentionCount.Value.(uint64)
+	if retentionCount == 0 {
+		return nil
+	}
+	if time.Since(t.lastPrunedAt) < rewardsTreePruneInterval {
+		return nil
+	}
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the latest finished interval
+	currentIndexBig, err := rewards.GetRewardIndex(t.rp, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting current reward index: %w", err)
+	}
+	currentIndex := currentIndexBig.Uint64()
+	if currentIndex <= retentionCount {
+		t.lastPrunedAt = time.Now()
+		return nil
+	}
+
+	// Get the intervals the node hasn't claimed yet
+	unclaimed, _, err := rprewards.GetClaimStatus(t.rp, nodeAccount.Address)
+	if err != nil {
+		return fmt.Errorf("Error getting rewards claim status: %w", err)
+	}
+	isUnclaimed := map[uint64]bool{}
+	for _, index := range unclaimed {
+		isUnclaimed[index] = true
+	}
+
+	// Delete the files for every interval before the retained ones
+	pruneBefore := currentIndex - retentionCount
+	prunedCount := 0
+	for index := uint64(0); index < pruneBefore; index++ {
+		rewardsTreePath := t.cfg.Smartnode.GetRewardsTreePath(index, true)
+		if isUnclaimed[index] && t.hasClaimableRewards(rewardsTreePath, nodeAccount.Address) {
+			continue
+		}
+
+		minipoolPerformancePath := t.cfg.Smartnode.GetMinipoolPerformancePath(index, true)
+		paths := []string{
+			rewardsTreePath,
+			rewardsTreePath + config.RewardsTreeIpfsExtension,
+			t.cfg.Smartnode.GetRewardsTreeGzipPath(index, true),
+			t.cfg.Smartnode.GetRewardsTreeBinaryPath(index, true),
+			minipoolPerformancePath,
+			minipoolPerformancePath + config.RewardsTreeIpfsExtension,
+			t.cfg.Smartnode.GetRewardsTreeSummaryPath(index, true),
+			t.cfg.Smartnode.GetRewardsTreeWithdrawalAddressesPath(index, true),
+		}
+		deleted := false
+		for _, path := range paths {
+			err := os.Remove(path)
+			if err == nil {
+				deleted = true
+			} else if !os.IsNotExist(err) {
+				return fmt.Errorf("Error deleting %s: %w", path, err)
+			}
+		}
+		if deleted {
+			prunedCount++
+		}
+	}
+
+	if prunedCount > 0 {
+		t.log.Printlnf("Pruned the rewards tree files for %d old intervals, keeping the latest %d.", prunedCount, retentionCount)
+	}
+	t.lastPrunedAt = time.Now()
+	return nil
+
+}
+
+// Check if the node has rewards in the provided tree file. Files that can't be read are assumed to have rewards so
+// they're never deleted by mistake.
+func (t *pruneRewardsTrees) hasClaimableRewards(path string, nodeAddress common.Address) bool {
+
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		// Check the gzipped copy instead if that's what was saved
+		gzipPath := path + config.RewardsTreeGzipExtension
+		if _, err := os.Stat(gzipPath); err == nil {
+			gzipFile, err := rprewards.LoadRewardsFile(gzipPath)
+			if err != nil {
+				t.log.Printlnf("WARNING: couldn't load %s to check for unclaimed rewards, it will be kept: %s", gzipPath, err.Error())
+				return true
+			}
+			_, exists := gzipFile.NodeRewards[nodeAddress]
+			return exists
+		}
+
+		// Only keep the compressed copy if there is one, since it can't be checked without decompressing it
+		_, err = os.Stat(path + config.RewardsTreeIpfsExtension)
+		return err == nil
+	}
+	if err != nil {
+		t.log.Printlnf("WARNING: couldn't read %s to check for unclaimed rewards, it will be kept: %s", path, err.Error())
+		return true
+	}
+
+	var rewardsFile rprewards.RewardsFile
+	err = json.Unmarshal(bytes, &rewardsFile)
+	if err != nil {
+		t.log.Printlnf("WARNING: couldn't deserialize %s to check for unclaimed rewards, it will be kept: %s", path, err.Error())
+		return true
+	}
+
+	_, exists := rewardsFile.NodeRewards[nodeAddress]
+	return exists
+
+}
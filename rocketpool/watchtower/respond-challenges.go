@@ -1,31 +1,49 @@
 package watchtower
 
 import (
+	"context"
 	"fmt"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	tnsettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
+// Settings
+const (
+	challengeResponseAttempts   int           = 3
+	challengeResponseRetryDelay time.Duration = 15 * time.Second
+)
+
 // Respond to challenges task
 type respondChallenges struct {
-	c   *cli.Context
-	log log.ColorLogger
-	cfg *config.RocketPoolConfig
-	w   *wallet.Wallet
-	rp  *rocketpool.RocketPool
+	c        *cli.Context
+	log      log.ColorLogger
+	cfg      *config.RocketPoolConfig
+	w        *wallet.Wallet
+	rp       *rocketpool.RocketPool
+	notifier notifications.Notifier
+	coll     *collectors.ChallengeCollector
+
+	// The challenge time of each member's latest detected challenge, so challenges that last for several runs are only counted once
+	detectedChallenges map[common.Address]uint64
 }
 
 // Create respond to challenges task
-func newRespondChallenges(c *cli.Context, logger log.ColorLogger) (*respondChallenges, error) {
+func newRespondChallenges(c *cli.Context, logger log.ColorLogger, coll *collectors.ChallengeCollector) (*respondChallenges, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -41,13 +59,23 @@ func newRespondChallenges(c *cli.Context, logger log.ColorLogger) (*respondChall
 		return nil, err
 	}
 
+	notifier, err := notifications.NewNotifier(cfg)
+	if err != nil {
+		logger.Printlnf("WARNING: notifications are disabled: %s", err.Error())
+		notifier = notifications.NewNoopNotifier()
+	}
+
 	// Return task
 	return &respondChallenges{
-		c:   c,
-		log: logger,
-		cfg: cfg,
-		w:   w,
-		rp:  rp,
+		c:        c,
+		log:      logger,
+		cfg:      cfg,
+		w:        w,
+		rp:       rp,
+		notifier: notifier,
+		coll:     coll,
+
+		detectedChallenges: map[common.Address]uint64{},
 	}, nil
 
 }
@@ -78,34 +106,164 @@ func (t *respondChallenges) run() error {
 	// Log
 	t.log.Println("Checking for challenges to respond to...")
 
+	// Respond to challenges against this node
+	err = t.respondToOwnChallenge(nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+
+	// Decide expired challenges against the other members
+	if t.cfg.Smartnode.DecideExpiredChallenges.Value == true {
+		return t.decideExpiredChallenges(nodeAccount.Address)
+	}
+	return nil
+
+}
+
+// Respond to a challenge against this node, if there is one
+func (t *respondChallenges) respondToOwnChallenge(nodeAddress common.Address) error {
+
 	// Check for active challenges
-	isChallenged, err := trustednode.GetMemberIsChallenged(t.rp, nodeAccount.Address, nil)
+	isChallenged, err := trustednode.GetMemberIsChallenged(t.rp, nodeAddress, nil)
 	if err != nil {
 		return err
 	}
 	if !isChallenged {
 		return nil
 	}
+	detectedTime := time.Now()
+	t.recordChallengeDetected(nodeAddress)
 
 	// Log
-	t.log.Printlnf("Node %s has an active challenge against it, responding...", nodeAccount.Address.Hex())
+	t.log.Printlnf("Node %s has an active challenge against it, responding...", nodeAddress.Hex())
+	action := "The watchtower is attempting to respond to it."
+	if t.cfg.Smartnode.ExportChallengeResponses.Value == true {
+		action = "The watchtower is exporting the response so it can be signed offline."
+	}
+	err = t.notifier.Notify(notifications.NewEvent(t.cfg, notifications.EventType_ChallengeDetected,
+		"Oracle DAO challenge detected",
+		fmt.Sprintf("Node %s has an active challenge against it. %s", nodeAddress.Hex(), action)))
+	if err != nil {
+		t.log.Printlnf("WARNING: Couldn't send notification: %s", err.Error())
+	}
+
+	// Export the response for offline signing if requested
+	if t.cfg.Smartnode.ExportChallengeResponses.Value == true {
+		return t.exportChallengeResponse(nodeAddress)
+	}
+
+	// Respond to challenge
+	hash, submitted, err := t.decideChallenge(nodeAddress)
+	if err != nil {
+		t.reportChallenge(nodeAddress, detectedTime, &hash, err)
+		return err
+	}
+	if !submitted {
+		return nil
+	}
+	t.reportChallenge(nodeAddress, detectedTime, &hash, nil)
+
+	// Log & return
+	t.log.Printlnf("Successfully responded to challenge against node %s.", nodeAddress.Hex())
+	return nil
+
+}
+
+// Decide the challenges against other members whose response windows have passed
+func (t *respondChallenges) decideExpiredChallenges(nodeAddress common.Address) error {
+
+	// The exported response only covers this node, and there's no one to sign these offline
+	if t.cfg.Smartnode.ExportChallengeResponses.Value == true {
+		t.log.Println("Challenge responses are being exported for offline signing, so expired challenges against other members won't be decided.")
+		return nil
+	}
+
+	// Get the challenge window
+	challengeWindow, err := tnsettings.GetChallengeWindow(t.rp, nil)
+	if err != nil {
+		return fmt.Errorf("Could not get the challenge window: %w", err)
+	}
+
+	// Check each of the other members
+	memberAddresses, err := trustednode.GetMemberAddresses(t.rp, nil)
+	if err != nil {
+		return fmt.Errorf("Could not get the Oracle DAO members: %w", err)
+	}
+	for _, memberAddress := range memberAddresses {
+		if memberAddress == nodeAddress {
+			continue
+		}
+		isChallenged, err := trustednode.GetMemberIsChallenged(t.rp, memberAddress, nil)
+		if err != nil {
+			return err
+		}
+		if !isChallenged {
+			continue
+		}
+
+		detectedTime := time.Now()
+
+		// Skip challenges that the member can still respond to
+		challengedTime, err := getMemberChallengedTime(t.rp, memberAddress)
+		if err != nil {
+			return err
+		}
+		t.countChallenge(memberAddress, challengedTime)
+		expiryTime := time.Unix(int64(challengedTime+challengeWindow), 0)
+		if !time.Now().After(expiryTime) {
+			t.log.Printlnf("Node %s has an active challenge against it that can be responded to until %s.", memberAddress.Hex(), expiryTime.UTC().Format(time.RFC3339))
+			continue
+		}
+
+		// Decide it
+		t.log.Printlnf("The challenge against node %s expired at %s, deciding it...", memberAddress.Hex(), expiryTime.UTC().Format(time.RFC3339))
+		hash, submitted, err := t.decideChallenge(memberAddress)
+		if err != nil {
+			t.log.Printlnf("Could not decide the challenge against node %s: %s", memberAddress.Hex(), err.Error())
+			t.reportChallenge(memberAddress, detectedTime, &hash, err)
+			continue
+		}
+		if !submitted {
+			continue
+		}
+		t.reportChallenge(memberAddress, detectedTime, &hash, nil)
+		t.log.Printlnf("Successfully decided the challenge against node %s.", memberAddress.Hex())
+	}
+
+	return nil
+
+}
+
+// Submit a transaction deciding the challenge against the provided member.
+// Returns false if it wasn't submitted because gas was too high.
+func (t *respondChallenges) decideChallenge(memberAddress common.Address) (common.Hash, bool, error) {
+
+	// Wait for the next check if the base fee is above the ceiling
+	belowCeiling, err := t.isBaseFeeBelowCeiling(memberAddress)
+	if err != nil {
+		return common.Hash{}, false, err
+	}
+	if !belowCeiling {
+		return common.Hash{}, false, nil
+	}
 
 	// Get transactor
 	opts, err := t.w.GetNodeAccountTransactor()
 	if err != nil {
-		return err
+		return common.Hash{}, false, err
 	}
 
 	// Get the gas limit
-	gasInfo, err := trustednode.EstimateDecideChallengeGas(t.rp, nodeAccount.Address, opts)
+	gasInfo, err := trustednode.EstimateDecideChallengeGas(t.rp, memberAddress, opts)
 	if err != nil {
-		return fmt.Errorf("Could not estimate the gas required to respond to the challenge: %w", err)
+		t.recordResponse(false)
+		return common.Hash{}, false, fmt.Errorf("Could not estimate the gas required to decide the challenge: %w", err)
 	}
 
 	// Print the gas info
 	maxFee := eth.GweiToWei(WatchtowerMaxFee)
 	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, t.log, maxFee, 0) {
-		return nil
+		return common.Hash{}, false, nil
 	}
 
 	// Set the gas settings
@@ -113,20 +271,119 @@ func (t *respondChallenges) run() error {
 	opts.GasTipCap = eth.GweiToWei(WatchtowerMaxPriorityFee)
 	opts.GasLimit = gasInfo.SafeGasLimit
 
-	// Respond to challenge
-	hash, err := trustednode.DecideChallenge(t.rp, nodeAccount.Address, opts)
-	if err != nil {
-		return err
+	// Decide the challenge, retrying with backoff so a transient failure doesn't leave it until the next cycle
+	var hash common.Hash
+	retryDelay := challengeResponseRetryDelay
+	for attempt := 1; ; attempt++ {
+		hash, err = trustednode.DecideChallenge(t.rp, memberAddress, opts)
+		if err == nil {
+			break
+		}
+		if attempt == challengeResponseAttempts {
+			t.recordResponse(false)
+			return common.Hash{}, false, fmt.Errorf("Could not decide the challenge after %d attempts: %w", attempt, err)
+		}
+		t.log.Printlnf("Attempt %d of %d to decide the challenge against node %s failed (%s), retrying in %s...", attempt, challengeResponseAttempts, memberAddress.Hex(), err.Error(), retryDelay)
+		time.Sleep(retryDelay)
+		retryDelay *= 2
 	}
 
 	// Print TX info and wait for it to be included in a block
 	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, t.log)
 	if err != nil {
-		return err
+		t.recordResponse(false)
+		return hash, false, err
 	}
+	t.recordResponse(true)
+	return hash, true, nil
 
-	// Log & return
-	t.log.Printlnf("Successfully responded to challenge against node %s.", nodeAccount.Address.Hex())
-	return nil
+}
+
+// Check the current base fee against the challenge response ceiling, logging a warning if it's too high
+func (t *respondChallenges) isBaseFeeBelowCeiling(memberAddress common.Address) (bool, error) {
+	maxBaseFeeGwei := t.cfg.Smartnode.ChallengeMaxBaseFee.Value.(float64)
+	if maxBaseFeeGwei <= 0 {
+		return true, nil
+	}
+
+	header, err := t.rp.Client.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return false, fmt.Errorf("Could not get the latest block header to check the base fee: %w", err)
+	}
+	if header.BaseFee == nil {
+		return true, nil
+	}
+	maxBaseFee := eth.GweiToWei(maxBaseFeeGwei)
+	if header.BaseFee.Cmp(maxBaseFee) > 0 {
+		t.log.Printlnf("WARNING: the current base fee of %.2f gwei is above the challenge response limit of %.2f gwei, so the challenge against node %s will be handled on a later check.", eth.WeiToGwei(header.BaseFee), maxBaseFeeGwei, memberAddress.Hex())
+		return false, nil
+	}
+	return true, nil
+}
+
+// Send a report about a handled challenge to the challenge webhook, if one is set. This runs in the background and
+// failures are only logged, so an unreachable endpoint never holds up or fails the task.
+func (t *respondChallenges) reportChallenge(nodeAddress common.Address, detectedTime time.Time, hash *common.Hash, err error) {
+	url := t.cfg.Smartnode.ChallengeWebhookUrl.Value.(string)
+	if url == "" {
+		return
+	}
+	report := notifications.ChallengeReport{
+		NodeAddress:  nodeAddress,
+		DetectedTime: detectedTime,
+		Success:      (err == nil),
+	}
+	if hash != nil && *hash != (common.Hash{}) {
+		report.TxHash = hash
+	}
+	if err != nil {
+		report.Error = err.Error()
+	}
+	go func() {
+		if err := notifications.PostChallengeReport(url, report); err != nil {
+			t.log.Printlnf("WARNING: Couldn't send challenge report to the webhook: %s", err.Error())
+		}
+	}()
+}
 
+// Count a challenge against a member for the metrics, if its challenge time can be looked up
+func (t *respondChallenges) recordChallengeDetected(memberAddress common.Address) {
+	challengedTime, err := getMemberChallengedTime(t.rp, memberAddress)
+	if err != nil {
+		t.log.Printlnf("WARNING: the challenge against node %s won't be counted in the metrics: %s", memberAddress.Hex(), err.Error())
+		return
+	}
+	t.countChallenge(memberAddress, challengedTime)
+}
+
+// Count a challenge for the metrics unless it was already counted on an earlier run
+func (t *respondChallenges) countChallenge(memberAddress common.Address, challengedTime uint64) {
+	if t.detectedChallenges[memberAddress] == challengedTime {
+		return
+	}
+	t.detectedChallenges[memberAddress] = challengedTime
+	t.coll.UpdateLock.Lock()
+	t.coll.ChallengesDetected++
+	t.coll.UpdateLock.Unlock()
+}
+
+// Record the result of a submitted challenge response or decision for the metrics
+func (t *respondChallenges) recordResponse(success bool) {
+	t.coll.UpdateLock.Lock()
+	defer t.coll.UpdateLock.Unlock()
+	if success {
+		t.coll.ResponsesSubmitted++
+		t.coll.LastResponseTime = float64(time.Now().Unix())
+	} else {
+		t.coll.ResponseFailures++
+	}
+}
+
+// Get the time a challenge was made against a member; there's no binding for this, so it's read from storage directly
+func getMemberChallengedTime(rp *rocketpool.RocketPool, memberAddress common.Address) (uint64, error) {
+	challengedTime, err := rp.RocketStorage.GetUint(nil, crypto.Keccak256Hash([]byte("dao.trustednodes."), []byte("member.challenged.time"), memberAddress.Bytes()))
+	if err != nil {
+		return 0, fmt.Errorf("Could not get the challenge time for node %s: %w", memberAddress.Hex(), err)
+	}
+	return challengedTime.Uint64(), nil
 }
@@ -0,0 +1,105 @@
+package watchtower
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// A watchtower task and when it's next due to run
+type scheduledTask struct {
+	name     string
+	run      func() error
+	interval time.Duration
+	nextRun  time.Time
+	running  bool
+	lock     sync.Mutex
+}
+
+// Create a task that runs every interval; a zero interval uses the shared, randomized schedule
+func newScheduledTask(name string, run func() error, intervalSeconds uint64) *scheduledTask {
+	return &scheduledTask{
+		name:     name,
+		run:      run,
+		interval: time.Duration(intervalSeconds) * time.Second,
+		nextRun:  time.Now(),
+	}
+}
+
+// Check if the task is due to run; a task that's still running isn't due again until it finishes
+func (t *scheduledTask) isDue(now time.Time) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return !t.running && !now.Before(t.nextRun)
+}
+
+// Schedule the task's next run relative to the provided time
+func (t *scheduledTask) reschedule(now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.rescheduleImpl(now)
+}
+
+// Schedule the task's next run relative to the provided time; the caller must hold the lock
+func (t *scheduledTask) rescheduleImpl(now time.Time) {
+	interval := t.interval
+	if interval == 0 {
+		randomSeconds := rand.Intn(int((maxTasksInterval - minTasksInterval).Seconds()))
+		interval = time.Duration(randomSeconds)*time.Second + minTasksInterval
+	}
+	t.nextRun = now.Add(interval)
+}
+
+// Run the task in the background, then reschedule it relative to when it finished. The caller must have already taken
+// a slot in the semaphore; it's released and the done channel is signaled when the task finishes.
+func (t *scheduledTask) launch(semaphore chan struct{}, done chan struct{}, runTask func(name string, task func() error)) {
+	t.lock.Lock()
+	t.running = true
+	t.lock.Unlock()
+
+	go func() {
+		defer func() {
+			<-semaphore
+			select {
+			case done <- struct{}{}:
+			default:
+			}
+		}()
+		runTask(t.name, t.run)
+
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		t.running = false
+		t.rescheduleImpl(time.Now())
+	}()
+}
+
+// Wait for every running task to finish by taking all of the semaphore's slots, which also keeps any more from being
+// launched. Returns false if they didn't all finish within the timeout.
+func waitForRunningTasks(semaphore chan struct{}, timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for i := 0; i < cap(semaphore); i++ {
+		select {
+		case semaphore <- struct{}{}:
+		case <-timer.C:
+			return false
+		}
+	}
+	return true
+}
+
+// Get the time the next of the provided tasks that isn't already running is due. Returns false if they're all running.
+func getNextDueTime(tasks []*scheduledTask) (time.Time, bool) {
+	var next time.Time
+	found := false
+	for _, task := range tasks {
+		task.lock.Lock()
+		if !task.running && (!found || task.nextRun.Before(next)) {
+			next = task.nextRun
+			found = true
+		}
+		task.lock.Unlock()
+	}
+	return next, found
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"math"
 	"math/big"
 	"os"
@@ -28,6 +27,7 @@ import (
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
 	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
 	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/urfave/cli"
@@ -42,7 +42,7 @@ type submitRewardsTree struct {
 	cfg              *config.RocketPoolConfig
 	w                *wallet.Wallet
 	rp               *rocketpool.RocketPool
-	ec               rocketpool.ExecutionClient
+	ec               *services.ExecutionClientManager
 	bc               beacon.Client
 	lock             *sync.Mutex
 	isRunning        bool
@@ -185,26 +185,27 @@ func (t *submitRewardsTree) run() error {
 	minipoolPerformancePath := t.cfg.Smartnode.GetMinipoolPerformancePath(currentIndex, true)
 	compressedMinipoolPerformancePath := minipoolPerformancePath + config.RewardsTreeIpfsExtension
 
-	// Check if we can reuse an existing file fir this interval
-	if t.isExistingFileValid(rewardsTreePath, uint64(intervalsPassed)) {
+	// Check if we can reuse an existing file fir this interval; it may have been saved compressed
+	existingRewardsTreePath := t.cfg.Smartnode.GetExistingRewardsTreePath(currentIndex, true)
+	if t.isExistingFileValid(existingRewardsTreePath, uint64(intervalsPassed)) {
 		if !nodeTrusted {
-			t.log.Printlnf("Merkle rewards tree for interval %d already exists at %s.", currentIndex, rewardsTreePath)
+			t.log.Printlnf("Merkle rewards tree for interval %d already exists at %s.", currentIndex, existingRewardsTreePath)
 			return nil
 		}
 
 		// Return if this node has already submitted the tree for the current interval and there's a file present
-		hasSubmitted, err := t.hasSubmittedTree(nodeAccount.Address, currentIndexBig)
+		submissionRequired, err := t.isSubmissionRequired(nodeAccount.Address, currentIndexBig)
 		if err != nil {
-			return fmt.Errorf("error checking if Merkle tree submission has already been processed: %w", err)
+			return err
 		}
-		if hasSubmitted {
+		if !submissionRequired {
 			return nil
 		}
 
-		t.log.Printlnf("Merkle rewards tree for interval %d already exists at %s, attempting to resubmit...", currentIndex, rewardsTreePath)
+		t.log.Printlnf("Merkle rewards tree for interval %d already exists at %s, attempting to resubmit...", currentIndex, existingRewardsTreePath)
 
 		// Deserialize the file
-		wrapperBytes, err := ioutil.ReadFile(rewardsTreePath)
+		wrapperBytes, err := rprewards.ReadRewardsFileBytes(existingRewardsTreePath)
 		if err != nil {
 			return fmt.Errorf("Error reading rewards tree file: %w", err)
 		}
@@ -260,7 +261,7 @@ func (t *submitRewardsTree) isExistingFileValid(rewardsTreePath string, interval
 	if !os.IsNotExist(err) {
 		// The file already exists, attempt to read it
 		var proofWrapper rprewards.RewardsFile
-		fileBytes, err := ioutil.ReadFile(rewardsTreePath)
+		fileBytes, err := rprewards.ReadRewardsFileBytes(rewardsTreePath)
 		if err != nil {
 			t.log.Printlnf("WARNING: failed to read %s: %s\nRegenerating file...\n", rewardsTreePath, err.Error())
 			return false
@@ -294,12 +295,24 @@ func (t *submitRewardsTree) generateTree(intervalsPassed time.Duration, nodeTrus
 		t.isRunning = true
 		t.lock.Unlock()
 
+		// Make sure the EC can actually serve historical calls before spending time on generation
+		err := eth1.CheckHistoricalCallSupport(t.ec, t.rp, t.cfg, snapshotElBlockHeader.Number)
+		if err != nil {
+			t.handleError(err)
+			return
+		}
+
 		// Get an appropriate client
 		client, err := eth1.GetBestApiClient(t.rp, t.cfg, t.printMessage, snapshotElBlockHeader.Number)
 		if err != nil {
 			t.handleError(err)
 			return
 		}
+		err = eth1.CheckStateAvailable(client, t.cfg, currentIndex, snapshotElBlockHeader.Number)
+		if err != nil {
+			t.handleError(err)
+			return
+		}
 
 		// Generate the tree
 		err = t.generateTreeImpl(client, intervalsPassed, nodeTrusted, currentIndex, snapshotBeaconBlock, elBlockIndex, startTime, endTime, snapshotElBlockHeader, rewardsTreePath, compressedRewardsTreePath, minipoolPerformancePath, compressedMinipoolPerformancePath)
@@ -335,6 +348,9 @@ func (t *submitRewardsTree) generateTreeImpl(rp *rocketpool.RocketPool, interval
 	for address, network := range rewardsFile.InvalidNetworkNodes {
 		t.printMessage(fmt.Sprintf("WARNING: Node %s has invalid network %d assigned! Using 0 (mainnet) instead.", address.Hex(), network))
 	}
+	for address, reason := range rewardsFile.UndeterminedSmoothingPoolNodes {
+		t.printMessage(fmt.Sprintf("WARNING: Node %s has a Smoothing Pool opt-in status that couldn't be determined, so its registration change time was used as-is: %s", address.Hex(), reason))
+	}
 
 	// Serialize the minipool performance file
 	minipoolPerformanceBytes, err := json.Marshal(rewardsFile.MinipoolPerformanceFile)
@@ -343,13 +359,33 @@ func (t *submitRewardsTree) generateTreeImpl(rp *rocketpool.RocketPool, interval
 	}
 
 	// Write it to disk
-	err = ioutil.WriteFile(minipoolPerformancePath, minipoolPerformanceBytes, 0644)
+	fileMode, err := t.cfg.Smartnode.GetRewardsTreeFileMode()
+	if err != nil {
+		return fmt.Errorf("Error getting rewards tree file permissions: %w", err)
+	}
+	err = files.WriteFileAtomic(minipoolPerformancePath, minipoolPerformanceBytes, fileMode)
 	if err != nil {
 		return fmt.Errorf("Error saving minipool performance file to %s: %w", minipoolPerformancePath, err)
 	}
 
-	// Upload it if this is an Oracle DAO node
+	// Generation can take a long time, so make sure the submission is still needed before uploading anything
+	submitTree := false
 	if nodeTrusted {
+		nodeAccount, err := t.w.GetNodeAccount()
+		if err != nil {
+			return err
+		}
+		submitTree, err = t.isSubmissionRequired(nodeAccount.Address, big.NewInt(int64(currentIndex)))
+		if err != nil {
+			return err
+		}
+		if !submitTree {
+			t.printMessage(fmt.Sprintf("This node has already submitted a rewards snapshot for interval %d (or the interval has already been finalized), so it will only be saved locally.", currentIndex))
+		}
+	}
+
+	// Upload it if this is an Oracle DAO node that still needs to submit
+	if submitTree {
 		t.printMessage("Uploading minipool performance file to Web3.Storage...")
 		minipoolPerformanceCid, err := t.uploadFileToWeb3Storage(minipoolPerformanceBytes, compressedMinipoolPerformancePath, "compressed minipool performance")
 		if err != nil {
@@ -370,13 +406,19 @@ func (t *submitRewardsTree) generateTreeImpl(rp *rocketpool.RocketPool, interval
 	t.printMessage("Generation complete! Saving tree...")
 
 	// Write the rewards tree to disk
-	err = ioutil.WriteFile(rewardsTreePath, wrapperBytes, 0644)
+	err = files.WriteFileAtomic(rewardsTreePath, wrapperBytes, fileMode)
 	if err != nil {
 		return fmt.Errorf("Error saving rewards tree file to %s: %w", rewardsTreePath, err)
 	}
 
-	// Only do the upload and submission process if this is an Oracle DAO node
-	if nodeTrusted {
+	// Save the binary copy alongside it
+	err = rprewards.SaveRewardsFileBinary(rewardsFile, t.cfg.Smartnode.GetRewardsTreeBinaryPath(currentIndex, true), fileMode)
+	if err != nil {
+		t.printMessage(fmt.Sprintf("WARNING: couldn't save the binary copy of the rewards tree: %s", err.Error()))
+	}
+
+	// Only do the upload and submission process if this is an Oracle DAO node that still needs to submit
+	if submitTree {
 		// Upload the rewards tree file
 		t.printMessage("Uploading to Web3.Storage and submitting results to the contracts...")
 		cid, err := t.uploadFileToWeb3Storage(wrapperBytes, compressedRewardsTreePath, "compressed rewards tree")
@@ -576,6 +618,23 @@ func (t *submitRewardsTree) getSnapshotConsensusBlock(endTime time.Time) (uint64
 
 }
 
+// Check whether the node still needs to submit the rewards tree for the given interval; it doesn't if it already has,
+// or if the rest of the Oracle DAO reached consensus on it and the interval has been finalized
+func (t *submitRewardsTree) isSubmissionRequired(nodeAddress common.Address, index *big.Int) (bool, error) {
+	hasSubmitted, err := t.hasSubmittedTree(nodeAddress, index)
+	if err != nil {
+		return false, fmt.Errorf("error checking if Merkle tree submission has already been processed: %w", err)
+	}
+	if hasSubmitted {
+		return false, nil
+	}
+	currentIndex, err := rewards.GetRewardIndex(t.rp, nil)
+	if err != nil {
+		return false, fmt.Errorf("error getting current reward index: %w", err)
+	}
+	return currentIndex.Cmp(index) <= 0, nil
+}
+
 // Check whether the rewards tree for the current interval been submitted by the node
 func (t *submitRewardsTree) hasSubmittedTree(nodeAddress common.Address, index *big.Int) (bool, error) {
 	indexBuffer := make([]byte, 32)
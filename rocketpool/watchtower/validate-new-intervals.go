@@ -0,0 +1,179 @@
+package watchtower
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Validate new rewards intervals task
+type validateNewIntervals struct {
+	c          *cli.Context
+	log        log.ColorLogger
+	errLog     log.ColorLogger
+	cfg        *config.RocketPoolConfig
+	w          *wallet.Wallet
+	rp         *rocketpool.RocketPool
+	notifier   notifications.Notifier
+	nextIndex  uint64
+	hasStarted bool
+}
+
+// Create validate new rewards intervals task
+func newValidateNewIntervals(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*validateNewIntervals, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	notifier, err := notifications.NewNotifier(cfg)
+	if err != nil {
+		logger.Printlnf("WARNING: notifications are disabled: %s", err.Error())
+		notifier = notifications.NewNoopNotifier()
+	}
+
+	// Return task
+	return &validateNewIntervals{
+		c:        c,
+		log:      logger,
+		errLog:   errorLogger,
+		cfg:      cfg,
+		w:        w,
+		rp:       rp,
+		notifier: notifier,
+	}, nil
+
+}
+
+// Validate any intervals that have finished since the last check
+func (t *validateNewIntervals) run() error {
+
+	// Check if validation is enabled
+	if t.cfg.Smartnode.ValidateNewIntervals.Value != true {
+		return nil
+	}
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Check node trusted status
+	nodeTrusted, err := trustednode.GetMemberExists(t.rp, nodeAccount.Address, nil)
+	if err != nil {
+		return err
+	}
+	if !nodeTrusted {
+		return nil
+	}
+
+	// Get the latest finished interval
+	currentIndexBig, err := rewards.GetRewardIndex(t.rp, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting current reward index: %w", err)
+	}
+	currentIndex := currentIndexBig.Uint64()
+	if currentIndex == 0 {
+		return nil
+	}
+
+	// Start from the latest interval, older ones can be checked with the manual verification commands
+	if !t.hasStarted {
+		t.nextIndex = currentIndex - 1
+		t.hasStarted = true
+	}
+
+	// Log
+	t.log.Println("Checking for new rewards intervals to validate...")
+
+	for t.nextIndex < currentIndex {
+		validated, err := t.validateInterval(t.nextIndex)
+		if err != nil {
+			return err
+		}
+		if !validated {
+			break
+		}
+		t.nextIndex++
+	}
+
+	return nil
+
+}
+
+// Check the local tree for an interval against the canonical root, requesting generation if it doesn't exist yet.
+// Returns false if the interval couldn't be validated yet because its tree is still being generated.
+func (t *validateNewIntervals) validateInterval(index uint64) (bool, error) {
+
+	// Make sure the tree exists, and request it if it doesn't
+	path := t.cfg.Smartnode.GetExistingRewardsTreePath(index, true)
+	_, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		requestPath := filepath.Join(t.cfg.Smartnode.GetWatchtowerFolder(true), fmt.Sprintf(config.RegenerateRewardsTreeRequestFormat, index))
+		_, err = os.Stat(requestPath)
+		if os.IsNotExist(err) {
+			t.log.Printlnf("Interval %d has finished but its tree doesn't exist yet, requesting generation.", index)
+			err = os.WriteFile(requestPath, []byte{}, 0644)
+			if err != nil {
+				return false, fmt.Errorf("Error writing generation request for interval %d: %w", index, err)
+			}
+		}
+
+		// Validation will continue once the tree has been generated
+		return false, nil
+	} else if err != nil {
+		return false, fmt.Errorf("Error checking rewards tree for interval %d: %w", index, err)
+	}
+
+	// Load the local tree
+	rewardsFile, err := rprewards.LoadRewardsFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	// Compare it to the canonical root
+	rewardsEvent, err := rprewards.GetRewardSnapshotEvent(t.rp, t.cfg, index)
+	if err != nil {
+		return false, fmt.Errorf("Error getting event for interval %d: %w", index, err)
+	}
+	root := common.HexToHash(rewardsFile.MerkleRoot)
+	if root != rewardsEvent.MerkleRoot {
+		message := fmt.Sprintf("Your Merkle tree for interval %d had a root of %s, but the canonical Merkle tree's root was %s.", index, root.Hex(), rewardsEvent.MerkleRoot.Hex())
+		t.errLog.Printlnf("***ERROR*** %s", message)
+		err = t.notifier.Notify(notifications.NewIntervalEvent(t.cfg, notifications.EventType_TreeRootMismatch, index,
+			fmt.Sprintf("Rewards tree for interval %d doesn't match consensus", index),
+			message))
+		if err != nil {
+			t.log.Printlnf("WARNING: Couldn't send notification: %s", err.Error())
+		}
+	} else {
+		t.log.Printlnf("Your Merkle tree for interval %d matches the canonical root of %s.", index, root.Hex())
+	}
+
+	return true, nil
+
+}
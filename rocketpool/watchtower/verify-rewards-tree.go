@@ -0,0 +1,104 @@
+package watchtower
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/notifications"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+)
+
+// Generate the tree for an interval twice and make sure both runs produce byte-identical files. The runs use different
+// thread counts so results that depend on how the nodes were split between threads get caught too.
+func (t *generateRewardsTree) verifyRewardsTreeImpl(rp *rocketpool.RocketPool, index uint64, generationPrefix string, rewardsEvent rewards.RewardsEvent, elBlockHeader *types.Header, request config.RewardsTreeRequest) {
+
+	start := time.Now()
+	threads := request.Threads
+	if threads == 0 {
+		threads = t.cfg.Smartnode.RewardsTreeThreads.Value.(uint64)
+	}
+	if threads == 0 {
+		threads = uint64(runtime.NumCPU())
+	}
+	runThreads := []uint64{threads, threads / 2}
+	if threads == 1 {
+		runThreads[1] = 2
+	}
+
+	files := make([]*rprewards.RewardsFile, 2)
+	paths := make([]string, 2)
+	contents := make([][]byte, 2)
+	defer func() {
+		for _, path := range paths {
+			if path != "" {
+				os.Remove(path)
+			}
+		}
+	}()
+
+	for i := range files {
+		t.log.Printlnf("%s Starting run %d of 2 with %d threads...", generationPrefix, i+1, runThreads[i])
+		runRequest := request
+		runRequest.Threads = runThreads[i]
+		treegen, _, err := t.newTreeGenerator(rp, index, generationPrefix, rewardsEvent, elBlockHeader, true, runRequest)
+		if err != nil {
+			t.handleError(fmt.Errorf("%s %w", generationPrefix, err))
+			return
+		}
+		rewardsFile, err := treegen.GenerateTree()
+		if err != nil {
+			t.handleError(fmt.Errorf("%s Error generating Merkle tree: %w", generationPrefix, err))
+			return
+		}
+		rewardsFile.MinipoolPerformanceFileCID = "---"
+		files[i] = rewardsFile
+
+		// Serialize it to a temporary file
+		contents[i], err = json.Marshal(rewardsFile)
+		if err != nil {
+			t.handleError(fmt.Errorf("%s Error serializing proof wrapper into JSON: %w", generationPrefix, err))
+			return
+		}
+		tempFile, err := os.CreateTemp("", fmt.Sprintf("rp-rewards-determinism-%d-*.json", index))
+		if err != nil {
+			t.handleError(fmt.Errorf("%s Error creating temporary file: %w", generationPrefix, err))
+			return
+		}
+		paths[i] = tempFile.Name()
+		_, err = tempFile.Write(contents[i])
+		tempFile.Close()
+		if err != nil {
+			t.handleError(fmt.Errorf("%s Error writing temporary file %s: %w", generationPrefix, paths[i], err))
+			return
+		}
+		t.log.Printlnf("%s Run %d finished with a root of %s (saved to %s).", generationPrefix, i+1, rewardsFile.MerkleRoot, paths[i])
+	}
+	t.log.Printlnf("%s Finished in %s", generationPrefix, time.Since(start).String())
+
+	// Compare the results
+	if bytes.Equal(contents[0], contents[1]) {
+		t.log.Printlnf("%s Both runs produced byte-identical rewards files. Tree generation is deterministic for this interval.", generationPrefix)
+		t.sendNotification(notifications.NewIntervalEvent(t.cfg, notifications.EventType_TreeGenerated, index,
+			fmt.Sprintf("Rewards tree for interval %d is deterministic", index),
+			fmt.Sprintf("Generating the Merkle rewards tree for interval %d twice produced identical files with a root of %s.", index, files[0].MerkleRoot)))
+	} else {
+		message := fmt.Sprintf("The two runs produced different rewards files (roots %s and %s).", files[0].MerkleRoot, files[1].MerkleRoot)
+		address, difference, found := rprewards.FindFirstNodeDifference(files[0], files[1])
+		if found {
+			message += fmt.Sprintf(" The first differing node is %s: %s.", address.Hex(), difference)
+		} else {
+			message += " Every node's rewards matched, so the difference is in the file's metadata or totals."
+		}
+		t.handleError(fmt.Errorf("%s ***ERROR*** Tree generation is not deterministic! %s", generationPrefix, message))
+		return
+	}
+
+}
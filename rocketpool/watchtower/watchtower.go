@@ -2,9 +2,11 @@ package watchtower
 
 import (
 	"fmt"
-	"math/rand"
 	"net/http"
+	"os"
+	"os/signal"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/fatih/color"
@@ -61,42 +63,60 @@ func run(c *cli.Context) error {
 		return err
 	}
 
+	// Refuse to run any tasks if the clients are on a different network than the config
+	if err := services.RequireClientNetworksMatch(c); err != nil {
+		return fmt.Errorf("Refusing to start the watchtower: %w", err)
+	}
+
 	// Initialize the scrub metrics reporter
 	scrubCollector := collectors.NewScrubCollector()
 
+	// Initialize the challenge metrics reporter
+	challengeCollector := collectors.NewChallengeCollector()
+
+	// Initialize the rewards tree generation metrics reporter
+	rewardsTreeCollector := collectors.NewRewardsTreeCollector()
+
+	// Get the logging mode
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	jsonLogging := cfg.Smartnode.WatchtowerJsonLogging.Value.(bool)
+
 	// Initialize error logger
-	errorLog := log.NewColorLogger(ErrorColor)
+	errorLog := newTaskLogger(ErrorColor, "watchtower", "error", jsonLogging)
 
 	// Initialize tasks
-	respondChallenges, err := newRespondChallenges(c, log.NewColorLogger(RespondChallengesColor))
+	respondChallenges, err := newRespondChallenges(c, newTaskLogger(RespondChallengesColor, "respond-challenges", "info", jsonLogging), challengeCollector)
 	if err != nil {
 		return fmt.Errorf("error during respond-to-challenges check: %w", err)
 	}
-	submitRplPrice, err := newSubmitRplPrice(c, log.NewColorLogger(SubmitRplPriceColor))
+	submitRplPrice, err := newSubmitRplPrice(c, newTaskLogger(SubmitRplPriceColor, "submit-rpl-price", "info", jsonLogging))
 	if err != nil {
 		return fmt.Errorf("error during rpl price check: %w", err)
 	}
-	submitNetworkBalances, err := newSubmitNetworkBalances(c, log.NewColorLogger(SubmitNetworkBalancesColor))
+	submitNetworkBalances, err := newSubmitNetworkBalances(c, newTaskLogger(SubmitNetworkBalancesColor, "submit-network-balances", "info", jsonLogging))
 	if err != nil {
 		return fmt.Errorf("error during network balances check: %w", err)
 	}
-	submitWithdrawableMinipools, err := newSubmitWithdrawableMinipools(c, log.NewColorLogger(SubmitWithdrawableMinipoolsColor))
+	submitWithdrawableMinipools, err := newSubmitWithdrawableMinipools(c, newTaskLogger(SubmitWithdrawableMinipoolsColor, "submit-withdrawable-minipools", "info", jsonLogging))
 	if err != nil {
 		return fmt.Errorf("error during withdrawable minipools check: %w", err)
 	}
-	dissolveTimedOutMinipools, err := newDissolveTimedOutMinipools(c, log.NewColorLogger(DissolveTimedOutMinipoolsColor))
+	dissolveTimedOutMinipools, err := newDissolveTimedOutMinipools(c, newTaskLogger(DissolveTimedOutMinipoolsColor, "dissolve-timed-out-minipools", "info", jsonLogging))
 	if err != nil {
 		return fmt.Errorf("error during timed-out minipools check: %w", err)
 	}
-	processWithdrawals, err := newProcessWithdrawals(c, log.NewColorLogger(ProcessWithdrawalsColor))
+	processWithdrawals, err := newProcessWithdrawals(c, newTaskLogger(ProcessWithdrawalsColor, "process-withdrawals", "info", jsonLogging))
 	if err != nil {
 		return fmt.Errorf("error during withdrawal processing check: %w", err)
 	}
-	submitScrubMinipools, err := newSubmitScrubMinipools(c, log.NewColorLogger(SubmitScrubMinipoolsColor), errorLog, scrubCollector)
+	submitScrubMinipools, err := newSubmitScrubMinipools(c, newTaskLogger(SubmitScrubMinipoolsColor, "submit-scrub-minipools", "info", jsonLogging), errorLog, scrubCollector)
 	if err != nil {
 		return fmt.Errorf("error during scrub check: %w", err)
 	}
-	submitRewardsTree, err := newSubmitRewardsTree(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog)
+	submitRewardsTree, err := newSubmitRewardsTree(c, newTaskLogger(SubmitRewardsTreeColor, "submit-rewards-tree", "info", jsonLogging), errorLog)
 	if err != nil {
 		return fmt.Errorf("error during rewards tree check: %w", err)
 	}
@@ -104,115 +124,179 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("error during penalties check: %w", err)
 	}*/
-	generateRewardsTree, err := newGenerateRewardsTree(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog)
+	generateRewardsTree, err := newGenerateRewardsTree(c, newTaskLogger(SubmitRewardsTreeColor, "generate-rewards-tree", "info", jsonLogging), errorLog, rewardsTreeCollector)
 	if err != nil {
 		return fmt.Errorf("error during manual tree generation check: %w", err)
 	}
 
-	intervalDelta := maxTasksInterval - minTasksInterval
-	secondsDelta := intervalDelta.Seconds()
+	validateNewIntervals, err := newValidateNewIntervals(c, newTaskLogger(SubmitRewardsTreeColor, "validate-new-intervals", "info", jsonLogging), errorLog)
+	if err != nil {
+		return fmt.Errorf("error during new interval validation check: %w", err)
+	}
+	pruneRewardsTrees, err := newPruneRewardsTrees(c, newTaskLogger(SubmitRewardsTreeColor, "prune-rewards-trees", "info", jsonLogging))
+	if err != nil {
+		return fmt.Errorf("error during rewards tree pruning check: %w", err)
+	}
 
-	// Wait group to handle the various threads
+	// Record each task's runs in the heartbeat file, along with any rewards tree being generated
+	taskHeartbeat := newHeartbeat(cfg.Smartnode.GetWatchtowerHeartbeatPath(true), generateRewardsTree.getRunningIndex)
+	runTask := func(name string, task func() error) {
+		if err := taskHeartbeat.start(name); err != nil {
+			errorLog.Printlnf("WARNING: couldn't update the heartbeat file: %s", err.Error())
+		}
+		if err := task(); err != nil {
+			errorLog.Println(err)
+		}
+		if err := taskHeartbeat.end(name); err != nil {
+			errorLog.Printlnf("WARNING: couldn't update the heartbeat file: %s", err.Error())
+		}
+	}
+
+	// Stop launching tasks when the daemon is asked to exit
+	stop := make(chan struct{})
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-signals
+		close(stop)
+	}()
+
+	// Each task runs on its own interval, with a limit on how many can run at once so heavy tasks don't pile up on the
+	// clients; the default limit of 1 runs them one at a time so their transactions don't compete
+	smartnode := cfg.Smartnode
+	tasks := []*scheduledTask{
+		newScheduledTask("generate-rewards-tree", generateRewardsTree.run, smartnode.WatchtowerGenerateRewardsTreeInterval.Value.(uint64)),
+		newScheduledTask("respond-challenges", respondChallenges.run, smartnode.WatchtowerRespondChallengesInterval.Value.(uint64)),
+		newScheduledTask("submit-rewards-tree", submitRewardsTree.run, smartnode.WatchtowerSubmitRewardsTreeInterval.Value.(uint64)),
+		newScheduledTask("validate-new-intervals", validateNewIntervals.run, smartnode.WatchtowerValidateNewIntervalsInterval.Value.(uint64)),
+		newScheduledTask("prune-rewards-trees", pruneRewardsTrees.run, smartnode.WatchtowerPruneRewardsTreesInterval.Value.(uint64)),
+		newScheduledTask("submit-rpl-price", submitRplPrice.run, smartnode.WatchtowerSubmitRplPriceInterval.Value.(uint64)),
+		newScheduledTask("submit-network-balances", submitNetworkBalances.run, smartnode.WatchtowerSubmitNetworkBalancesInterval.Value.(uint64)),
+		newScheduledTask("submit-withdrawable-minipools", submitWithdrawableMinipools.run, smartnode.WatchtowerSubmitWithdrawableMinipoolsInterval.Value.(uint64)),
+		newScheduledTask("dissolve-timed-out-minipools", dissolveTimedOutMinipools.run, smartnode.WatchtowerDissolveTimedOutMinipoolsInterval.Value.(uint64)),
+		newScheduledTask("process-withdrawals", processWithdrawals.run, smartnode.WatchtowerProcessWithdrawalsInterval.Value.(uint64)),
+		newScheduledTask("submit-scrub-minipools", submitScrubMinipools.run, smartnode.WatchtowerSubmitScrubMinipoolsInterval.Value.(uint64)),
+		// The fee recipient penalty check is DISABLED until MEV-Boost can support it
+	}
+
+	maxConcurrentTasks := smartnode.WatchtowerMaxConcurrentTasks.Value.(uint64)
+	if maxConcurrentTasks == 0 {
+		maxConcurrentTasks = 1
+	}
+	semaphore := make(chan struct{}, maxConcurrentTasks)
+	taskDone := make(chan struct{}, 1)
+
+	// Wait group to handle the task loop
 	wg := new(sync.WaitGroup)
-	wg.Add(2)
+	wg.Add(1)
 
 	// Run task loop
 	go func() {
+		defer wg.Done()
 		for {
-			// Randomize the next interval
-			randomSeconds := rand.Intn(int(secondsDelta))
-			interval := time.Duration(randomSeconds)*time.Second + minTasksInterval
-
-			// Check the EC status
-			err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
-			if err != nil {
-				errorLog.Println(err)
-			} else {
-				// Check the BC status
-				err := services.WaitBeaconClientSynced(c, false) // Force refresh the primary / fallback BC status
+			// Get the tasks that are due
+			due := []*scheduledTask{}
+			for _, task := range tasks {
+				if task.isDue(time.Now()) {
+					due = append(due, task)
+				}
+			}
+
+			if len(due) > 0 {
+				// Check the EC status
+				err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
 				if err != nil {
 					errorLog.Println(err)
 				} else {
-					// Run the manual rewards tree generation
-					if err := generateRewardsTree.run(); err != nil {
+					// Check the BC status
+					err = services.WaitBeaconClientSynced(c, false) // Force refresh the primary / fallback BC status
+					if err != nil {
 						errorLog.Println(err)
 					}
-					time.Sleep(taskCooldown)
-
-					// Run the challenge check
-					if err := respondChallenges.run(); err != nil {
-						errorLog.Println(err)
-					}
-					time.Sleep(taskCooldown)
-
-					// Run the rewards tree submission check
-					if err := submitRewardsTree.run(); err != nil {
-						errorLog.Println(err)
-					}
-					time.Sleep(taskCooldown)
-
-					// Run the price submission check
-					if err := submitRplPrice.run(); err != nil {
-						errorLog.Println(err)
-					}
-					time.Sleep(taskCooldown)
-
-					// Run the network balance submission check
-					if err := submitNetworkBalances.run(); err != nil {
-						errorLog.Println(err)
-					}
-					time.Sleep(taskCooldown)
-
-					// Run the withdrawable status submission check
-					if err := submitWithdrawableMinipools.run(); err != nil {
-						errorLog.Println(err)
-					}
-					time.Sleep(taskCooldown)
+				}
 
-					// Run the minipool dissolve check
-					if err := dissolveTimedOutMinipools.run(); err != nil {
-						errorLog.Println(err)
+				// Launch the due tasks as slots free up, or wait for their next run if the clients aren't ready
+				for i, task := range due {
+					if err != nil {
+						task.reschedule(time.Now())
+						continue
 					}
-					time.Sleep(taskCooldown)
-
-					// Run the withdrawal processing check
-					if err := processWithdrawals.run(); err != nil {
-						errorLog.Println(err)
+					select {
+					case semaphore <- struct{}{}:
+					case <-stop:
+						return
 					}
-					time.Sleep(taskCooldown)
-
-					// Run the minipool scrub check
-					if err := submitScrubMinipools.run(); err != nil {
-						errorLog.Println(err)
+					if i > 0 {
+						select {
+						case <-time.After(taskCooldown):
+						case <-stop:
+							<-semaphore
+							return
+						}
 					}
-					/*time.Sleep(taskCooldown)
+					task.launch(semaphore, taskDone, runTask)
+				}
+			}
 
-					// Run the fee recipient penalty check
-					if err := processPenalties.run(); err != nil {
-						errorLog.Println(err)
-					}*/
-					// DISABLED until MEV-Boost can support it
+			// Wait for the next task to be due, or for a running one to finish so it can be rescheduled
+			next, found := getNextDueTime(tasks)
+			if found {
+				timer := time.NewTimer(time.Until(next))
+				select {
+				case <-timer.C:
+				case <-taskDone:
+					timer.Stop()
+				case <-stop:
+					timer.Stop()
+					return
+				}
+			} else {
+				select {
+				case <-taskDone:
+				case <-stop:
+					return
 				}
 			}
-			time.Sleep(interval)
 		}
-		wg.Done()
 	}()
 
-	// Run metrics loop
+	// Run metrics loop; it doesn't hold up shutdown
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), scrubCollector)
+		err := runMetricsServer(c, newTaskLogger(MetricsColor, "metrics", "info", jsonLogging), scrubCollector, challengeCollector, rewardsTreeCollector)
 		if err != nil {
 			errorLog.Println(err)
 		}
-		wg.Done()
 	}()
 
-	// Wait for both threads to stop
+	// Once the task loop has stopped, let the tasks that are already running finish before exiting so they aren't cut
+	// off partway through (for example, while sending a transaction)
 	wg.Wait()
+	timeout := time.Duration(cfg.Smartnode.WatchtowerShutdownTimeout.Value.(uint64)) * time.Second
+	shutdownLog := newTaskLogger(WarningColor, "watchtower", "info", jsonLogging)
+	shutdownLog.Printlnf("Shutting down; waiting up to %s for running tasks to finish...", timeout)
+	treeShutdownDone := make(chan struct{})
+	go func() {
+		generateRewardsTree.shutdown(timeout)
+		close(treeShutdownDone)
+	}()
+	if waitForRunningTasks(semaphore, timeout) {
+		shutdownLog.Println("All running tasks finished.")
+	} else {
+		errorLog.Printlnf("WARNING: some tasks were still running after %s and will be stopped.", timeout)
+	}
+	<-treeShutdownDone
 	return nil
 }
 
+// Create the logger for a task, switching it to JSON output if that's enabled
+func newTaskLogger(colorAttr color.Attribute, task string, level string, jsonLogging bool) log.ColorLogger {
+	logger := log.NewColorLogger(colorAttr)
+	if jsonLogging {
+		logger = logger.WithJsonOutput(task, level)
+	}
+	return logger
+}
+
 // Configure HTTP transport settings
 func configureHTTP() {
 
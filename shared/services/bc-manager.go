@@ -3,6 +3,7 @@ package services
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/rocket-pool/rocketpool-go/types"
@@ -73,16 +74,17 @@ func NewBeaconClientManager(cfg *config.RocketPoolConfig) (*BeaconClientManager,
 
 	var primaryBc beacon.Client
 	var fallbackBc beacon.Client
+	requestTimeout := time.Duration(cfg.Smartnode.ClientCallTimeout.Value.(uint64)) * time.Second
 	switch selectedCC {
 	case cfgtypes.ConsensusClient_Nimbus:
-		primaryBc = client.NewNimbusClient(primaryProvider)
+		primaryBc = client.NewNimbusClient(primaryProvider, requestTimeout)
 		if fallbackProvider != "" {
-			fallbackBc = client.NewNimbusClient(fallbackProvider)
+			fallbackBc = client.NewNimbusClient(fallbackProvider, requestTimeout)
 		}
 	default:
-		primaryBc = client.NewStandardHttpClient(primaryProvider)
+		primaryBc = client.NewStandardHttpClient(primaryProvider, requestTimeout)
 		if fallbackProvider != "" {
-			fallbackBc = client.NewStandardHttpClient(fallbackProvider)
+			fallbackBc = client.NewStandardHttpClient(fallbackProvider, requestTimeout)
 		}
 	}
 
@@ -292,6 +294,19 @@ func (m *BeaconClientManager) GetCommitteesForEpoch(epoch *uint64) ([]beacon.Com
 	return result.([]beacon.Committee), nil
 }
 
+// Get the Beacon configuration from the fallback client alone, so it can be compared against the primary's.
+// Returns false if there isn't a fallback client.
+func (m *BeaconClientManager) GetFallbackEth2Config() (beacon.Eth2Config, bool, error) {
+	if m.fallbackBc == nil {
+		return beacon.Eth2Config{}, false, nil
+	}
+	eth2Config, err := m.fallbackBc.GetEth2Config()
+	if err != nil {
+		return beacon.Eth2Config{}, true, fmt.Errorf("error getting Beacon config from the fallback client: %w", err)
+	}
+	return eth2Config, true, nil
+}
+
 /// ==================
 /// Internal Functions
 /// ==================
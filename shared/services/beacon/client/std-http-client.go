@@ -2,8 +2,10 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -47,12 +49,14 @@ const (
 // Beacon client using the standard Beacon HTTP REST API (https://ethereum.github.io/beacon-APIs/)
 type StandardHttpClient struct {
 	providerAddress string
+	requestTimeout  time.Duration
 }
 
-// Create a new client instance
-func NewStandardHttpClient(providerAddress string) *StandardHttpClient {
+// Create a new client instance. Each request is cancelled if it takes longer than the timeout; 0 disables it.
+func NewStandardHttpClient(providerAddress string, requestTimeout time.Duration) *StandardHttpClient {
 	return &StandardHttpClient{
 		providerAddress: providerAddress,
+		requestTimeout:  requestTimeout,
 	}
 }
 
@@ -795,10 +799,16 @@ func (c *StandardHttpClient) getCommittees(stateId string, epoch *uint64) (Commi
 func (c *StandardHttpClient) getRequest(requestPath string) ([]byte, int, error) {
 
 	// Send request
-	response, err := http.Get(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath))
+	ctx, cancel := c.getRequestContext()
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), nil)
 	if err != nil {
 		return []byte{}, 0, err
 	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return []byte{}, 0, c.checkTimeout(err)
+	}
 	defer func() {
 		_ = response.Body.Close()
 	}()
@@ -806,7 +816,7 @@ func (c *StandardHttpClient) getRequest(requestPath string) ([]byte, int, error)
 	// Get response
 	body, err := ioutil.ReadAll(response.Body)
 	if err != nil {
-		return []byte{}, 0, err
+		return []byte{}, 0, c.checkTimeout(err)
 	}
 
 	// Return
@@ -825,10 +835,17 @@ func (c *StandardHttpClient) postRequest(requestPath string, requestBody interfa
 	requestBodyReader := bytes.NewReader(requestBodyBytes)
 
 	// Send request
-	response, err := http.Post(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), RequestContentType, requestBodyReader)
+	ctx, cancel := c.getRequestContext()
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), requestBodyReader)
 	if err != nil {
 		return []byte{}, 0, err
 	}
+	request.Header.Set("Content-Type", RequestContentType)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return []byte{}, 0, c.checkTimeout(err)
+	}
 	defer func() {
 		_ = response.Body.Close()
 	}()
@@ -836,10 +853,26 @@ func (c *StandardHttpClient) postRequest(requestPath string, requestBody interfa
 	// Get response
 	body, err := ioutil.ReadAll(response.Body)
 	if err != nil {
-		return []byte{}, 0, err
+		return []byte{}, 0, c.checkTimeout(err)
 	}
 
 	// Return
 	return body, response.StatusCode, nil
 
 }
+
+// Get the context for a single request, which enforces the request timeout if there is one
+func (c *StandardHttpClient) getRequestContext() (context.Context, context.CancelFunc) {
+	if c.requestTimeout == 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), c.requestTimeout)
+}
+
+// Make timed out requests easy to recognize in the logs
+func (c *StandardHttpClient) checkTimeout(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("Beacon client request timed out after %s: %w", c.requestTimeout, err)
+	}
+	return err
+}
@@ -289,6 +289,28 @@ func (cfg *MevBoostConfig) GetAvailableRelays() []config.MevRelay {
 	return relays
 }
 
+// Get the parameter that enables the provided relay in relay selection mode, or nil if the relay is unknown
+func (cfg *MevBoostConfig) GetRelayParameter(id config.MevRelayID) *config.Parameter {
+	switch id {
+	case config.MevRelayID_Flashbots:
+		return &cfg.FlashbotsRelay
+	case config.MevRelayID_BloxrouteMaxProfit:
+		return &cfg.BloxRouteMaxProfitRelay
+	case config.MevRelayID_BloxrouteEthical:
+		return &cfg.BloxRouteEthicalRelay
+	case config.MevRelayID_BloxrouteRegulated:
+		return &cfg.BloxRouteRegulatedRelay
+	case config.MevRelayID_Blocknative:
+		return &cfg.BlocknativeRelay
+	case config.MevRelayID_Eden:
+		return &cfg.EdenRelay
+	case config.MevRelayID_Ultrasound:
+		return &cfg.UltrasoundRelay
+	default:
+		return nil
+	}
+}
+
 // Get which MEV-boost relays are enabled
 func (cfg *MevBoostConfig) GetEnabledMevRelays() []config.MevRelay {
 	relays := []config.MevRelay{}
@@ -7,23 +7,25 @@ import (
 	"github.com/hashicorp/go-version"
 )
 
+// An upgrade for configs made with this version or older. The upgrade function returns a description of each change it made.
 type ConfigUpgrader struct {
 	Version     *version.Version
-	UpgradeFunc func(serializedConfig map[string]map[string]string) error
+	UpgradeFunc func(serializedConfig map[string]map[string]string) ([]string, error)
 }
 
-func UpdateConfig(serializedConfig map[string]map[string]string) error {
+// Upgrade a serialized config to the latest schema, returning a description of each migration that was applied
+func UpdateConfig(serializedConfig map[string]map[string]string) ([]string, error) {
 
 	// Get the config's version
 	configVersion, err := getVersionFromConfig(serializedConfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Create versions
 	v131, err := parseVersion("1.3.1")
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Create the collection of upgraders
@@ -34,29 +36,32 @@ func UpdateConfig(serializedConfig map[string]map[string]string) error {
 		},
 	}
 
-	// Find the index of the provided config's version
+	// Find the index of the first upgrade that applies to the provided config's version
 	targetIndex := -1
 	for i, upgrader := range upgraders {
 		if configVersion.LessThanOrEqual(upgrader.Version) {
 			targetIndex = i
+			break
 		}
 	}
 
-	// If there are no upgrades to apply, return
-	if targetIndex == -1 {
-		return nil
-	}
-
 	// If there are upgrades, start at the first applicable index and apply them all in series
-	for i := targetIndex; i < len(upgraders); i++ {
-		upgrader := upgraders[i]
-		err = upgrader.UpgradeFunc(serializedConfig)
-		if err != nil {
-			return fmt.Errorf("error applying upgrade for config version %s: %w", upgrader.Version.String(), err)
+	report := []string{}
+	if targetIndex != -1 {
+		for i := targetIndex; i < len(upgraders); i++ {
+			upgrader := upgraders[i]
+			changes, err := upgrader.UpgradeFunc(serializedConfig)
+			if err != nil {
+				return nil, fmt.Errorf("error applying upgrade for config version %s: %w", upgrader.Version.String(), err)
+			}
+			report = append(report, changes...)
 		}
 	}
 
-	return nil
+	// Carry renamed parameters over to their new names
+	report = append(report, applyParameterRenames(serializedConfig)...)
+
+	return report, nil
 
 }
 
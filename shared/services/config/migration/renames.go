@@ -0,0 +1,58 @@
+package migration
+
+import "fmt"
+
+// A parameter whose ID or value encoding changed between releases
+type parameterRename struct {
+	// The section the parameter is in, such as `root` or `smartnode`
+	Section string
+
+	// The old and new IDs; they're the same if only the values changed
+	OldID string
+	NewID string
+
+	// Old values mapped to their new encodings; values that aren't listed are kept as-is
+	Values map[string]string
+}
+
+// Every parameter that has been renamed or re-encoded. Add an entry here whenever a parameter's ID or values change,
+// so settings from older config files are carried over instead of silently falling back to the defaults.
+var parameterRenames = []parameterRename{}
+
+// Move each renamed parameter's value to its new ID and encoding, returning a description of each change.
+// Parameters that have already been migrated are left alone, so this is safe to run on any config.
+func applyParameterRenames(serializedConfig map[string]map[string]string) []string {
+	report := []string{}
+	for _, rename := range parameterRenames {
+		section, exists := serializedConfig[rename.Section]
+		if !exists {
+			continue
+		}
+		value, exists := section[rename.OldID]
+		if !exists {
+			continue
+		}
+		if rename.OldID != rename.NewID {
+			if _, migrated := section[rename.NewID]; migrated {
+				continue
+			}
+		}
+
+		newValue := value
+		if mapped, exists := rename.Values[value]; exists {
+			newValue = mapped
+		}
+		if rename.OldID == rename.NewID && newValue == value {
+			continue
+		}
+
+		delete(section, rename.OldID)
+		section[rename.NewID] = newValue
+		if rename.OldID == rename.NewID {
+			report = append(report, fmt.Sprintf("Converted [%s] %s from `%s` to `%s`", rename.Section, rename.NewID, value, newValue))
+		} else {
+			report = append(report, fmt.Sprintf("Renamed [%s] %s to %s", rename.Section, rename.OldID, rename.NewID))
+		}
+	}
+	return report
+}
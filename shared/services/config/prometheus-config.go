@@ -10,6 +10,7 @@ const prometheusTag string = "prom/prometheus:v2.41.0"
 // Defaults
 const defaultPrometheusPort uint16 = 9091
 const defaultPrometheusOpenPort bool = false
+const defaultPrometheusRetention string = "15d"
 
 // Configuration for Prometheus
 type PrometheusConfig struct {
@@ -21,6 +22,9 @@ type PrometheusConfig struct {
 	// Toggle for forwarding the API port outside of Docker
 	OpenPort config.Parameter `yaml:"openPort,omitempty"`
 
+	// How long to keep metrics for
+	Retention config.Parameter `yaml:"retention,omitempty"`
+
 	// The Docker Hub tag for Prometheus
 	ContainerTag config.Parameter `yaml:"containerTag,omitempty"`
 
@@ -57,6 +61,21 @@ func NewPrometheusConfig(cfg *RocketPoolConfig) *PrometheusConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		Retention: config.Parameter{
+			ID:                   "retention",
+			Name:                 "Retention Period",
+			Description:          "How long Prometheus should keep your metrics before deleting them, as a number followed by a unit (e.g. `15d` for 15 days or `1y` for 1 year). Longer periods let you view more history in Grafana, but use more disk space.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultPrometheusRetention},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Prometheus},
+			EnvironmentVariables: []string{},
+			// A number followed by one of Prometheus's duration units
+			Regex:              "^[0-9]+(ms|s|m|h|d|w|y)$",
+			MaxLength:          8,
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
 		ContainerTag: config.Parameter{
 			ID:                   "containerTag",
 			Name:                 "Prometheus Container Tag",
@@ -88,6 +107,7 @@ func (cfg *PrometheusConfig) GetParameters() []*config.Parameter {
 	return []*config.Parameter{
 		&cfg.Port,
 		&cfg.OpenPort,
+		&cfg.Retention,
 		&cfg.ContainerTag,
 		&cfg.AdditionalFlags,
 	}
@@ -54,6 +54,9 @@ type RocketPoolConfig struct {
 
 	Version string `yaml:"-"`
 
+	// Descriptions of the migrations applied when this config was loaded
+	AppliedMigrations []string `yaml:"-"`
+
 	RocketPoolDirectory string `yaml:"-"`
 
 	IsNativeMode bool `yaml:"-"`
@@ -736,10 +739,11 @@ func (cfg *RocketPoolConfig) Serialize() map[string]map[string]string {
 func (cfg *RocketPoolConfig) Deserialize(masterMap map[string]map[string]string) error {
 
 	// Upgrade the config to the latest version
-	err := migration.UpdateConfig(masterMap)
+	appliedMigrations, err := migration.UpdateConfig(masterMap)
 	if err != nil {
 		return fmt.Errorf("error upgrading configuration to v%s: %w", shared.RocketPoolVersion, err)
 	}
+	cfg.AppliedMigrations = appliedMigrations
 
 	// Get the network
 	network := config.Network_Mainnet
@@ -943,9 +947,11 @@ func (cfg *RocketPoolConfig) GenerateEnvironmentVariables() map[string]string {
 		if cfg.Exporter.AdditionalFlags.Value.(string) != "" {
 			envVars["EXPORTER_ADDITIONAL_FLAGS"] = fmt.Sprintf(", \"%s\"", cfg.Exporter.AdditionalFlags.Value.(string))
 		}
+		prometheusFlags := fmt.Sprintf(", \"--storage.tsdb.retention.time=%s\"", cfg.Prometheus.Retention.Value.(string))
 		if cfg.Prometheus.AdditionalFlags.Value.(string) != "" {
-			envVars["PROMETHEUS_ADDITIONAL_FLAGS"] = fmt.Sprintf(", \"%s\"", cfg.Prometheus.AdditionalFlags.Value.(string))
+			prometheusFlags += fmt.Sprintf(", \"%s\"", cfg.Prometheus.AdditionalFlags.Value.(string))
 		}
+		envVars["PROMETHEUS_ADDITIONAL_FLAGS"] = prometheusFlags
 	}
 
 	// Bitfly Node Metrics
@@ -1035,6 +1041,14 @@ func (cfg *RocketPoolConfig) GetChanges(oldConfig *RocketPoolConfig) (map[string
 	return changedSettings, totalAffectedContainers, changeNetworks
 }
 
+// Get all of the settings that differ from the defaults for the selected network, by category.
+// The old value of each setting is its default.
+func (cfg *RocketPoolConfig) GetChangesFromDefaults() map[string][]config.ChangedSetting {
+	defaultConfig := NewRocketPoolConfig(cfg.RocketPoolDirectory, cfg.IsNativeMode)
+	defaultConfig.ChangeNetwork(cfg.Smartnode.Network.Value.(config.Network))
+	return getChangedSettingsMap(defaultConfig, cfg)
+}
+
 // Checks to see if the current configuration is valid; if not, returns a list of errors
 func (cfg *RocketPoolConfig) Validate() []string {
 	errors := []string{}
@@ -1074,6 +1088,11 @@ func (cfg *RocketPoolConfig) Validate() []string {
 		errors = append(errors, "You are using an externally-managed Execution client and a locally-managed Consensus client.\nThis configuration is not compatible with The Merge; please select either locally-managed or externally-managed for both the EC and CC.")
 	}
 
+	// Ensure the rewards tree file permissions are sane
+	if _, err := cfg.Smartnode.GetRewardsTreeFileMode(); err != nil {
+		errors = append(errors, fmt.Sprintf("Your %s.", err.Error()))
+	}
+
 	// Ensure there's a MEV-boost URL
 	if !cfg.IsNativeMode && cfg.EnableMevBoost.Value == true {
 		switch cfg.MevBoost.Mode.Value.(config.Mode) {
@@ -2,11 +2,17 @@ package config
 
 import (
 	"fmt"
+	"math"
+	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/mitchellh/go-homedir"
 	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
 	"github.com/rocket-pool/smartnode/shared/types/config"
 )
 
@@ -20,21 +26,46 @@ const (
 	SnapshotID                         string = "rocketpool-dao.eth"
 	RewardsTreeFilenameFormat          string = "rp-rewards-%s-%d.json"
 	MinipoolPerformanceFilenameFormat  string = "rp-minipool-performance-%s-%d.json"
+	RewardsWatchFilenameFormat         string = "rp-rewards-watch-%s.json"
 	RewardsTreeIpfsExtension           string = ".zst"
+	RewardsTreeBinaryExtension         string = ".bin"
+	RewardsTreeGzipExtension           string = ".gz"
+	RewardsTreeSummarySuffix           string = "-summary.txt"
+	RewardsTreeWithdrawalsSuffix       string = "-withdrawal-addresses.json"
 	RewardsTreesFolder                 string = "rewards-trees"
 	DaemonDataPath                     string = "/.rocketpool/data"
 	WatchtowerFolder                   string = "watchtower"
 	WatchtowerStateFile                string = "state.yml"
+	WatchtowerHeartbeatFile            string = "heartbeat.json"
+	RewardsTreeGenerationStatusFile    string = "rewards-tree-status.json"
 	RegenerateRewardsTreeRequestSuffix string = ".request"
 	RegenerateRewardsTreeRequestFormat string = "%d" + RegenerateRewardsTreeRequestSuffix
+	VerifyRewardsTreeRequestSuffix     string = ".verify"
+	VerifyRewardsTreeRequestFormat     string = "%d" + VerifyRewardsTreeRequestSuffix
+	RegenerateRewardsTreeDryRunSuffix  string = ".dryrun"
+	RegenerateRewardsTreeDryRunFormat  string = "%d" + RegenerateRewardsTreeDryRunSuffix
+	UnsignedChallengeResponseFile      string = "challenge-response.unsigned.json"
+	RewardsTreeCheckpointSuffix        string = ".partial"
+	RewardsTreeCheckpointFormat        string = "%d" + RewardsTreeCheckpointSuffix
+	RewardsTreeGeneratingSuffix        string = ".generating"
+	RewardsTreeGeneratingFormat        string = "%d" + RewardsTreeGeneratingSuffix
+	RewardsTreeNodeSnapshotSuffix      string = ".nodes"
+	RewardsTreeNodeSnapshotFormat      string = "%d" + RewardsTreeNodeSnapshotSuffix
 	PrimaryRewardsFileUrl              string = "https://%s.ipfs.dweb.link/%s"
 	SecondaryRewardsFileUrl            string = "https://ipfs.io/ipfs/%s/%s"
 	FeeRecipientFilename               string = "rp-fee-recipient.txt"
 	NativeFeeRecipientFilename         string = "rp-fee-recipient-env.txt"
 )
 
+// A generation lock file that hasn't been refreshed for this long belongs to a run that crashed
+const RewardsTreeGeneratingLockTimeout time.Duration = 10 * time.Minute
+
+// The most intervals a single rewards tree generation request can name
+const MaxRewardsTreeRequestIntervals uint64 = 1000
+
 // Defaults
 const defaultProjectName string = "rocketpool"
+const defaultRewardsTreeFileMode string = "0644"
 
 // Configuration for the Smartnode
 type SmartnodeConfig struct {
@@ -53,6 +84,15 @@ type SmartnodeConfig struct {
 	// The path of the data folder where everything is stored
 	DataPath config.Parameter `yaml:"dataPath,omitempty"`
 
+	// Toggle for enforcing the password policy when the node password is set
+	PasswordPolicyEnabled config.Parameter `yaml:"passwordPolicyEnabled,omitempty"`
+
+	// The minimum length of a new node password under the password policy
+	PasswordMinLength config.Parameter `yaml:"passwordMinLength,omitempty"`
+
+	// The minimum number of character classes a new node password has to use under the password policy
+	PasswordMinCharacterClasses config.Parameter `yaml:"passwordMinCharacterClasses,omitempty"`
+
 	// The path of the watchtower's persistent state storage
 	WatchtowerStatePath config.Parameter `yaml:"watchtowerStatePath"`
 
@@ -77,6 +117,130 @@ type SmartnodeConfig struct {
 	// Token for Oracle DAO members to use when uploading Merkle trees to Web3.Storage
 	Web3StorageApiToken config.Parameter `yaml:"web3StorageApiToken,omitempty"`
 
+	// Toggle for uploading trees generated by the watchtower to an IPFS node, and the node's API URL
+	UploadTreesToIpfs config.Parameter `yaml:"uploadTreesToIpfs,omitempty"`
+	IpfsApiUrl        config.Parameter `yaml:"ipfsApiUrl,omitempty"`
+
+	// A URL serving rewards tree files that the node tries before IPFS when downloading missing trees
+	RewardsTreeMirrorUrl config.Parameter `yaml:"rewardsTreeMirrorUrl,omitempty"`
+
+	// The permissions used when saving rewards tree files
+	RewardsTreeFileMode config.Parameter `yaml:"rewardsTreeFileMode,omitempty"`
+
+	// How the rewards tree generator makes its per-node calls to the Execution client
+	RewardsEcCallStrategy config.Parameter `yaml:"rewardsEcCallStrategy,omitempty"`
+
+	// The number of threads to use for the rewards tree generator's per-node calculations
+	RewardsTreeThreads config.Parameter `yaml:"rewardsTreeThreads,omitempty"`
+
+	// Whether or not to reuse the previous interval's node details during generation
+	RewardsTreeIncremental config.Parameter `yaml:"rewardsTreeIncremental,omitempty"`
+
+	// Whether or not to gzip the rewards tree files the watchtower generates
+	RewardsTreeGzip config.Parameter `yaml:"rewardsTreeGzip,omitempty"`
+
+	// Toggle for adding a per-minipool breakdown of smoothing pool ETH to manually generated rewards trees
+	RewardsTreeMinipoolDetail config.Parameter `yaml:"rewardsTreeMinipoolDetail,omitempty"`
+
+	// The number of recent rewards tree files to keep when pruning old ones
+	RewardsTreeRetentionCount config.Parameter `yaml:"rewardsTreeRetentionCount,omitempty"`
+
+	// Toggle for checking the Beacon config against the fallback client before generating a tree
+	RewardsTreeCrossCheckBeaconConfig config.Parameter `yaml:"rewardsTreeCrossCheckBeaconConfig,omitempty"`
+
+	// The maximum number of EC requests per second to make while calculating rewards
+	RewardsTreeMaxRequestsPerSecond config.Parameter `yaml:"rewardsTreeMaxRequestsPerSecond,omitempty"`
+
+	// Toggle for regenerating a mismatched tree at the adjacent EL blocks
+	RewardsTreeRetryAdjacentBlocks config.Parameter `yaml:"rewardsTreeRetryAdjacentBlocks,omitempty"`
+
+	// The maximum number of manual rewards tree requests the watchtower picks up at once
+	RewardsTreeRequestBatchSize config.Parameter `yaml:"rewardsTreeRequestBatchSize,omitempty"`
+
+	// How long the watchtower waits for tree generation to finish when it's shutting down
+	WatchtowerShutdownTimeout config.Parameter `yaml:"watchtowerShutdownTimeout,omitempty"`
+
+	// The maximum time to wait for a single Execution or Beacon client call
+	ClientCallTimeout config.Parameter `yaml:"clientCallTimeout,omitempty"`
+
+	// The maximum number of watchtower tasks that can run at the same time
+	WatchtowerMaxConcurrentTasks config.Parameter `yaml:"watchtowerMaxConcurrentTasks,omitempty"`
+
+	// How often the watchtower runs the generate-rewards-tree task
+	WatchtowerGenerateRewardsTreeInterval config.Parameter `yaml:"watchtowerGenerateRewardsTreeInterval,omitempty"`
+
+	// How often the watchtower runs the respond-challenges task
+	WatchtowerRespondChallengesInterval config.Parameter `yaml:"watchtowerRespondChallengesInterval,omitempty"`
+
+	// How often the watchtower runs the submit-rewards-tree task
+	WatchtowerSubmitRewardsTreeInterval config.Parameter `yaml:"watchtowerSubmitRewardsTreeInterval,omitempty"`
+
+	// How often the watchtower runs the validate-new-intervals task
+	WatchtowerValidateNewIntervalsInterval config.Parameter `yaml:"watchtowerValidateNewIntervalsInterval,omitempty"`
+
+	// How often the watchtower runs the prune-rewards-trees task
+	WatchtowerPruneRewardsTreesInterval config.Parameter `yaml:"watchtowerPruneRewardsTreesInterval,omitempty"`
+
+	// How often the watchtower runs the submit-rpl-price task
+	WatchtowerSubmitRplPriceInterval config.Parameter `yaml:"watchtowerSubmitRplPriceInterval,omitempty"`
+
+	// How often the watchtower runs the submit-network-balances task
+	WatchtowerSubmitNetworkBalancesInterval config.Parameter `yaml:"watchtowerSubmitNetworkBalancesInterval,omitempty"`
+
+	// How often the watchtower runs the submit-withdrawable-minipools task
+	WatchtowerSubmitWithdrawableMinipoolsInterval config.Parameter `yaml:"watchtowerSubmitWithdrawableMinipoolsInterval,omitempty"`
+
+	// How often the watchtower runs the dissolve-timed-out-minipools task
+	WatchtowerDissolveTimedOutMinipoolsInterval config.Parameter `yaml:"watchtowerDissolveTimedOutMinipoolsInterval,omitempty"`
+
+	// How often the watchtower runs the process-withdrawals task
+	WatchtowerProcessWithdrawalsInterval config.Parameter `yaml:"watchtowerProcessWithdrawalsInterval,omitempty"`
+
+	// How often the watchtower runs the submit-scrub-minipools task
+	WatchtowerSubmitScrubMinipoolsInterval config.Parameter `yaml:"watchtowerSubmitScrubMinipoolsInterval,omitempty"`
+
+	// Toggle for logging watchtower output as JSON objects instead of colored text
+	WatchtowerJsonLogging config.Parameter `yaml:"watchtowerJsonLogging,omitempty"`
+
+	// The service used to send notifications about tree generation and challenges
+	NotificationBackend config.Parameter `yaml:"notificationBackend,omitempty"`
+
+	// The URL for webhook and Discord notifications
+	NotificationWebhookUrl config.Parameter `yaml:"notificationWebhookUrl,omitempty"`
+
+	// The bot token and chat for Telegram notifications
+	TelegramBotToken config.Parameter `yaml:"telegramBotToken,omitempty"`
+	TelegramChatID   config.Parameter `yaml:"telegramChatID,omitempty"`
+
+	// The SMTP server and addresses for email notifications
+	SmtpServer   config.Parameter `yaml:"smtpServer,omitempty"`
+	SmtpUsername config.Parameter `yaml:"smtpUsername,omitempty"`
+	SmtpPassword config.Parameter `yaml:"smtpPassword,omitempty"`
+	EmailFrom    config.Parameter `yaml:"emailFrom,omitempty"`
+	EmailTo      config.Parameter `yaml:"emailTo,omitempty"`
+
+	// The window, in minutes, during which repeats of the same notification are suppressed
+	NotificationThrottleWindow config.Parameter `yaml:"notificationThrottleWindow,omitempty"`
+
+	// Whether to export challenge responses for offline signing instead of sending them
+	ExportChallengeResponses config.Parameter `yaml:"exportChallengeResponses,omitempty"`
+
+	// The URL to POST the details of each challenge the watchtower handles to
+	ChallengeWebhookUrl config.Parameter `yaml:"challengeWebhookUrl,omitempty"`
+
+	// Whether to decide expired challenges against other Oracle DAO members
+	DecideExpiredChallenges config.Parameter `yaml:"decideExpiredChallenges,omitempty"`
+
+	// The base fee (in gwei) above which the watchtower defers challenge responses
+	ChallengeMaxBaseFee config.Parameter `yaml:"challengeMaxBaseFee,omitempty"`
+
+	// Whether to automatically generate and validate the tree for each new rewards interval
+	ValidateNewIntervals config.Parameter `yaml:"validateNewIntervals,omitempty"`
+
+	// The URL of the Validator client's keymanager API and the file holding its auth token
+	KeymanagerApiUrl       config.Parameter `yaml:"keymanagerApiUrl,omitempty"`
+	KeymanagerApiTokenPath config.Parameter `yaml:"keymanagerApiTokenPath,omitempty"`
+
 	///////////////////////////
 	// Non-editable settings //
 	///////////////////////////
@@ -111,6 +275,15 @@ type SmartnodeConfig struct {
 	// The contract address of rETH
 	rethAddress map[config.Network]string `yaml:"-"`
 
+	// The contract address of Multicall3
+	multicallAddress map[config.Network]string `yaml:"-"`
+
+	// The genesis time of the Beacon Chain, or 0 if it isn't known ahead of time
+	beaconGenesisTime map[config.Network]uint64 `yaml:"-"`
+
+	// The genesis fork version of the Beacon Chain, or blank if it isn't known ahead of time
+	beaconGenesisForkVersion map[config.Network]string `yaml:"-"`
+
 	// The contract address of rocketRewardsPool from v1.0.0
 	legacyRewardsPoolAddress map[config.Network]string `yaml:"-"`
 
@@ -173,6 +346,42 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		PasswordPolicyEnabled: config.Parameter{
+			ID:                   "passwordPolicyEnabled",
+			Name:                 "Enforce Password Policy",
+			Description:          "Require new node passwords to meet the minimum length and character class settings below.\n\nDisable this if you set the password from an automated script that can't meet the policy; passwords still have to be at least 12 characters long.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PasswordMinLength: config.Parameter{
+			ID:                   "passwordMinLength",
+			Name:                 "Password Minimum Length",
+			Description:          "The minimum number of characters a new node password needs when the password policy is enforced. Values below 12 have no effect, since every password has to be at least that long.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(12)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		PasswordMinCharacterClasses: config.Parameter{
+			ID:                   "passwordMinCharacterClasses",
+			Name:                 "Password Character Classes",
+			Description:          "The minimum number of character classes (lowercase letters, uppercase letters, numbers, and symbols) a new node password has to use when the password policy is enforced.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(3)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
 		WatchtowerStatePath: config.Parameter{
 			ID:                   "watchtowerPath",
 			Name:                 "Watchtower Path",
@@ -280,6 +489,590 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade:   false,
 		},
 
+		UploadTreesToIpfs: config.Parameter{
+			ID:                   "uploadTreesToIpfs",
+			Name:                 "Upload Trees to IPFS",
+			Description:          "Enable this to have the watchtower upload and pin every rewards tree it generates to your IPFS node, so other node operators can download it from there.\n\nIf the IPFS node can't be reached, the tree is still saved locally.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		IpfsApiUrl: config.Parameter{
+			ID:                   "ipfsApiUrl",
+			Name:                 "IPFS API URL",
+			Description:          "The URL of your IPFS node's HTTP RPC API (e.g. `http://localhost:5001`), used when Upload Trees to IPFS is enabled.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RewardsTreeMirrorUrl: config.Parameter{
+			ID:                   "rewardsTreeMirrorUrl",
+			Name:                 "Rewards Tree Mirror URL",
+			Description:          "The http(s) URL of a folder serving rewards tree files under their usual names (e.g. `https://trees.example.com/rewards-trees`), such as network storage shared by several nodes. When your node is missing a tree, it will try to download it from here before falling back to IPFS. Trees from here are only saved if they match the canonical Merkle root for their interval.\n\nLeave this blank to only download trees from IPFS.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RewardsTreeFileMode: config.Parameter{
+			ID:                   "rewardsTreeFileMode",
+			Name:                 "Rewards Tree File Permissions",
+			Description:          "The permissions (as an octal mode, such as 0644 or 0600) that will be given to the rewards tree files and minipool performance files the Smartnode saves.\nThe owner must be able to read and write the files, and they can't be executable or writable by everyone.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: defaultRewardsTreeFileMode},
+			MaxLength:            4,
+			Regex:                "^0?[0-7]{3}$",
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RewardsEcCallStrategy: config.Parameter{
+			ID:                   "rewardsEcCallStrategy",
+			Name:                 "Rewards EC Call Strategy",
+			Description:          "Select how the rewards tree generator should query your Execution client for each node's details. If multicall fails during generation, the Smartnode will automatically switch to individual calls for the rest of the run.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.EcCallStrategy_Multicall},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "Multicall",
+				Description: "Batch many calls together into a single request using the Multicall3 contract. This is much faster on most clients.",
+				Value:       config.EcCallStrategy_Multicall,
+			}, {
+				Name:        "Individual",
+				Description: "Send each call as its own request. Use this if your Execution client struggles with large multicall requests.",
+				Value:       config.EcCallStrategy_Individual,
+			}},
+		},
+
+		RewardsTreeThreads: config.Parameter{
+			ID:                   "rewardsTreeThreads",
+			Name:                 "Rewards Tree Threads",
+			Description:          "The number of threads the rewards tree generator should use to calculate each node's rewards in parallel. Lower this if your machine struggles during tree generation.\n\nUse 0 to use one thread per CPU core.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RewardsTreeGzip: config.Parameter{
+			ID:                   "rewardsTreeGzip",
+			Name:                 "Compress Rewards Tree Files",
+			Description:          "Enable this to save the rewards tree files the watchtower generates with gzip compression (e.g. `rp-rewards-mainnet-10.json.gz`) instead of as plain JSON. This saves a lot of disk space if you keep a long history of intervals.\n\nThe Smartnode reads both kinds of files, so you can change this at any time. Files that were already generated aren't converted.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RewardsTreeIncremental: config.Parameter{
+			ID:                   "rewardsTreeIncremental",
+			Name:                 "Incremental Rewards Tree Generation",
+			Description:          "Enable this to save each node's RPL stake and minipools when a rewards tree is generated, so the next interval only has to query the nodes that changed since then. This can make generating consecutive intervals much faster.\n\nThe snapshots are stored in the watchtower folder. If the previous interval's snapshot is missing, every node is queried as usual.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RewardsTreeMinipoolDetail: config.Parameter{
+			ID:                   "rewardsTreeMinipoolDetail",
+			Name:                 "Include Minipool Rewards",
+			Description:          "Enable this to add a breakdown of how much smoothing pool ETH each minipool earned to the rewards trees you generate manually, under a separate `minipoolRewards` field. This doesn't affect the Merkle root.\n\n[orange]NOTE: This is never applied to the trees Oracle DAO members submit, since they must match the other members' files exactly.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RewardsTreeRetentionCount: config.Parameter{
+			ID:                   "rewardsTreeRetentionCount",
+			Name:                 "Rewards Trees to Keep",
+			Description:          "The number of the most recent intervals whose rewards tree files the watchtower should keep. Older files are deleted once a day to save disk space, except for intervals where your node still has rewards to claim.\n\nUse 0 to keep every file.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RewardsTreeCrossCheckBeaconConfig: config.Parameter{
+			ID:                   "rewardsTreeCrossCheckBeaconConfig",
+			Name:                 "Cross-Check Beacon Config",
+			Description:          "Enable this to have the watchtower compare your Beacon Node's genesis time and slot duration against your fallback Beacon Node's before generating a rewards tree, and refuse to generate it if they disagree. A misconfigured Beacon Node would otherwise produce a tree with the wrong root.\n\nThis requires fallback clients to be enabled.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RewardsTreeMaxRequestsPerSecond: config.Parameter{
+			ID:                   "rewardsTreeMaxRequestsPerSecond",
+			Name:                 "Rewards Tree Max Requests Per Second",
+			Description:          "The maximum number of requests per second the watchtower will make to your Execution client while calculating a rewards tree. The limit is shared by every generation thread, and short bursts are smoothed out instead of rejected.\n\nSet this if you use a rate-limited Execution client provider. Leave it at 0 for no limit, which is best for a local Execution client.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RewardsTreeRetryAdjacentBlocks: config.Parameter{
+			ID:                   "rewardsTreeRetryAdjacentBlocks",
+			Name:                 "Retry Adjacent Blocks on Mismatch",
+			Description:          "When a manually generated rewards tree doesn't match the canonical root, regenerate it at the execution blocks just before and after the snapshot block, since timestamp rounding can leave it off by one. If one of them matches, that tree is saved instead.\n\nEach retry takes as long as the original generation. Disable this to skip the retries.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		RewardsTreeRequestBatchSize: config.Parameter{
+			ID:                   "rewardsTreeRequestBatchSize",
+			Name:                 "Rewards Tree Request Batch Size",
+			Description:          "The maximum number of manual rewards tree generation requests the watchtower picks up at once. They're still generated one at a time, lowest interval first, but back to back instead of one per duty cycle.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(1)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerShutdownTimeout: config.Parameter{
+			ID:                   "watchtowerShutdownTimeout",
+			Name:                 "Watchtower Shutdown Timeout",
+			Description:          "The number of seconds the watchtower waits for a rewards tree that's being generated to finish when it's asked to shut down. If the tree isn't done in time, any partially-written files are removed so they can't be loaded later; the checkpoint is kept, so generation resumes on the next start.\n\nThe watchtower container's stop timeout must be at least this long, or it will be killed before the wait is over.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(30)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ClientCallTimeout: config.Parameter{
+			ID:                   "clientCallTimeout",
+			Name:                 "Client Call Timeout",
+			Description:          "The maximum number of seconds the Smartnode will wait for a single call to your Execution or Beacon client before giving up on it. A task that hits this logs the timeout and tries again on its next run, instead of hanging on a slow or unresponsive client.\n\nUse 0 to wait indefinitely.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(120)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerMaxConcurrentTasks: config.Parameter{
+			ID:                   "watchtowerMaxConcurrentTasks",
+			Name:                 "Watchtower Max Concurrent Tasks",
+			Description:          "The maximum number of watchtower tasks that can run at the same time. Tasks that come due while this many are already running wait for one of them to finish, so several heavy tasks can't overwhelm your Execution client at once.\n\nThe default of 1 runs the tasks one at a time, so their transactions don't compete with each other. Rewards tree generation continues in the background after its task finishes, so it doesn't count against this limit.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(1)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerGenerateRewardsTreeInterval: config.Parameter{
+			ID:                   "watchtowerGenerateRewardsTreeInterval",
+			Name:                 "Watchtower Generate Rewards Tree Interval",
+			Description:          "The number of seconds between runs of the watchtower task that checks for manual rewards tree generation requests.\n\nSet this to 0 to use the default schedule, which runs it every 4 to 6 minutes along with the other tasks.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerRespondChallengesInterval: config.Parameter{
+			ID:                   "watchtowerRespondChallengesInterval",
+			Name:                 "Watchtower Respond Challenges Interval",
+			Description:          "The number of seconds between runs of the watchtower task that checks for challenges against this node and other Oracle DAO members.\n\nSet this to 0 to use the default schedule, which runs it every 4 to 6 minutes along with the other tasks.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerSubmitRewardsTreeInterval: config.Parameter{
+			ID:                   "watchtowerSubmitRewardsTreeInterval",
+			Name:                 "Watchtower Submit Rewards Tree Interval",
+			Description:          "The number of seconds between runs of the watchtower task that checks whether a rewards interval has ended and submits its tree.\n\nSet this to 0 to use the default schedule, which runs it every 4 to 6 minutes along with the other tasks.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerValidateNewIntervalsInterval: config.Parameter{
+			ID:                   "watchtowerValidateNewIntervalsInterval",
+			Name:                 "Watchtower Validate New Intervals Interval",
+			Description:          "The number of seconds between runs of the watchtower task that validates newly submitted rewards intervals.\n\nSet this to 0 to use the default schedule, which runs it every 4 to 6 minutes along with the other tasks.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerPruneRewardsTreesInterval: config.Parameter{
+			ID:                   "watchtowerPruneRewardsTreesInterval",
+			Name:                 "Watchtower Prune Rewards Trees Interval",
+			Description:          "The number of seconds between runs of the watchtower task that prunes old rewards tree files.\n\nSet this to 0 to use the default schedule, which runs it every 4 to 6 minutes along with the other tasks.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerSubmitRplPriceInterval: config.Parameter{
+			ID:                   "watchtowerSubmitRplPriceInterval",
+			Name:                 "Watchtower Submit RPL Price Interval",
+			Description:          "The number of seconds between runs of the watchtower task that checks whether the RPL price is due to be submitted.\n\nSet this to 0 to use the default schedule, which runs it every 4 to 6 minutes along with the other tasks.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerSubmitNetworkBalancesInterval: config.Parameter{
+			ID:                   "watchtowerSubmitNetworkBalancesInterval",
+			Name:                 "Watchtower Submit Network Balances Interval",
+			Description:          "The number of seconds between runs of the watchtower task that checks whether the network balances are due to be submitted.\n\nSet this to 0 to use the default schedule, which runs it every 4 to 6 minutes along with the other tasks.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerSubmitWithdrawableMinipoolsInterval: config.Parameter{
+			ID:                   "watchtowerSubmitWithdrawableMinipoolsInterval",
+			Name:                 "Watchtower Submit Withdrawable Minipools Interval",
+			Description:          "The number of seconds between runs of the watchtower task that checks for minipools that have become withdrawable.\n\nSet this to 0 to use the default schedule, which runs it every 4 to 6 minutes along with the other tasks.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerDissolveTimedOutMinipoolsInterval: config.Parameter{
+			ID:                   "watchtowerDissolveTimedOutMinipoolsInterval",
+			Name:                 "Watchtower Dissolve Timed Out Minipools Interval",
+			Description:          "The number of seconds between runs of the watchtower task that checks for prelaunch minipools that have timed out.\n\nSet this to 0 to use the default schedule, which runs it every 4 to 6 minutes along with the other tasks.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerProcessWithdrawalsInterval: config.Parameter{
+			ID:                   "watchtowerProcessWithdrawalsInterval",
+			Name:                 "Watchtower Process Withdrawals Interval",
+			Description:          "The number of seconds between runs of the watchtower task that checks for withdrawals to process.\n\nSet this to 0 to use the default schedule, which runs it every 4 to 6 minutes along with the other tasks.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerSubmitScrubMinipoolsInterval: config.Parameter{
+			ID:                   "watchtowerSubmitScrubMinipoolsInterval",
+			Name:                 "Watchtower Submit Scrub Minipools Interval",
+			Description:          "The number of seconds between runs of the watchtower task that checks for minipools that need to be scrubbed.\n\nSet this to 0 to use the default schedule, which runs it every 4 to 6 minutes along with the other tasks.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		WatchtowerJsonLogging: config.Parameter{
+			ID:                   "watchtowerJsonLogging",
+			Name:                 "Watchtower JSON Logging",
+			Description:          "Enable this to have the watchtower write each log line as a JSON object with the time, task, level, interval (when there is one), and message, so it can be ingested by a log aggregator.\n\nLeave this off to keep the colored, human-readable logs.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NotificationBackend: config.Parameter{
+			ID:                   "notificationBackend",
+			Name:                 "Notification Service",
+			Description:          "Select the service that the Smartnode should use to notify you when a rewards tree has been generated (or failed to generate), and when your Oracle DAO node has been challenged.",
+			Type:                 config.ParameterType_Choice,
+			Default:              map[config.Network]interface{}{config.Network_All: config.NotificationBackend_None},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+			Options: []config.ParameterOption{{
+				Name:        "None",
+				Description: "Don't send any notifications.",
+				Value:       config.NotificationBackend_None,
+			}, {
+				Name:        "Webhook",
+				Description: "POST each notification as JSON to the URL in the `Notification Webhook URL` setting.",
+				Value:       config.NotificationBackend_Webhook,
+			}, {
+				Name:        "Discord",
+				Description: "Send each notification to the Discord webhook in the `Notification Webhook URL` setting.",
+				Value:       config.NotificationBackend_Discord,
+			}, {
+				Name:        "Telegram",
+				Description: "Send each notification to a Telegram chat using the `Telegram Bot Token` and `Telegram Chat ID` settings.",
+				Value:       config.NotificationBackend_Telegram,
+			}, {
+				Name:        "Email",
+				Description: "Send each notification as an email using the SMTP settings.",
+				Value:       config.NotificationBackend_Email,
+			}},
+		},
+
+		NotificationWebhookUrl: config.Parameter{
+			ID:                   "notificationWebhookUrl",
+			Name:                 "Notification Webhook URL",
+			Description:          "The URL to send notifications to when using the Webhook or Discord notification services.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		TelegramBotToken: config.Parameter{
+			ID:                   "telegramBotToken",
+			Name:                 "Telegram Bot Token",
+			Description:          "The token of the Telegram bot that will send notifications, when using the Telegram notification service.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		TelegramChatID: config.Parameter{
+			ID:                   "telegramChatID",
+			Name:                 "Telegram Chat ID",
+			Description:          "The ID of the Telegram chat that notifications will be sent to, when using the Telegram notification service.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SmtpServer: config.Parameter{
+			ID:                   "smtpServer",
+			Name:                 "SMTP Server",
+			Description:          "The address of the SMTP server to send email notifications through, including the port (e.g. `smtp.example.com:587`).",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SmtpUsername: config.Parameter{
+			ID:                   "smtpUsername",
+			Name:                 "SMTP Username",
+			Description:          "The username to log into the SMTP server with. Leave this blank if the server doesn't require authentication.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		SmtpPassword: config.Parameter{
+			ID:                   "smtpPassword",
+			Name:                 "SMTP Password",
+			Description:          "The password to log into the SMTP server with.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EmailFrom: config.Parameter{
+			ID:                   "emailFrom",
+			Name:                 "Email Sender",
+			Description:          "The address that email notifications will be sent from.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		EmailTo: config.Parameter{
+			ID:                   "emailTo",
+			Name:                 "Email Recipients",
+			Description:          "The address (or comma-separated addresses) that email notifications will be sent to.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		NotificationThrottleWindow: config.Parameter{
+			ID:                   "notificationThrottleWindow",
+			Name:                 "Notification Throttle Window",
+			Description:          "The number of minutes during which repeats of the same notification (for example, repeated tree generation failures while your Execution client is down) will be suppressed. A summary of how many were suppressed will be sent at the end of the window.\n\nUse 0 to send every notification.",
+			Type:                 config.ParameterType_Uint,
+			Default:              map[config.Network]interface{}{config.Network_All: uint64(60)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ExportChallengeResponses: config.Parameter{
+			ID:                   "exportChallengeResponses",
+			Name:                 "Export Challenge Responses",
+			Description:          "Enable this if your node's key is kept offline. Instead of responding to a challenge against your node automatically, the watchtower will save the unsigned response transaction to its folder so you can sign it offline and broadcast it with `rocketpool odao broadcast-challenge-response`.\n\n[orange]NOTE: This is only used by Oracle DAO members.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ChallengeWebhookUrl: config.Parameter{
+			ID:                   "challengeWebhookUrl",
+			Name:                 "Challenge Webhook URL",
+			Description:          "(Optional) A URL that the watchtower will POST a JSON report to whenever it handles a challenge, including the node address, when the challenge was detected, the response transaction hash, and whether it succeeded.\n\n[orange]NOTE: This is only used by Oracle DAO members.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		DecideExpiredChallenges: config.Parameter{
+			ID:                   "decideExpiredChallenges",
+			Name:                 "Decide Expired Challenges",
+			Description:          "Enable this to have the watchtower check every Oracle DAO member for a challenge whose response window has passed, and decide it on their behalf. Your own node's challenges are always responded to regardless of this setting.\n\n[orange]NOTE: This is only used by Oracle DAO members.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ChallengeMaxBaseFee: config.Parameter{
+			ID:                   "challengeMaxBaseFee",
+			Name:                 "Challenge Max Base Fee",
+			Description:          "The highest network base fee (in gwei) at which the watchtower will respond to or decide a challenge. If the base fee is above this, the watchtower will wait and try again on its next check instead of overpaying during a gas spike; the challenge window is long enough that a short delay is safe.\n\nA value of 0 disables the limit.\n\n[orange]NOTE: This is only used by Oracle DAO members.",
+			Type:                 config.ParameterType_Float,
+			Default:              map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		ValidateNewIntervals: config.Parameter{
+			ID:                   "validateNewIntervals",
+			Name:                 "Validate New Intervals",
+			Description:          "Enable this to have the watchtower check every new rewards interval as it appears. It will generate the Merkle tree for the interval if you don't already have it, compare its root against the canonical one, and send a notification if they don't match.\n\n[orange]NOTE: This is only used by Oracle DAO members.",
+			Type:                 config.ParameterType_Bool,
+			Default:              map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Watchtower},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           false,
+			OverwriteOnUpgrade:   false,
+		},
+
+		KeymanagerApiUrl: config.Parameter{
+			ID:                   "keymanagerApiUrl",
+			Name:                 "Keymanager API URL",
+			Description:          "The URL of your Validator client's keymanager API (for example, http://eth2:5062). This is used by `rocketpool wallet verify-custom-keys` to confirm which keys your Validator client has loaded. Leave it blank if your Validator client doesn't have the keymanager API enabled.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
+		KeymanagerApiTokenPath: config.Parameter{
+			ID:                   "keymanagerApiTokenPath",
+			Name:                 "Keymanager API Token Path",
+			Description:          "The path to the file holding the bearer token for your Validator client's keymanager API, as the Smartnode daemon sees it.",
+			Type:                 config.ParameterType_String,
+			Default:              map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:    []config.ContainerID{config.ContainerID_Api},
+			EnvironmentVariables: []string{},
+			CanBeBlank:           true,
+			OverwriteOnUpgrade:   false,
+		},
+
 		txWatchUrl: map[config.Network]string{
 			config.Network_Mainnet: "https://etherscan.io/tx",
 			config.Network_Prater:  "https://goerli.etherscan.io/tx",
@@ -328,6 +1121,24 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			config.Network_Devnet:  "0x2DF914425da6d0067EF1775AfDBDd7B24fc8100E",
 		},
 
+		multicallAddress: map[config.Network]string{
+			config.Network_Mainnet: "0xcA11bde05977b3631167028862bE2a173976CA11",
+			config.Network_Prater:  "0xcA11bde05977b3631167028862bE2a173976CA11",
+			config.Network_Devnet:  "0xcA11bde05977b3631167028862bE2a173976CA11",
+		},
+
+		beaconGenesisTime: map[config.Network]uint64{
+			config.Network_Mainnet: 1606824023,
+			config.Network_Prater:  1616508000,
+			config.Network_Devnet:  0,
+		},
+
+		beaconGenesisForkVersion: map[config.Network]string{
+			config.Network_Mainnet: "0x00000000",
+			config.Network_Prater:  "0x00001020",
+			config.Network_Devnet:  "",
+		},
+
 		legacyRewardsPoolAddress: map[config.Network]string{
 			config.Network_Mainnet: "0xA3a18348e6E2d3897B6f2671bb8c120e36554802",
 			config.Network_Prater:  "0xf9aE18eB0CE4930Bc3d7d1A5E33e4286d4FB0f8B",
@@ -431,12 +1242,61 @@ func (cfg *SmartnodeConfig) GetParameters() []*config.Parameter {
 		&cfg.Network,
 		&cfg.ProjectName,
 		&cfg.DataPath,
+		&cfg.PasswordPolicyEnabled,
+		&cfg.PasswordMinLength,
+		&cfg.PasswordMinCharacterClasses,
 		&cfg.ManualMaxFee,
 		&cfg.PriorityFee,
 		&cfg.MinipoolStakeGasThreshold,
 		&cfg.RewardsTreeMode,
 		&cfg.ArchiveECUrl,
 		&cfg.Web3StorageApiToken,
+		&cfg.UploadTreesToIpfs,
+		&cfg.IpfsApiUrl,
+		&cfg.RewardsTreeMirrorUrl,
+		&cfg.RewardsTreeFileMode,
+		&cfg.RewardsEcCallStrategy,
+		&cfg.RewardsTreeThreads,
+		&cfg.RewardsTreeIncremental,
+		&cfg.RewardsTreeGzip,
+		&cfg.RewardsTreeMinipoolDetail,
+		&cfg.RewardsTreeRetentionCount,
+		&cfg.RewardsTreeCrossCheckBeaconConfig,
+		&cfg.RewardsTreeMaxRequestsPerSecond,
+		&cfg.RewardsTreeRetryAdjacentBlocks,
+		&cfg.RewardsTreeRequestBatchSize,
+		&cfg.WatchtowerShutdownTimeout,
+		&cfg.ClientCallTimeout,
+		&cfg.WatchtowerMaxConcurrentTasks,
+		&cfg.WatchtowerGenerateRewardsTreeInterval,
+		&cfg.WatchtowerRespondChallengesInterval,
+		&cfg.WatchtowerSubmitRewardsTreeInterval,
+		&cfg.WatchtowerValidateNewIntervalsInterval,
+		&cfg.WatchtowerPruneRewardsTreesInterval,
+		&cfg.WatchtowerSubmitRplPriceInterval,
+		&cfg.WatchtowerSubmitNetworkBalancesInterval,
+		&cfg.WatchtowerSubmitWithdrawableMinipoolsInterval,
+		&cfg.WatchtowerDissolveTimedOutMinipoolsInterval,
+		&cfg.WatchtowerProcessWithdrawalsInterval,
+		&cfg.WatchtowerSubmitScrubMinipoolsInterval,
+		&cfg.WatchtowerJsonLogging,
+		&cfg.NotificationBackend,
+		&cfg.NotificationWebhookUrl,
+		&cfg.TelegramBotToken,
+		&cfg.TelegramChatID,
+		&cfg.SmtpServer,
+		&cfg.SmtpUsername,
+		&cfg.SmtpPassword,
+		&cfg.EmailFrom,
+		&cfg.EmailTo,
+		&cfg.NotificationThrottleWindow,
+		&cfg.ExportChallengeResponses,
+		&cfg.ChallengeWebhookUrl,
+		&cfg.DecideExpiredChallenges,
+		&cfg.ChallengeMaxBaseFee,
+		&cfg.ValidateNewIntervals,
+		&cfg.KeymanagerApiUrl,
+		&cfg.KeymanagerApiTokenPath,
 	}
 }
 
@@ -454,6 +1314,15 @@ func (cfg *SmartnodeConfig) GetChainID() uint {
 	return cfg.chainID[cfg.Network.Value.(config.Network)]
 }
 
+// Get the policy new node passwords have to meet
+func (cfg *SmartnodeConfig) GetPasswordPolicy() passwords.PasswordPolicy {
+	return passwords.PasswordPolicy{
+		Enabled:             cfg.PasswordPolicyEnabled.Value == true,
+		MinLength:           int(cfg.PasswordMinLength.Value.(uint64)),
+		MinCharacterClasses: int(cfg.PasswordMinCharacterClasses.Value.(uint64)),
+	}
+}
+
 func (cfg *SmartnodeConfig) GetWalletPath() string {
 	if cfg.parent.IsNativeMode {
 		return filepath.Join(cfg.DataPath.Value.(string), "wallet")
@@ -559,6 +1428,23 @@ func (cfg *SmartnodeConfig) GetRethAddress() common.Address {
 	return common.HexToAddress(cfg.rethAddress[cfg.Network.Value.(config.Network)])
 }
 
+func (cfg *SmartnodeConfig) GetMulticallAddress() common.Address {
+	return common.HexToAddress(cfg.multicallAddress[cfg.Network.Value.(config.Network)])
+}
+
+func (cfg *SmartnodeConfig) GetBeaconGenesisTime() uint64 {
+	return cfg.beaconGenesisTime[cfg.Network.Value.(config.Network)]
+}
+
+// Get the genesis fork version of the Beacon Chain, or nil if it isn't known ahead of time
+func (cfg *SmartnodeConfig) GetBeaconGenesisForkVersion() []byte {
+	forkVersion := cfg.beaconGenesisForkVersion[cfg.Network.Value.(config.Network)]
+	if forkVersion == "" {
+		return nil
+	}
+	return common.FromHex(forkVersion)
+}
+
 func getDefaultDataDir(config *RocketPoolConfig) string {
 	return filepath.Join(config.RocketPoolDirectory, "data")
 }
@@ -571,6 +1457,39 @@ func (cfg *SmartnodeConfig) GetRewardsTreePath(interval uint64, daemon bool) str
 	return filepath.Join(cfg.DataPath.Value.(string), RewardsTreesFolder, fmt.Sprintf(RewardsTreeFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
 }
 
+// Get the path of the gzipped copy of the rewards tree, which is saved instead of the JSON file when compression is enabled
+func (cfg *SmartnodeConfig) GetRewardsTreeGzipPath(interval uint64, daemon bool) string {
+	return cfg.GetRewardsTreePath(interval, daemon) + RewardsTreeGzipExtension
+}
+
+// Get the path of the rewards tree for an interval that's actually on disk: the JSON file if there is one, otherwise the
+// gzipped copy if there's that instead. Returns the JSON path if neither exists.
+func (cfg *SmartnodeConfig) GetExistingRewardsTreePath(interval uint64, daemon bool) string {
+	path := cfg.GetRewardsTreePath(interval, daemon)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		gzipPath := cfg.GetRewardsTreeGzipPath(interval, daemon)
+		if _, err := os.Stat(gzipPath); err == nil {
+			return gzipPath
+		}
+	}
+	return path
+}
+
+// Get the path of the compact binary copy of the rewards tree, which sits next to the JSON file
+func (cfg *SmartnodeConfig) GetRewardsTreeBinaryPath(interval uint64, daemon bool) string {
+	return strings.TrimSuffix(cfg.GetRewardsTreePath(interval, daemon), filepath.Ext(RewardsTreeFilenameFormat)) + RewardsTreeBinaryExtension
+}
+
+// Get the path of the human-readable summary of the rewards tree, which sits next to the JSON file
+func (cfg *SmartnodeConfig) GetRewardsTreeSummaryPath(interval uint64, daemon bool) string {
+	return strings.TrimSuffix(cfg.GetRewardsTreePath(interval, daemon), filepath.Ext(RewardsTreeFilenameFormat)) + RewardsTreeSummarySuffix
+}
+
+// Get the path of the withdrawal addresses of the nodes in the rewards tree, which sits next to the JSON file
+func (cfg *SmartnodeConfig) GetRewardsTreeWithdrawalAddressesPath(interval uint64, daemon bool) string {
+	return strings.TrimSuffix(cfg.GetRewardsTreePath(interval, daemon), filepath.Ext(RewardsTreeFilenameFormat)) + RewardsTreeWithdrawalsSuffix
+}
+
 func (cfg *SmartnodeConfig) GetMinipoolPerformancePath(interval uint64, daemon bool) string {
 	if daemon && !cfg.parent.IsNativeMode {
 		return filepath.Join(DaemonDataPath, RewardsTreesFolder, fmt.Sprintf(MinipoolPerformanceFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
@@ -579,6 +1498,14 @@ func (cfg *SmartnodeConfig) GetMinipoolPerformancePath(interval uint64, daemon b
 	return filepath.Join(cfg.DataPath.Value.(string), RewardsTreesFolder, fmt.Sprintf(MinipoolPerformanceFilenameFormat, string(cfg.Network.Value.(config.Network)), interval))
 }
 
+func (cfg *SmartnodeConfig) GetRewardsWatchFilePath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, RewardsTreesFolder, fmt.Sprintf(RewardsWatchFilenameFormat, string(cfg.Network.Value.(config.Network))))
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), RewardsTreesFolder, fmt.Sprintf(RewardsWatchFilenameFormat, string(cfg.Network.Value.(config.Network))))
+}
+
 func (cfg *SmartnodeConfig) GetRegenerateRewardsTreeRequestPath(interval uint64, daemon bool) string {
 	if daemon && !cfg.parent.IsNativeMode {
 		return filepath.Join(DaemonDataPath, WatchtowerFolder, fmt.Sprintf(RegenerateRewardsTreeRequestFormat, interval))
@@ -587,6 +1514,220 @@ func (cfg *SmartnodeConfig) GetRegenerateRewardsTreeRequestPath(interval uint64,
 	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder, fmt.Sprintf(RegenerateRewardsTreeRequestFormat, interval))
 }
 
+// Parse the intervals named by a rewards tree generation request, which is either a single interval (e.g. "5")
+// or an inclusive range of up to MaxRewardsTreeRequestIntervals of them (e.g. "5-9")
+func ParseRewardsTreeRequestIntervals(indexString string) ([]uint64, error) {
+	startString, endString, isRange := strings.Cut(indexString, "-")
+	start, err := strconv.ParseUint(startString, 0, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid interval [%s]: %w", startString, err)
+	}
+	if !isRange {
+		return []uint64{start}, nil
+	}
+	end, err := strconv.ParseUint(endString, 0, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid interval [%s]: %w", endString, err)
+	}
+	if end < start {
+		return nil, fmt.Errorf("the range %s ends before it starts", indexString)
+	}
+	if end == math.MaxUint64 {
+		return nil, fmt.Errorf("the range %s ends past the last possible interval", indexString)
+	}
+	if end-start >= MaxRewardsTreeRequestIntervals {
+		return nil, fmt.Errorf("the range %s covers %d intervals, but requests can only cover up to %d", indexString, end-start+1, MaxRewardsTreeRequestIntervals)
+	}
+	intervals := make([]uint64, 0, end-start+1)
+	for index := start; index < end+1; index++ {
+		intervals = append(intervals, index)
+	}
+	return intervals, nil
+}
+
+// The options a rewards tree generation request can carry in its contents
+type RewardsTreeRequest struct {
+	// Overrides the Smartnode's thread count setting if nonzero
+	Threads uint64
+
+	// Saves the generated files to this directory instead of the rewards trees folder if set
+	OutputDir string
+
+	// Rebuilds the Merkle proofs from the amounts in the existing tree file instead of recalculating the rewards
+	ProofsOnly bool
+
+	// Only calculates and reports this node's rewards, without building the Merkle tree or saving any files, if set
+	NodeAddress *common.Address
+
+	// Regenerates the tree even if the existing file already matches the canonical Merkle root
+	Force bool
+
+	// Generates the tree against this EL block instead of the one from the interval's snapshot event if nonzero
+	ElBlock uint64
+}
+
+// Serialize a rewards tree request as one "key=value" option per line
+func (r RewardsTreeRequest) Serialize() []byte {
+	builder := strings.Builder{}
+	if r.Threads > 0 {
+		builder.WriteString(fmt.Sprintf("threads=%d\n", r.Threads))
+	}
+	if r.OutputDir != "" {
+		builder.WriteString(fmt.Sprintf("outputDir=%s\n", r.OutputDir))
+	}
+	if r.ProofsOnly {
+		builder.WriteString("proofsOnly=true\n")
+	}
+	if r.NodeAddress != nil {
+		builder.WriteString(fmt.Sprintf("nodeAddress=%s\n", r.NodeAddress.Hex()))
+	}
+	if r.Force {
+		builder.WriteString("force=true\n")
+	}
+	if r.ElBlock > 0 {
+		builder.WriteString(fmt.Sprintf("elBlock=%d\n", r.ElBlock))
+	}
+	return []byte(builder.String())
+}
+
+// Parse the contents of a rewards tree request file. Requests that only contain a bare thread count are still supported.
+func ParseRewardsTreeRequest(contents []byte) (RewardsTreeRequest, error) {
+	request := RewardsTreeRequest{}
+	for _, line := range strings.Split(string(contents), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		key, value, hasKey := strings.Cut(line, "=")
+		if !hasKey {
+			key, value = "threads", line
+		}
+		switch key {
+		case "threads":
+			threads, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return RewardsTreeRequest{}, fmt.Errorf("invalid thread count [%s]: %w", value, err)
+			}
+			request.Threads = threads
+		case "outputDir":
+			request.OutputDir = value
+		case "proofsOnly":
+			proofsOnly, err := strconv.ParseBool(value)
+			if err != nil {
+				return RewardsTreeRequest{}, fmt.Errorf("invalid proofs-only setting [%s]: %w", value, err)
+			}
+			request.ProofsOnly = proofsOnly
+		case "nodeAddress":
+			if !common.IsHexAddress(value) {
+				return RewardsTreeRequest{}, fmt.Errorf("invalid node address [%s]", value)
+			}
+			address := common.HexToAddress(value)
+			request.NodeAddress = &address
+		case "force":
+			force, err := strconv.ParseBool(value)
+			if err != nil {
+				return RewardsTreeRequest{}, fmt.Errorf("invalid force setting [%s]: %w", value, err)
+			}
+			request.Force = force
+		case "elBlock":
+			elBlock, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return RewardsTreeRequest{}, fmt.Errorf("invalid EL block [%s]: %w", value, err)
+			}
+			request.ElBlock = elBlock
+		default:
+			return RewardsTreeRequest{}, fmt.Errorf("unknown request option [%s]", key)
+		}
+	}
+	return request, nil
+}
+
+// Convert a path on the host machine to the path the daemons see it at. In Docker mode, the path must be inside the
+// Smartnode data directory since that's the only part of the filesystem the daemons can access.
+func (cfg *SmartnodeConfig) GetDaemonPath(hostPath string) (string, error) {
+	hostPath, err := homedir.Expand(hostPath)
+	if err != nil {
+		return "", fmt.Errorf("error expanding path: %w", err)
+	}
+	hostPath, err = filepath.Abs(hostPath)
+	if err != nil {
+		return "", fmt.Errorf("error getting absolute path: %w", err)
+	}
+	if cfg.parent.IsNativeMode {
+		return hostPath, nil
+	}
+
+	dataPath, err := homedir.Expand(cfg.DataPath.Value.(string))
+	if err != nil {
+		return "", fmt.Errorf("error expanding data directory: %w", err)
+	}
+	relativePath, err := filepath.Rel(dataPath, hostPath)
+	if err != nil || relativePath == "." || strings.HasPrefix(relativePath, "..") {
+		return "", fmt.Errorf("%s must be inside your Smartnode data directory (%s)", hostPath, dataPath)
+	}
+	return filepath.Join(DaemonDataPath, relativePath), nil
+}
+
+func (cfg *SmartnodeConfig) GetVerifyRewardsTreeRequestPath(interval uint64, daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, WatchtowerFolder, fmt.Sprintf(VerifyRewardsTreeRequestFormat, interval))
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder, fmt.Sprintf(VerifyRewardsTreeRequestFormat, interval))
+}
+
+func (cfg *SmartnodeConfig) GetRegenerateRewardsTreeDryRunRequestPath(interval uint64, daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, WatchtowerFolder, fmt.Sprintf(RegenerateRewardsTreeDryRunFormat, interval))
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder, fmt.Sprintf(RegenerateRewardsTreeDryRunFormat, interval))
+}
+
+// Get the path of the file the watchtower exports unsigned challenge responses to
+func (cfg *SmartnodeConfig) GetUnsignedChallengeResponsePath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, WatchtowerFolder, UnsignedChallengeResponseFile)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder, UnsignedChallengeResponseFile)
+}
+
+func (cfg *SmartnodeConfig) GetRewardsTreeCheckpointPath(interval uint64, daemon bool) string {
+	return filepath.Join(cfg.GetWatchtowerFolder(daemon), fmt.Sprintf(RewardsTreeCheckpointFormat, interval))
+}
+
+// Get the path of the snapshot of the per-node details an interval's tree was generated with
+func (cfg *SmartnodeConfig) GetRewardsTreeNodeSnapshotPath(interval uint64, daemon bool) string {
+	return filepath.Join(cfg.GetWatchtowerFolder(daemon), fmt.Sprintf(RewardsTreeNodeSnapshotFormat, interval))
+}
+
+// Get the path of the lock file that marks an interval's tree as being generated
+func (cfg *SmartnodeConfig) GetRewardsTreeGeneratingPath(interval uint64, daemon bool) string {
+	return filepath.Join(cfg.GetWatchtowerFolder(daemon), fmt.Sprintf(RewardsTreeGeneratingFormat, interval))
+}
+
+// Get the permissions to use when saving rewards tree files
+func (cfg *SmartnodeConfig) GetRewardsTreeFileMode() (os.FileMode, error) {
+	modeString := cfg.RewardsTreeFileMode.Value.(string)
+	mode, err := strconv.ParseUint(modeString, 8, 32)
+	if err != nil {
+		return 0, fmt.Errorf("rewards tree file permissions [%s] are not a valid octal mode", modeString)
+	}
+
+	fileMode := os.FileMode(mode)
+	if fileMode&^0666 != 0 {
+		return 0, fmt.Errorf("rewards tree file permissions [%s] must not include executable or special bits", modeString)
+	}
+	if fileMode&0600 != 0600 {
+		return 0, fmt.Errorf("rewards tree file permissions [%s] must allow the owner to read and write the file", modeString)
+	}
+	if fileMode&0002 != 0 {
+		return 0, fmt.Errorf("rewards tree file permissions [%s] must not allow everyone to write to the file", modeString)
+	}
+	return fileMode, nil
+}
+
 func (cfg *SmartnodeConfig) GetWatchtowerFolder(daemon bool) string {
 	if daemon && !cfg.parent.IsNativeMode {
 		return filepath.Join(DaemonDataPath, WatchtowerFolder)
@@ -595,6 +1736,16 @@ func (cfg *SmartnodeConfig) GetWatchtowerFolder(daemon bool) string {
 	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder)
 }
 
+// Get the path of the file the watchtower tasks record their liveness in
+func (cfg *SmartnodeConfig) GetWatchtowerHeartbeatPath(daemon bool) string {
+	return filepath.Join(cfg.GetWatchtowerFolder(daemon), WatchtowerHeartbeatFile)
+}
+
+// Get the path of the file the watchtower publishes the status of manual rewards tree generation in
+func (cfg *SmartnodeConfig) GetRewardsTreeGenerationStatusPath(daemon bool) string {
+	return filepath.Join(cfg.GetWatchtowerFolder(daemon), RewardsTreeGenerationStatusFile)
+}
+
 func (cfg *SmartnodeConfig) GetFeeRecipientFilePath() string {
 	if !cfg.parent.IsNativeMode {
 		return filepath.Join(DaemonDataPath, "validators", FeeRecipientFilename)
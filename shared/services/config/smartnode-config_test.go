@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestParseRewardsTreeRequestIntervals(t *testing.T) {
+	maxEnd := MaxRewardsTreeRequestIntervals - 1
+	tests := []struct {
+		indexString string
+		expected    []uint64
+	}{
+		{"5", []uint64{5}},
+		{"5-5", []uint64{5}},
+		{"5-8", []uint64{5, 6, 7, 8}},
+		{fmt.Sprintf("%d", uint64(math.MaxUint64)), []uint64{math.MaxUint64}},
+	}
+	for _, test := range tests {
+		intervals, err := ParseRewardsTreeRequestIntervals(test.indexString)
+		if err != nil {
+			t.Fatalf("error parsing %s: %s", test.indexString, err.Error())
+		}
+		if fmt.Sprint(intervals) != fmt.Sprint(test.expected) {
+			t.Fatalf("parsing %s gave %v instead of %v", test.indexString, intervals, test.expected)
+		}
+	}
+
+	// The largest allowed range has to come back in full
+	intervals, err := ParseRewardsTreeRequestIntervals(fmt.Sprintf("0-%d", maxEnd))
+	if err != nil {
+		t.Fatalf("error parsing the largest allowed range: %s", err.Error())
+	}
+	if uint64(len(intervals)) != MaxRewardsTreeRequestIntervals || intervals[len(intervals)-1] != maxEnd {
+		t.Fatalf("the largest allowed range gave %d intervals, ending at %d", len(intervals), intervals[len(intervals)-1])
+	}
+
+	invalid := []string{
+		"",
+		"-",
+		"a",
+		"5-",
+		"-5",
+		"9-5",
+		fmt.Sprintf("0-%d", maxEnd+1),
+		fmt.Sprintf("0-%d", uint64(math.MaxUint64)),
+		fmt.Sprintf("%d-%d", uint64(math.MaxUint64)-1, uint64(math.MaxUint64)),
+		fmt.Sprintf("%d-%d", uint64(math.MaxUint64), uint64(math.MaxUint64)),
+		"1-18446744073709551616",
+	}
+	for _, indexString := range invalid {
+		intervals, err := ParseRewardsTreeRequestIntervals(indexString)
+		if err == nil {
+			t.Fatalf("parsing %s should have failed but gave %d intervals", indexString, len(intervals))
+		}
+	}
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
@@ -12,6 +13,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/fatih/color"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/types/api"
@@ -29,11 +31,15 @@ type ExecutionClientManager struct {
 	primaryReady    bool
 	fallbackReady   bool
 	ignoreSyncCheck bool
+	callTimeout     time.Duration
 }
 
 // This is a signature for a wrapped ethclient.Client function
 type ecFunction func(*ethclient.Client) (interface{}, error)
 
+// This is a signature for a wrapped ethclient.Client function that takes the context for a single client attempt
+type ecCallFunction func(context.Context, *ethclient.Client) (interface{}, error)
+
 // Creates a new ExecutionClientManager instance based on the Rocket Pool config
 func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientManager, error) {
 
@@ -85,6 +91,7 @@ func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientMa
 		logger:        log.NewColorLogger(color.FgYellow),
 		primaryReady:  true,
 		fallbackReady: fallbackEc != nil,
+		callTimeout:   time.Duration(cfg.Smartnode.ClientCallTimeout.Value.(uint64)) * time.Second,
 	}, nil
 
 }
@@ -96,7 +103,7 @@ func NewExecutionClientManager(cfg *config.RocketPoolConfig) (*ExecutionClientMa
 // CodeAt returns the code of the given account. This is needed to differentiate
 // between contract internal errors and the local chain being out of sync.
 func (p *ExecutionClientManager) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runCall(ctx, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.CodeAt(ctx, contract, blockNumber)
 	})
 	if err != nil {
@@ -108,7 +115,7 @@ func (p *ExecutionClientManager) CodeAt(ctx context.Context, contract common.Add
 // CallContract executes an Ethereum contract call with the specified data as the
 // input.
 func (p *ExecutionClientManager) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runCall(ctx, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.CallContract(ctx, call, blockNumber)
 	})
 	if err != nil {
@@ -123,7 +130,7 @@ func (p *ExecutionClientManager) CallContract(ctx context.Context, call ethereum
 
 // HeaderByHash returns the block header with the given hash.
 func (p *ExecutionClientManager) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runCall(ctx, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.HeaderByHash(ctx, hash)
 	})
 	if err != nil {
@@ -135,7 +142,7 @@ func (p *ExecutionClientManager) HeaderByHash(ctx context.Context, hash common.H
 // HeaderByNumber returns a block header from the current canonical chain. If number is
 // nil, the latest known header is returned.
 func (p *ExecutionClientManager) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runCall(ctx, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.HeaderByNumber(ctx, number)
 	})
 	if err != nil {
@@ -146,7 +153,7 @@ func (p *ExecutionClientManager) HeaderByNumber(ctx context.Context, number *big
 
 // PendingCodeAt returns the code of the given account in the pending state.
 func (p *ExecutionClientManager) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runCall(ctx, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.PendingCodeAt(ctx, account)
 	})
 	if err != nil {
@@ -157,7 +164,7 @@ func (p *ExecutionClientManager) PendingCodeAt(ctx context.Context, account comm
 
 // PendingNonceAt retrieves the current pending nonce associated with an account.
 func (p *ExecutionClientManager) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runCall(ctx, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.PendingNonceAt(ctx, account)
 	})
 	if err != nil {
@@ -169,7 +176,7 @@ func (p *ExecutionClientManager) PendingNonceAt(ctx context.Context, account com
 // SuggestGasPrice retrieves the currently suggested gas price to allow a timely
 // execution of a transaction.
 func (p *ExecutionClientManager) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runCall(ctx, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.SuggestGasPrice(ctx)
 	})
 	if err != nil {
@@ -181,7 +188,7 @@ func (p *ExecutionClientManager) SuggestGasPrice(ctx context.Context) (*big.Int,
 // SuggestGasTipCap retrieves the currently suggested 1559 priority fee to allow
 // a timely execution of a transaction.
 func (p *ExecutionClientManager) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runCall(ctx, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.SuggestGasTipCap(ctx)
 	})
 	if err != nil {
@@ -196,7 +203,7 @@ func (p *ExecutionClientManager) SuggestGasTipCap(ctx context.Context) (*big.Int
 // transactions may be added or removed by miners, but it should provide a basis
 // for setting a reasonable default.
 func (p *ExecutionClientManager) EstimateGas(ctx context.Context, call ethereum.CallMsg) (gas uint64, err error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runCall(ctx, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.EstimateGas(ctx, call)
 	})
 	if err != nil {
@@ -207,7 +214,7 @@ func (p *ExecutionClientManager) EstimateGas(ctx context.Context, call ethereum.
 
 // SendTransaction injects the transaction into the pending pool for execution.
 func (p *ExecutionClientManager) SendTransaction(ctx context.Context, tx *types.Transaction) error {
-	_, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	_, err := p.runCall(ctx, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return nil, client.SendTransaction(ctx, tx)
 	})
 	return err
@@ -222,7 +229,7 @@ func (p *ExecutionClientManager) SendTransaction(ctx context.Context, tx *types.
 //
 // TODO(karalabe): Deprecate when the subscription one can return past data too.
 func (p *ExecutionClientManager) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runCall(ctx, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.FilterLogs(ctx, query)
 	})
 	if err != nil {
@@ -250,7 +257,7 @@ func (p *ExecutionClientManager) SubscribeFilterLogs(ctx context.Context, query
 // TransactionReceipt returns the receipt of a transaction by transaction hash.
 // Note that the receipt is not available for pending transactions.
 func (p *ExecutionClientManager) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runCall(ctx, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.TransactionReceipt(ctx, txHash)
 	})
 	if err != nil {
@@ -265,7 +272,7 @@ func (p *ExecutionClientManager) TransactionReceipt(ctx context.Context, txHash
 
 // BlockNumber returns the most recent block number
 func (p *ExecutionClientManager) BlockNumber(ctx context.Context) (uint64, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runCall(ctx, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.BlockNumber(ctx)
 	})
 	if err != nil {
@@ -274,10 +281,21 @@ func (p *ExecutionClientManager) BlockNumber(ctx context.Context) (uint64, error
 	return result.(uint64), err
 }
 
+// ChainID retrieves the current chain ID for transaction replay protection.
+func (p *ExecutionClientManager) ChainID(ctx context.Context) (*big.Int, error) {
+	result, err := p.runCall(ctx, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
+		return client.ChainID(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*big.Int), err
+}
+
 // BalanceAt returns the wei balance of the given account.
 // The block number can be nil, in which case the balance is taken from the latest known block.
 func (p *ExecutionClientManager) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runCall(ctx, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.BalanceAt(ctx, account, blockNumber)
 	})
 	if err != nil {
@@ -288,7 +306,7 @@ func (p *ExecutionClientManager) BalanceAt(ctx context.Context, account common.A
 
 // TransactionByHash returns the transaction with the given hash.
 func (p *ExecutionClientManager) TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runCall(ctx, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		tx, isPending, err := client.TransactionByHash(ctx, hash)
 		result := []interface{}{tx, isPending}
 		return result, err
@@ -307,7 +325,7 @@ func (p *ExecutionClientManager) TransactionByHash(ctx context.Context, hash com
 // NonceAt returns the account nonce of the given account.
 // The block number can be nil, in which case the nonce is taken from the latest known block.
 func (p *ExecutionClientManager) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runCall(ctx, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.NonceAt(ctx, account, blockNumber)
 	})
 	if err != nil {
@@ -319,7 +337,7 @@ func (p *ExecutionClientManager) NonceAt(ctx context.Context, account common.Add
 // SyncProgress retrieves the current progress of the sync algorithm. If there's
 // no sync currently running, it returns nil.
 func (p *ExecutionClientManager) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
-	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+	result, err := p.runCall(ctx, func(ctx context.Context, client *ethclient.Client) (interface{}, error) {
 		return client.SyncProgress(ctx)
 	})
 	if err != nil {
@@ -328,6 +346,32 @@ func (p *ExecutionClientManager) SyncProgress(ctx context.Context) (*ethereum.Sy
 	return result.(*ethereum.SyncProgress), err
 }
 
+// ClientVersion returns the version string the active Execution client reports via web3_clientVersion.
+func (p *ExecutionClientManager) ClientVersion(ctx context.Context) (string, error) {
+	ctx, cancel := p.withCallTimeout(ctx)
+	defer cancel()
+	url := p.primaryEcUrl
+	if !p.primaryReady {
+		if !p.fallbackReady {
+			return "", fmt.Errorf("no Execution clients were ready")
+		}
+		url = p.fallbackEcUrl
+	}
+
+	client, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		return "", fmt.Errorf("error connecting to Execution client at [%s]: %w", url, err)
+	}
+	defer client.Close()
+
+	var version string
+	err = client.CallContext(ctx, &version, "web3_clientVersion")
+	if err != nil {
+		return "", fmt.Errorf("error getting Execution client version: %w", err)
+	}
+	return version, nil
+}
+
 /// ==================
 /// Internal functions
 /// ==================
@@ -455,6 +499,27 @@ func checkEcStatus(client *ethclient.Client) api.ClientStatus {
 
 }
 
+// Apply the call timeout to a context that doesn't already have a deadline, so a hung client can't block the caller forever
+func (p *ExecutionClientManager) withCallTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if _, hasDeadline := ctx.Deadline(); hasDeadline || p.callTimeout == 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, p.callTimeout)
+}
+
+// Runs a call through runFunction, giving each client attempt its own call timeout so a primary that hangs doesn't use up
+// the fallback's time too
+func (p *ExecutionClientManager) runCall(ctx context.Context, function ecCallFunction) (interface{}, error) {
+	return p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		callCtx, cancel := p.withCallTimeout(ctx)
+		defer cancel()
+		return function(callCtx, client)
+	})
+}
+
 // Attempts to run a function progressively through each client until one succeeds or they all fail.
 func (p *ExecutionClientManager) runFunction(function ecFunction) (interface{}, error) {
 
@@ -471,6 +536,9 @@ func (p *ExecutionClientManager) runFunction(function ecFunction) (interface{},
 			}
 
 			// If it's a different error, just return it
+			if errors.Is(err, context.DeadlineExceeded) {
+				return nil, fmt.Errorf("Execution client call timed out: %w", err)
+			}
 			return nil, err
 		}
 
@@ -490,6 +558,9 @@ func (p *ExecutionClientManager) runFunction(function ecFunction) (interface{},
 			}
 
 			// If it's a different error, just return it
+			if errors.Is(err, context.DeadlineExceeded) {
+				return nil, fmt.Errorf("Execution client call timed out: %w", err)
+			}
 			return nil, err
 		}
 
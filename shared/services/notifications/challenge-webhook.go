@@ -0,0 +1,21 @@
+package notifications
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// The details of a challenge the watchtower handled, sent to the challenge webhook
+type ChallengeReport struct {
+	NodeAddress  common.Address `json:"nodeAddress"`
+	DetectedTime time.Time      `json:"detectedTime"`
+	TxHash       *common.Hash   `json:"txHash,omitempty"`
+	Success      bool           `json:"success"`
+	Error        string         `json:"error,omitempty"`
+}
+
+// POST a challenge report to the provided webhook URL
+func PostChallengeReport(url string, report ChallengeReport) error {
+	return postJson(url, report)
+}
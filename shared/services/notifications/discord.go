@@ -0,0 +1,53 @@
+package notifications
+
+import "time"
+
+const (
+	discordColorSuccess int = 0x2ecc71
+	discordColorFailure int = 0xe74c3c
+	discordColorWarning int = 0xf1c40f
+)
+
+// Notifier that posts events as embeds to a Discord webhook
+type discordNotifier struct {
+	url string
+}
+
+type discordEmbed struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Color       int    `json:"color"`
+	Timestamp   string `json:"timestamp"`
+}
+
+type discordMessage struct {
+	Username string         `json:"username"`
+	Embeds   []discordEmbed `json:"embeds"`
+}
+
+func newDiscordNotifier(url string) *discordNotifier {
+	return &discordNotifier{
+		url: url,
+	}
+}
+
+func (n *discordNotifier) Notify(event Event) error {
+	color := discordColorWarning
+	switch event.Type {
+	case EventType_TreeGenerated:
+		color = discordColorSuccess
+	case EventType_TreeGenerationFailed, EventType_TreeRootMismatch:
+		color = discordColorFailure
+	}
+
+	message := discordMessage{
+		Username: "Rocket Pool Smartnode",
+		Embeds: []discordEmbed{{
+			Title:       "[" + event.Network + "] " + event.Title,
+			Description: event.Message,
+			Color:       color,
+			Timestamp:   event.Time.Format(time.RFC3339),
+		}},
+	}
+	return postJson(n.url, message)
+}
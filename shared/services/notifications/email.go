@@ -0,0 +1,100 @@
+package notifications
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Notifier that sends events as emails through an SMTP server
+type emailNotifier struct {
+	server   string
+	username string
+	password string
+	from     string
+	to       []string
+}
+
+func newEmailNotifier(server string, username string, password string, from string, to string) *emailNotifier {
+	recipients := []string{}
+	for _, recipient := range strings.Split(to, ",") {
+		recipient = strings.TrimSpace(recipient)
+		if recipient != "" {
+			recipients = append(recipients, recipient)
+		}
+	}
+	return &emailNotifier{
+		server:   server,
+		username: username,
+		password: password,
+		from:     from,
+		to:       recipients,
+	}
+}
+
+func (n *emailNotifier) Notify(event Event) error {
+
+	host, _, err := net.SplitHostPort(n.server)
+	if err != nil {
+		return fmt.Errorf("invalid SMTP server [%s]: %w", n.server, err)
+	}
+
+	// Connect with a deadline so a hung server can't block the caller
+	conn, err := net.DialTimeout("tcp", n.server, notificationTimeout)
+	if err != nil {
+		return fmt.Errorf("error connecting to SMTP server: %w", err)
+	}
+	conn.SetDeadline(time.Now().Add(notificationTimeout))
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("error creating SMTP client: %w", err)
+	}
+	defer client.Close()
+
+	// Upgrade to TLS and log in if possible
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		err = client.StartTLS(&tls.Config{ServerName: host})
+		if err != nil {
+			return fmt.Errorf("error starting TLS: %w", err)
+		}
+	}
+	if n.username != "" {
+		err = client.Auth(smtp.PlainAuth("", n.username, n.password, host))
+		if err != nil {
+			return fmt.Errorf("error authenticating with SMTP server: %w", err)
+		}
+	}
+
+	// Send the message
+	err = client.Mail(n.from)
+	if err != nil {
+		return fmt.Errorf("error setting sender: %w", err)
+	}
+	for _, recipient := range n.to {
+		err = client.Rcpt(recipient)
+		if err != nil {
+			return fmt.Errorf("error adding recipient %s: %w", recipient, err)
+		}
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("error starting message: %w", err)
+	}
+	message := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: [%s] %s\r\nDate: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n",
+		n.from, strings.Join(n.to, ", "), event.Network, event.Title, event.Time.Format(time.RFC1123Z), event.Message)
+	_, err = writer.Write([]byte(message))
+	if err != nil {
+		return fmt.Errorf("error writing message: %w", err)
+	}
+	err = writer.Close()
+	if err != nil {
+		return fmt.Errorf("error sending message: %w", err)
+	}
+
+	return client.Quit()
+
+}
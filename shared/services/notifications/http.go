@@ -0,0 +1,39 @@
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// POST a JSON body to the provided URL, failing if the server doesn't respond with a 2xx code
+func postJson(url string, body interface{}) error {
+
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("error serializing notification: %w", err)
+	}
+
+	client := http.Client{
+		Timeout: notificationTimeout,
+	}
+	response, err := client.Post(url, "application/json", bytes.NewReader(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("error sending notification: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		responseBody, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("notification was rejected with status %s: %s", response.Status, string(responseBody))
+	}
+	return nil
+
+}
+
+// Format an event as a short plain-text message
+func formatPlainText(event Event) string {
+	return fmt.Sprintf("[%s] %s\n\n%s", event.Network, event.Title, event.Message)
+}
@@ -0,0 +1,125 @@
+package notifications
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The maximum time a backend is allowed to spend delivering a single notification
+const notificationTimeout time.Duration = 15 * time.Second
+
+// The kind of event that triggered a notification
+type EventType string
+
+const (
+	EventType_TreeGenerated        EventType = "treeGenerated"
+	EventType_TreeGenerationFailed EventType = "treeGenerationFailed"
+	EventType_ChallengeDetected    EventType = "challengeDetected"
+	EventType_TreeRootMismatch     EventType = "treeRootMismatch"
+)
+
+// The common payload shared by every notification backend
+type Event struct {
+	Type     EventType `json:"type"`
+	Title    string    `json:"title"`
+	Message  string    `json:"message"`
+	Network  string    `json:"network"`
+	Interval *uint64   `json:"interval,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// A backend that can deliver notifications
+type Notifier interface {
+	Notify(event Event) error
+}
+
+// Create a new event for the provided network
+func NewEvent(cfg *config.RocketPoolConfig, eventType EventType, title string, message string) Event {
+	return Event{
+		Type:    eventType,
+		Title:   title,
+		Message: message,
+		Network: string(cfg.Smartnode.Network.Value.(cfgtypes.Network)),
+		Time:    time.Now().UTC(),
+	}
+}
+
+// Create a new event about a specific rewards interval
+func NewIntervalEvent(cfg *config.RocketPoolConfig, eventType EventType, interval uint64, title string, message string) Event {
+	event := NewEvent(cfg, eventType, title, message)
+	event.Interval = &interval
+	return event
+}
+
+// Create the notifier selected in the Smartnode config, throttled according to the configured window
+func NewNotifier(cfg *config.RocketPoolConfig) (Notifier, error) {
+	notifier, err := newBackendNotifier(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	window := time.Duration(cfg.Smartnode.NotificationThrottleWindow.Value.(uint64)) * time.Minute
+	if window == 0 {
+		return notifier, nil
+	}
+	return newThrottlingNotifier(notifier, window), nil
+}
+
+// Create the notifier for the backend selected in the Smartnode config
+func newBackendNotifier(cfg *config.RocketPoolConfig) (Notifier, error) {
+
+	backend := cfg.Smartnode.NotificationBackend.Value.(cfgtypes.NotificationBackend)
+	switch backend {
+	case cfgtypes.NotificationBackend_None, "":
+		return NewNoopNotifier(), nil
+
+	case cfgtypes.NotificationBackend_Webhook:
+		url := cfg.Smartnode.NotificationWebhookUrl.Value.(string)
+		if url == "" {
+			return nil, fmt.Errorf("the webhook notification service requires a webhook URL")
+		}
+		return newWebhookNotifier(url), nil
+
+	case cfgtypes.NotificationBackend_Discord:
+		url := cfg.Smartnode.NotificationWebhookUrl.Value.(string)
+		if url == "" {
+			return nil, fmt.Errorf("the Discord notification service requires a webhook URL")
+		}
+		return newDiscordNotifier(url), nil
+
+	case cfgtypes.NotificationBackend_Telegram:
+		token := cfg.Smartnode.TelegramBotToken.Value.(string)
+		chatID := cfg.Smartnode.TelegramChatID.Value.(string)
+		if token == "" || chatID == "" {
+			return nil, fmt.Errorf("the Telegram notification service requires a bot token and a chat ID")
+		}
+		return newTelegramNotifier(token, chatID), nil
+
+	case cfgtypes.NotificationBackend_Email:
+		server := cfg.Smartnode.SmtpServer.Value.(string)
+		from := cfg.Smartnode.EmailFrom.Value.(string)
+		to := cfg.Smartnode.EmailTo.Value.(string)
+		if server == "" || from == "" || to == "" {
+			return nil, fmt.Errorf("the email notification service requires an SMTP server, a sender, and at least one recipient")
+		}
+		return newEmailNotifier(server, cfg.Smartnode.SmtpUsername.Value.(string), cfg.Smartnode.SmtpPassword.Value.(string), from, to), nil
+
+	default:
+		return nil, fmt.Errorf("unknown notification service [%s]", backend)
+	}
+
+}
+
+// Notifier used when notifications are disabled
+type noopNotifier struct{}
+
+func NewNoopNotifier() Notifier {
+	return &noopNotifier{}
+}
+
+func (n *noopNotifier) Notify(event Event) error {
+	return nil
+}
@@ -0,0 +1,31 @@
+package notifications
+
+import "fmt"
+
+const telegramSendMessageUrl string = "https://api.telegram.org/bot%s/sendMessage"
+
+// Notifier that sends events to a Telegram chat through a bot
+type telegramNotifier struct {
+	token  string
+	chatID string
+}
+
+type telegramMessage struct {
+	ChatID string `json:"chat_id"`
+	Text   string `json:"text"`
+}
+
+func newTelegramNotifier(token string, chatID string) *telegramNotifier {
+	return &telegramNotifier{
+		token:  token,
+		chatID: chatID,
+	}
+}
+
+func (n *telegramNotifier) Notify(event Event) error {
+	message := telegramMessage{
+		ChatID: n.chatID,
+		Text:   formatPlainText(event),
+	}
+	return postJson(fmt.Sprintf(telegramSendMessageUrl, n.token), message)
+}
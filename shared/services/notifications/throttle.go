@@ -0,0 +1,91 @@
+package notifications
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Notifier that suppresses repeats of the same kind of event within a window, sending a summary of what was
+// suppressed once the window ends so operators aren't flooded during a prolonged incident
+type throttlingNotifier struct {
+	notifier Notifier
+	window   time.Duration
+	lock     *sync.Mutex
+	windows  map[string]*throttleWindow
+}
+
+// The kinds of events that can repeat during an incident; anything else is always sent
+var throttledEventTypes = map[EventType]bool{
+	EventType_TreeGenerationFailed: true,
+	EventType_ChallengeDetected:    true,
+}
+
+// The state of a single kind of event's current window
+type throttleWindow struct {
+	start      time.Time
+	suppressed int
+	last       Event
+}
+
+func newThrottlingNotifier(notifier Notifier, window time.Duration) *throttlingNotifier {
+	return &throttlingNotifier{
+		notifier: notifier,
+		window:   window,
+		lock:     &sync.Mutex{},
+		windows:  map[string]*throttleWindow{},
+	}
+}
+
+func (n *throttlingNotifier) Notify(event Event) error {
+	if !throttledEventTypes[event.Type] {
+		return n.notifier.Notify(event)
+	}
+	key := getThrottleKey(event)
+
+	n.lock.Lock()
+	window, exists := n.windows[key]
+	if exists {
+		// Suppress it until the window ends
+		window.suppressed++
+		window.last = event
+		n.lock.Unlock()
+		return nil
+	}
+	n.windows[key] = &throttleWindow{
+		start: time.Now(),
+	}
+	n.lock.Unlock()
+
+	// Send the summary once the window ends
+	time.AfterFunc(n.window, func() {
+		n.sendSummary(key)
+	})
+	return n.notifier.Notify(event)
+}
+
+// Close the window for a kind of event, and send a summary of anything that was suppressed during it.
+// There's nobody to report a failure to at this point, so a summary that can't be delivered is dropped.
+func (n *throttlingNotifier) sendSummary(key string) {
+	n.lock.Lock()
+	window := n.windows[key]
+	delete(n.windows, key)
+	n.lock.Unlock()
+
+	if window == nil || window.suppressed == 0 {
+		return
+	}
+	summary := window.last
+	summary.Title = fmt.Sprintf("%s (repeated %d more times)", window.last.Title, window.suppressed)
+	summary.Message = fmt.Sprintf("%d more notifications like this were suppressed since %s. The most recent one was:\n\n%s", window.suppressed, window.start.UTC().Format(time.RFC1123), window.last.Message)
+	summary.Time = time.Now().UTC()
+	n.notifier.Notify(summary)
+}
+
+// Events of the same type about the same interval are considered identical
+func getThrottleKey(event Event) string {
+	if event.Interval == nil {
+		return string(event.Type)
+	}
+	return fmt.Sprintf("%s-%d", event.Type, *event.Interval)
+}
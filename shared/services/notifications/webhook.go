@@ -0,0 +1,16 @@
+package notifications
+
+// Notifier that POSTs the raw event payload to a generic webhook
+type webhookNotifier struct {
+	url string
+}
+
+func newWebhookNotifier(url string) *webhookNotifier {
+	return &webhookNotifier{
+		url: url,
+	}
+}
+
+func (n *webhookNotifier) Notify(event Event) error {
+	return postJson(n.url, event)
+}
@@ -16,12 +16,14 @@ const (
 // Password manager
 type PasswordManager struct {
 	passwordPath string
+	policy       PasswordPolicy
 }
 
 // Create new password manager
-func NewPasswordManager(passwordPath string) *PasswordManager {
+func NewPasswordManager(passwordPath string, policy PasswordPolicy) *PasswordManager {
 	return &PasswordManager{
 		passwordPath: passwordPath,
+		policy:       policy,
 	}
 }
 
@@ -58,6 +60,11 @@ func (pm *PasswordManager) SetPassword(password string) error {
 		return fmt.Errorf("Password must be at least %d characters long", MinPasswordLength)
 	}
 
+	// Check password policy
+	if err := pm.policy.Check(password); err != nil {
+		return err
+	}
+
 	// Write to disk
 	if err := ioutil.WriteFile(pm.passwordPath, []byte(password), FileMode); err != nil {
 		return fmt.Errorf("Could not write password to disk: %w", err)
@@ -0,0 +1,64 @@
+package passwords
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Requirements a new node password has to meet, on top of the minimum length
+type PasswordPolicy struct {
+	// Whether the policy is enforced at all; only the minimum length is checked if it isn't
+	Enabled bool
+
+	// The minimum number of characters
+	MinLength int
+
+	// The minimum number of character classes (lowercase letters, uppercase letters, numbers, and symbols) to use
+	MinCharacterClasses int
+}
+
+// Check a password against the policy, listing every requirement it doesn't meet
+func (p PasswordPolicy) Check(password string) error {
+	if !p.Enabled {
+		return nil
+	}
+
+	unmet := []string{}
+	length := len([]rune(password))
+	if length < p.MinLength {
+		unmet = append(unmet, fmt.Sprintf("it must be at least %d characters long (it is %d)", p.MinLength, length))
+	}
+	classes := countCharacterClasses(password)
+	if classes < p.MinCharacterClasses {
+		unmet = append(unmet, fmt.Sprintf("it must use at least %d of lowercase letters, uppercase letters, numbers, and symbols (it uses %d)", p.MinCharacterClasses, classes))
+	}
+	if len(unmet) > 0 {
+		return fmt.Errorf("Password doesn't meet the password policy: %s", strings.Join(unmet, "; "))
+	}
+	return nil
+}
+
+// Count how many character classes a password uses
+func countCharacterClasses(password string) int {
+	var hasLower, hasUpper, hasDigit, hasSymbol bool
+	for _, char := range password {
+		switch {
+		case unicode.IsLower(char):
+			hasLower = true
+		case unicode.IsUpper(char):
+			hasUpper = true
+		case unicode.IsDigit(char):
+			hasDigit = true
+		default:
+			hasSymbol = true
+		}
+	}
+	classes := 0
+	for _, hasClass := range []bool{hasLower, hasUpper, hasDigit, hasSymbol} {
+		if hasClass {
+			classes++
+		}
+	}
+	return classes
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -32,6 +33,10 @@ var ethClientStatusRefreshInterval, _ = time.ParseDuration("60s")
 // Service requirements
 //
 
+func RequireClientNetworksMatch(c *cli.Context) error {
+	return checkClientNetworks(c)
+}
+
 func RequireNodePassword(c *cli.Context) error {
 	nodePasswordSet, err := getNodePasswordSet(c)
 	if err != nil {
@@ -356,6 +361,44 @@ func getNodeTrusted(c *cli.Context) (bool, error) {
 // timeout of 0 indicates no timeout
 var ethClientSyncLock sync.Mutex
 
+// Make sure the Execution and Beacon clients are on the network the Smartnode is configured for
+func checkClientNetworks(c *cli.Context) error {
+	cfg, err := GetConfig(c)
+	if err != nil {
+		return err
+	}
+	ec, err := GetEthClient(c)
+	if err != nil {
+		return err
+	}
+	bc, err := GetBeaconClient(c)
+	if err != nil {
+		return err
+	}
+	network := cfg.Smartnode.Network.Value
+
+	// Check the EC's chain ID
+	chainID, err := ec.ChainID(context.Background())
+	if err != nil {
+		return fmt.Errorf("Error getting the Execution client's chain ID: %w", err)
+	}
+	expectedChainID := cfg.Smartnode.GetChainID()
+	if chainID.Uint64() != uint64(expectedChainID) {
+		return fmt.Errorf("Your Execution client is on the chain with ID %d, but your Smartnode is configured for the %s network (chain ID %d). Please check that your Execution client is set up for the right network.", chainID.Uint64(), network, expectedChainID)
+	}
+
+	// Check the BC's genesis fork version
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return fmt.Errorf("Error getting the Beacon client's config: %w", err)
+	}
+	expectedForkVersion := cfg.Smartnode.GetBeaconGenesisForkVersion()
+	if expectedForkVersion != nil && !bytes.Equal(eth2Config.GenesisForkVersion, expectedForkVersion) {
+		return fmt.Errorf("Your Beacon client has a genesis fork version of 0x%x, but the %s network's is 0x%x. Please check that your Beacon client is set up for the right network.", eth2Config.GenesisForkVersion, network, expectedForkVersion)
+	}
+	return nil
+}
+
 func checkExecutionClientStatus(ecMgr *ExecutionClientManager, cfg *config.RocketPoolConfig) (bool, rocketpool.ExecutionClient, error) {
 
 	// Check the EC status
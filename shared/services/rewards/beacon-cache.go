@@ -0,0 +1,132 @@
+package rewards
+
+import (
+	"sync"
+
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// The default number of slots whose validator statuses are kept in a Beacon cache
+const DefaultBeaconCacheSlots int = 4
+
+// The validator statuses retrieved for a single slot, including the pubkeys that didn't have a validator
+type cachedValidatorStatuses struct {
+	statuses map[rptypes.ValidatorPubkey]beacon.ValidatorStatus
+	missing  map[rptypes.ValidatorPubkey]bool
+}
+
+// Memoizes Beacon client queries so several rewards trees can be generated back-to-back without re-requesting
+// the same data. Validator statuses are keyed by slot, and only the most recently used slots are kept.
+// It's safe for concurrent use.
+type BeaconCache struct {
+	bc        beacon.Client
+	maxSlots  int
+	lock      sync.Mutex
+	config    *beacon.Eth2Config
+	slots     map[uint64]*cachedValidatorStatuses
+	slotOrder []uint64
+}
+
+// Create a new Beacon cache that holds the validator statuses for up to maxSlots slots
+func NewBeaconCache(bc beacon.Client, maxSlots int) *BeaconCache {
+	if maxSlots < 1 {
+		maxSlots = DefaultBeaconCacheSlots
+	}
+	return &BeaconCache{
+		bc:       bc,
+		maxSlots: maxSlots,
+		slots:    map[uint64]*cachedValidatorStatuses{},
+	}
+}
+
+// Get the Beacon chain config
+func (c *BeaconCache) GetEth2Config() (beacon.Eth2Config, error) {
+	c.lock.Lock()
+	cachedConfig := c.config
+	c.lock.Unlock()
+	if cachedConfig != nil {
+		return *cachedConfig, nil
+	}
+
+	// Don't hold the lock during the request; concurrent misses just fetch the same config
+	config, err := c.bc.GetEth2Config()
+	if err != nil {
+		return beacon.Eth2Config{}, err
+	}
+	c.lock.Lock()
+	c.config = &config
+	c.lock.Unlock()
+	return config, nil
+}
+
+// Get the statuses of the provided validators at a slot, only querying the Beacon client for ones that aren't cached
+func (c *BeaconCache) GetValidatorStatuses(pubkeys []rptypes.ValidatorPubkey, slot uint64) (map[rptypes.ValidatorPubkey]beacon.ValidatorStatus, error) {
+	// Copy out the cached statuses so callers can't modify the cache
+	statuses := make(map[rptypes.ValidatorPubkey]beacon.ValidatorStatus, len(pubkeys))
+	uncached := []rptypes.ValidatorPubkey{}
+	c.lock.Lock()
+	cached := c.getSlot(slot)
+	for _, pubkey := range pubkeys {
+		status, exists := cached.statuses[pubkey]
+		if exists {
+			statuses[pubkey] = status
+		} else if !cached.missing[pubkey] {
+			uncached = append(uncached, pubkey)
+		}
+	}
+	c.lock.Unlock()
+	if len(uncached) == 0 {
+		return statuses, nil
+	}
+
+	// Don't hold the lock during the request so other slots and cached lookups aren't blocked behind it
+	fetched, err := c.bc.GetValidatorStatuses(uncached, &beacon.ValidatorStatusOptions{
+		Slot: &slot,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The slot may have been evicted while the request was running, so look it up again before storing the results
+	c.lock.Lock()
+	cached = c.getSlot(slot)
+	for _, pubkey := range uncached {
+		status, exists := fetched[pubkey]
+		if exists {
+			cached.statuses[pubkey] = status
+			statuses[pubkey] = status
+		} else {
+			cached.missing[pubkey] = true
+		}
+	}
+	c.lock.Unlock()
+	return statuses, nil
+}
+
+// Get the cached statuses for a slot, creating them and evicting the least recently used slot if necessary
+func (c *BeaconCache) getSlot(slot uint64) *cachedValidatorStatuses {
+	cached, exists := c.slots[slot]
+	if exists {
+		for i, cachedSlot := range c.slotOrder {
+			if cachedSlot == slot {
+				c.slotOrder = append(c.slotOrder[:i], c.slotOrder[i+1:]...)
+				break
+			}
+		}
+		c.slotOrder = append(c.slotOrder, slot)
+		return cached
+	}
+
+	if len(c.slotOrder) >= c.maxSlots {
+		delete(c.slots, c.slotOrder[0])
+		c.slotOrder = c.slotOrder[1:]
+	}
+	cached = &cachedValidatorStatuses{
+		statuses: map[rptypes.ValidatorPubkey]beacon.ValidatorStatus{},
+		missing:  map[rptypes.ValidatorPubkey]bool{},
+	}
+	c.slots[slot] = cached
+	c.slotOrder = append(c.slotOrder, slot)
+	return cached
+}
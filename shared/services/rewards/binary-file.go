@@ -0,0 +1,171 @@
+package rewards
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// The version of the binary rewards file layout, bumped whenever binaryRewardsFile changes
+const binaryRewardsFileVersion uint64 = 1
+
+// The serialized subset of a rewards file in the binary format. The Merkle proofs make up most of a JSON tree file, but
+// they can be rebuilt from the amounts, so they're left out and regenerated when the file is loaded.
+type binaryRewardsFile struct {
+	BinaryVersion              uint64
+	RewardsFileVersion         uint64
+	RulesetVersion             uint64
+	Index                      uint64
+	Network                    string
+	StartTime                  time.Time
+	EndTime                    time.Time
+	ConsensusStartBlock        uint64
+	ConsensusEndBlock          uint64
+	ExecutionStartBlock        uint64
+	ExecutionEndBlock          uint64
+	IntervalsPassed            uint64
+	MerkleRoot                 string
+	MinipoolPerformanceFileCID string
+	TotalRewards               *TotalRewards
+	NetworkRewards             map[uint64]*NetworkRewardsInfo
+	NodeRewards                map[common.Address]*binaryNodeRewards
+	MinipoolRewards            map[common.Address]map[common.Address]*QuotedBigInt
+}
+
+// A node's rewards in the binary format, without its Merkle data or proof
+type binaryNodeRewards struct {
+	RewardNetwork                uint64
+	CollateralRpl                *QuotedBigInt
+	OracleDaoRpl                 *QuotedBigInt
+	SmoothingPoolEth             *QuotedBigInt
+	SmoothingPoolEligibilityRate float64
+	WithdrawalAddress            *common.Address
+}
+
+// Serialize a rewards file into the compact binary format
+func SerializeRewardsFileBinary(rewardsFile *RewardsFile) ([]byte, error) {
+	file := binaryRewardsFile{
+		BinaryVersion:              binaryRewardsFileVersion,
+		RewardsFileVersion:         rewardsFile.RewardsFileVersion,
+		RulesetVersion:             rewardsFile.RulesetVersion,
+		Index:                      rewardsFile.Index,
+		Network:                    rewardsFile.Network,
+		StartTime:                  rewardsFile.StartTime,
+		EndTime:                    rewardsFile.EndTime,
+		ConsensusStartBlock:        rewardsFile.ConsensusStartBlock,
+		ConsensusEndBlock:          rewardsFile.ConsensusEndBlock,
+		ExecutionStartBlock:        rewardsFile.ExecutionStartBlock,
+		ExecutionEndBlock:          rewardsFile.ExecutionEndBlock,
+		IntervalsPassed:            rewardsFile.IntervalsPassed,
+		MerkleRoot:                 rewardsFile.MerkleRoot,
+		MinipoolPerformanceFileCID: rewardsFile.MinipoolPerformanceFileCID,
+		TotalRewards:               rewardsFile.TotalRewards,
+		NetworkRewards:             rewardsFile.NetworkRewards,
+		NodeRewards:                make(map[common.Address]*binaryNodeRewards, len(rewardsFile.NodeRewards)),
+		MinipoolRewards:            rewardsFile.MinipoolRewards,
+	}
+	for address, nodeRewards := range rewardsFile.NodeRewards {
+		file.NodeRewards[address] = &binaryNodeRewards{
+			RewardNetwork:                nodeRewards.RewardNetwork,
+			CollateralRpl:                nodeRewards.CollateralRpl,
+			OracleDaoRpl:                 nodeRewards.OracleDaoRpl,
+			SmoothingPoolEth:             nodeRewards.SmoothingPoolEth,
+			SmoothingPoolEligibilityRate: nodeRewards.SmoothingPoolEligibilityRate,
+			WithdrawalAddress:            nodeRewards.WithdrawalAddress,
+		}
+	}
+
+	var buffer bytes.Buffer
+	err := gob.NewEncoder(&buffer).Encode(&file)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding binary rewards file: %w", err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// Deserialize a rewards file from the compact binary format, rebuilding its Merkle tree and proofs. Fails if the rebuilt
+// root doesn't match the one the file was saved with.
+func DeserializeRewardsFileBinary(fileBytes []byte) (*RewardsFile, error) {
+	var file binaryRewardsFile
+	err := gob.NewDecoder(bytes.NewReader(fileBytes)).Decode(&file)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding binary rewards file: %w", err)
+	}
+	if file.BinaryVersion != binaryRewardsFileVersion {
+		return nil, fmt.Errorf("binary rewards file has version %d, but only version %d is supported", file.BinaryVersion, binaryRewardsFileVersion)
+	}
+
+	rewardsFile := &RewardsFile{
+		RewardsFileVersion:         file.RewardsFileVersion,
+		RulesetVersion:             file.RulesetVersion,
+		Index:                      file.Index,
+		Network:                    file.Network,
+		StartTime:                  file.StartTime,
+		EndTime:                    file.EndTime,
+		ConsensusStartBlock:        file.ConsensusStartBlock,
+		ConsensusEndBlock:          file.ConsensusEndBlock,
+		ExecutionStartBlock:        file.ExecutionStartBlock,
+		ExecutionEndBlock:          file.ExecutionEndBlock,
+		IntervalsPassed:            file.IntervalsPassed,
+		MinipoolPerformanceFileCID: file.MinipoolPerformanceFileCID,
+		TotalRewards:               file.TotalRewards,
+		NetworkRewards:             file.NetworkRewards,
+		NodeRewards:                make(map[common.Address]*NodeRewardsInfo, len(file.NodeRewards)),
+		MinipoolRewards:            file.MinipoolRewards,
+	}
+	if rewardsFile.NetworkRewards == nil {
+		rewardsFile.NetworkRewards = map[uint64]*NetworkRewardsInfo{}
+	}
+	for address, nodeRewards := range file.NodeRewards {
+		rewardsFile.NodeRewards[address] = &NodeRewardsInfo{
+			RewardNetwork:                nodeRewards.RewardNetwork,
+			CollateralRpl:                nodeRewards.CollateralRpl,
+			OracleDaoRpl:                 nodeRewards.OracleDaoRpl,
+			SmoothingPoolEth:             nodeRewards.SmoothingPoolEth,
+			SmoothingPoolEligibilityRate: nodeRewards.SmoothingPoolEligibilityRate,
+			WithdrawalAddress:            nodeRewards.WithdrawalAddress,
+		}
+	}
+
+	// Rebuild the proofs and make sure they produce the same tree
+	err = RebuildMerkleProofs(rewardsFile)
+	if err != nil {
+		return nil, fmt.Errorf("error rebuilding the Merkle proofs of the binary rewards file: %w", err)
+	}
+	if rewardsFile.MerkleRoot != file.MerkleRoot {
+		return nil, fmt.Errorf("the rebuilt Merkle root %s doesn't match the binary rewards file's root %s", rewardsFile.MerkleRoot, file.MerkleRoot)
+	}
+	return rewardsFile, nil
+}
+
+// Save a rewards file in the compact binary format
+func SaveRewardsFileBinary(rewardsFile *RewardsFile, path string, mode fs.FileMode) error {
+	fileBytes, err := SerializeRewardsFileBinary(rewardsFile)
+	if err != nil {
+		return err
+	}
+	err = files.WriteFileAtomic(path, fileBytes, mode)
+	if err != nil {
+		return fmt.Errorf("error saving binary rewards file to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Load a rewards file saved in the compact binary format
+func LoadRewardsFileBinary(path string) (*RewardsFile, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	rewardsFile, err := DeserializeRewardsFileBinary(fileBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing %s: %w", path, err)
+	}
+	return rewardsFile, nil
+}
@@ -0,0 +1,74 @@
+package rewards
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// The number of nodes to process between checkpoints
+const rplRewardsCheckpointInterval int = 100
+
+// The minimum time between collateral RPL progress reports
+const rplRewardsProgressInterval time.Duration = 5 * time.Second
+
+// Intermediate state of the collateral RPL calculation, saved periodically so an interrupted run can resume
+type rplRewardsCheckpoint struct {
+	Index                   uint64                              `json:"index"`
+	ElBlockNumber           uint64                              `json:"elBlockNumber"`
+	ElBlockHash             common.Hash                         `json:"elBlockHash"`
+	NodeCount               int                                 `json:"nodeCount"`
+	TrueNodeEffectiveStakes []*QuotedBigInt                     `json:"trueNodeEffectiveStakes"`
+	NextNodeIndex           int                                 `json:"nextNodeIndex"`
+	NodeRewards             map[common.Address]*NodeRewardsInfo `json:"nodeRewards"`
+	NetworkRewards          map[uint64]*NetworkRewardsInfo      `json:"networkRewards"`
+	InvalidNetworkNodes     map[common.Address]uint64           `json:"invalidNetworkNodes"`
+}
+
+// Load the checkpoint at the provided path. Returns nil if there isn't one, or if it was made for a different interval,
+// EL block, or set of nodes; stale checkpoints are deleted. A checkpoint made at the same height on a block that was
+// since reorged out has a different hash, so it's treated as stale too.
+func loadRplRewardsCheckpoint(path string, index uint64, elBlockNumber uint64, elBlockHash common.Hash, nodeCount int) (*rplRewardsCheckpoint, error) {
+
+	fileBytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading checkpoint %s: %w", path, err)
+	}
+
+	var checkpoint rplRewardsCheckpoint
+	err = json.Unmarshal(fileBytes, &checkpoint)
+	if err != nil ||
+		checkpoint.Index != index ||
+		checkpoint.ElBlockNumber != elBlockNumber ||
+		checkpoint.ElBlockHash != elBlockHash ||
+		checkpoint.NodeCount != nodeCount ||
+		len(checkpoint.TrueNodeEffectiveStakes) != nodeCount {
+		err = os.Remove(path)
+		if err != nil {
+			return nil, fmt.Errorf("error removing stale checkpoint %s: %w", path, err)
+		}
+		return nil, nil
+	}
+	return &checkpoint, nil
+
+}
+
+// Save a checkpoint to the provided path
+func saveRplRewardsCheckpoint(path string, checkpoint *rplRewardsCheckpoint) error {
+	bytes, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("error serializing checkpoint: %w", err)
+	}
+	err = files.WriteFileAtomic(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error saving checkpoint to %s: %w", path, err)
+	}
+	return nil
+}
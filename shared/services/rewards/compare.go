@@ -0,0 +1,116 @@
+package rewards
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Get the addresses of every node in either of the provided rewards files, sorted in ascending order
+func getSortedNodeAddresses(first *RewardsFile, second *RewardsFile) []common.Address {
+	seen := map[common.Address]bool{}
+	addresses := []common.Address{}
+	for _, file := range []*RewardsFile{first, second} {
+		for address := range file.NodeRewards {
+			if !seen[address] {
+				seen[address] = true
+				addresses = append(addresses, address)
+			}
+		}
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) < 0
+	})
+	return addresses
+}
+
+// Describe how two nodes' rewards differ, or return an empty string if they're the same
+func describeNodeRewardsDifference(first *NodeRewardsInfo, second *NodeRewardsInfo) string {
+	if first == nil {
+		return "missing from the first file"
+	}
+	if second == nil {
+		return "missing from the second file"
+	}
+
+	differences := []string{}
+	if first.RewardNetwork != second.RewardNetwork {
+		differences = append(differences, fmt.Sprintf("network %d vs %d", first.RewardNetwork, second.RewardNetwork))
+	}
+	if first.CollateralRpl.Cmp(&second.CollateralRpl.Int) != 0 {
+		differences = append(differences, fmt.Sprintf("collateral RPL %s vs %s", first.CollateralRpl.String(), second.CollateralRpl.String()))
+	}
+	if first.OracleDaoRpl.Cmp(&second.OracleDaoRpl.Int) != 0 {
+		differences = append(differences, fmt.Sprintf("Oracle DAO RPL %s vs %s", first.OracleDaoRpl.String(), second.OracleDaoRpl.String()))
+	}
+	if first.SmoothingPoolEth.Cmp(&second.SmoothingPoolEth.Int) != 0 {
+		differences = append(differences, fmt.Sprintf("smoothing pool ETH %s vs %s", first.SmoothingPoolEth.String(), second.SmoothingPoolEth.String()))
+	}
+	if first.SmoothingPoolEligibilityRate != second.SmoothingPoolEligibilityRate {
+		differences = append(differences, fmt.Sprintf("eligibility rate %f vs %f", first.SmoothingPoolEligibilityRate, second.SmoothingPoolEligibilityRate))
+	}
+	if strings.Join(first.MerkleProof, ",") != strings.Join(second.MerkleProof, ",") {
+		differences = append(differences, "Merkle proof")
+	}
+	return strings.Join(differences, ", ")
+}
+
+// Find the first node (in address order) whose rewards differ between the two files.
+// Returns false if every node's rewards are identical.
+func FindFirstNodeDifference(first *RewardsFile, second *RewardsFile) (common.Address, string, bool) {
+	for _, address := range getSortedNodeAddresses(first, second) {
+		difference := describeNodeRewardsDifference(first.NodeRewards[address], second.NodeRewards[address])
+		if difference != "" {
+			return address, difference, true
+		}
+	}
+	return common.Address{}, "", false
+}
+
+// How a node's rewards differ between two rewards files. The deltas are the second file's amounts minus the first's,
+// treating a node that's missing from a file as having no rewards in it.
+type NodeRewardsDifference struct {
+	Address               common.Address
+	Description           string
+	CollateralRplDelta    *big.Int
+	OracleDaoRplDelta     *big.Int
+	SmoothingPoolEthDelta *big.Int
+}
+
+// Get every node (in address order) whose rewards differ between the two files, regardless of how either file
+// orders its nodes
+func DiffRewardsFiles(first *RewardsFile, second *RewardsFile) []NodeRewardsDifference {
+	differences := []NodeRewardsDifference{}
+	for _, address := range getSortedNodeAddresses(first, second) {
+		firstRewards := first.NodeRewards[address]
+		secondRewards := second.NodeRewards[address]
+		description := describeNodeRewardsDifference(firstRewards, secondRewards)
+		if description == "" {
+			continue
+		}
+		differences = append(differences, NodeRewardsDifference{
+			Address:               address,
+			Description:           description,
+			CollateralRplDelta:    getRewardsDelta(firstRewards, secondRewards, func(n *NodeRewardsInfo) *QuotedBigInt { return n.CollateralRpl }),
+			OracleDaoRplDelta:     getRewardsDelta(firstRewards, secondRewards, func(n *NodeRewardsInfo) *QuotedBigInt { return n.OracleDaoRpl }),
+			SmoothingPoolEthDelta: getRewardsDelta(firstRewards, secondRewards, func(n *NodeRewardsInfo) *QuotedBigInt { return n.SmoothingPoolEth }),
+		})
+	}
+	return differences
+}
+
+// Get the difference of one kind of reward between two nodes' entries, treating missing entries as zero
+func getRewardsDelta(first *NodeRewardsInfo, second *NodeRewardsInfo, getAmount func(*NodeRewardsInfo) *QuotedBigInt) *big.Int {
+	delta := big.NewInt(0)
+	if second != nil {
+		delta.Add(delta, &getAmount(second).Int)
+	}
+	if first != nil {
+		delta.Sub(delta, &getAmount(first).Int)
+	}
+	return delta
+}
@@ -0,0 +1,86 @@
+package rewards
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The smallest log query range the rewards event search will shrink down to before giving up
+const minEventLogChunkSize uint64 = 16
+
+// Fragments of the errors execution clients and providers return when an eth_getLogs query covers too many blocks or
+// matches too many logs. These have to stay specific to the range and result size, since a generic "limit exceeded"
+// also matches rate limit errors, which shrinking the range won't fix.
+var logRangeErrorFragments = []string{
+	"query returned more than",
+	"block range",
+	"range too large",
+	"range is too large",
+	"too many blocks",
+	"too many results",
+	"response size",
+}
+
+// Checks if an error means the log query range was too large and should be shrunk
+func isLogRangeError(err error) bool {
+	message := strings.ToLower(err.Error())
+	for _, fragment := range logRangeErrorFragments {
+		if strings.Contains(message, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+// Searches for the rewards snapshot event between the start and end blocks, one chunk of blocks at a time. The chunk
+// starts at the provided size and is halved whenever the client rejects a query for covering too much, then doubled
+// again after each successful query, so the same settings work against providers with very different limits.
+func getRewardSnapshotEventAdaptive(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, interval uint64, chunkSize uint64, startBlock uint64, endBlock uint64) (rewards.RewardsEvent, error) {
+
+	maxChunkSize := endBlock - startBlock + 1
+	if chunkSize == 0 || chunkSize > maxChunkSize {
+		chunkSize = maxChunkSize
+	}
+	notFoundMessage := fmt.Sprintf("reward snapshot for interval %d not found", interval)
+
+	for start := startBlock; start <= endBlock; {
+		end := start + chunkSize - 1
+		if end > endBlock {
+			end = endBlock
+		}
+
+		// Query the whole chunk in one request
+		querySize := big.NewInt(0).SetUint64(end - start + 1)
+		event, err := GetUpgradedRewardSnapshotEvent(cfg, rp, interval, querySize, big.NewInt(0).SetUint64(start), big.NewInt(0).SetUint64(end))
+		if err == nil {
+			return event, nil
+		}
+		if err.Error() != notFoundMessage {
+			if !isLogRangeError(err) || chunkSize <= minEventLogChunkSize {
+				return rewards.RewardsEvent{}, err
+			}
+
+			// Retry the same blocks with a smaller chunk
+			chunkSize /= 2
+			if chunkSize < minEventLogChunkSize {
+				chunkSize = minEventLogChunkSize
+			}
+			continue
+		}
+
+		// Not in this chunk, so move on and try a larger one next time
+		start = end + 1
+		chunkSize *= 2
+		if chunkSize > maxChunkSize {
+			chunkSize = maxChunkSize
+		}
+	}
+
+	return rewards.RewardsEvent{}, fmt.Errorf("reward snapshot for interval %d not found", interval)
+
+}
@@ -0,0 +1,32 @@
+package rewards
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestIsLogRangeError(t *testing.T) {
+	rangeErrors := []string{
+		"query returned more than 10000 results",
+		"exceed maximum block range: 5000",
+		"eth_getLogs block range too large, range: 10001, max: 10000",
+		"Log response size exceeded. You can make eth_getLogs requests with up to a 2K block range",
+	}
+	for _, message := range rangeErrors {
+		if !isLogRangeError(errors.New(message)) {
+			t.Fatalf("\"%s\" should have been treated as a log range error", message)
+		}
+	}
+
+	otherErrors := []string{
+		"daily request count limit exceeded",
+		"rate limit exceeded",
+		"429 Too Many Requests",
+		"connection refused",
+	}
+	for _, message := range otherErrors {
+		if isLogRangeError(errors.New(message)) {
+			t.Fatalf("\"%s\" shouldn't have been treated as a log range error", message)
+		}
+	}
+}
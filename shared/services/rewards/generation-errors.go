@@ -0,0 +1,64 @@
+package rewards
+
+import (
+	"errors"
+)
+
+// The categories of rewards tree generation failures. Errors from the generation pipeline wrap one of these in a
+// *GenerationError, so callers can tell them apart with errors.Is while the message stays the same.
+var (
+	ErrELBlockNotFound   = errors.New("EL block not found")
+	ErrStateUnavailable  = errors.New("EL state unavailable")
+	ErrBeaconUnavailable = errors.New("Beacon client unavailable")
+	ErrRootMismatch      = errors.New("Merkle root mismatch")
+	ErrChainReorg        = errors.New("chain reorg")
+	ErrIntervalNotFound  = errors.New("rewards interval not submitted")
+)
+
+// The IDs of each failure category, for reporting them through the status API
+var generationErrorKinds = map[error]string{
+	ErrELBlockNotFound:   "elBlockNotFound",
+	ErrStateUnavailable:  "stateUnavailable",
+	ErrBeaconUnavailable: "beaconUnavailable",
+	ErrRootMismatch:      "rootMismatch",
+	ErrChainReorg:        "chainReorg",
+	ErrIntervalNotFound:  "intervalNotFound",
+}
+
+// A rewards tree generation failure in one of the known categories
+type GenerationError struct {
+	Kind error
+	Err  error
+}
+
+// Wrap an error in the provided failure category
+func NewGenerationError(kind error, err error) *GenerationError {
+	return &GenerationError{
+		Kind: kind,
+		Err:  err,
+	}
+}
+
+func (e *GenerationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *GenerationError) Unwrap() error {
+	return e.Err
+}
+
+func (e *GenerationError) Is(target error) bool {
+	return target == e.Kind
+}
+
+// Get the ID of the category a generation error is in, or "other" if it isn't in one of the known categories
+func GetGenerationErrorKind(err error) string {
+	var generationErr *GenerationError
+	if errors.As(err, &generationErr) {
+		kind, exists := generationErrorKinds[generationErr.Kind]
+		if exists {
+			return kind
+		}
+	}
+	return "other"
+}
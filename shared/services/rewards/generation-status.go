@@ -0,0 +1,65 @@
+package rewards
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// The stage a rewards tree generation is in, for status reporting
+type GenerationStage string
+
+const (
+	GenerationStage_Calculating  GenerationStage = "calculating"
+	GenerationStage_BuildingTree GenerationStage = "buildingTree"
+	GenerationStage_Finished     GenerationStage = "finished"
+	GenerationStage_Failed       GenerationStage = "failed"
+)
+
+// The status of the watchtower's latest manual rewards tree generation. The watchtower publishes it to a file since the
+// generation runs in the background, so other processes can poll it.
+type GenerationStatus struct {
+	Running   bool            `json:"running"`
+	Interval  uint64          `json:"interval"`
+	Stage     GenerationStage `json:"stage"`
+	Progress  float64         `json:"progress"`
+	StartTime time.Time       `json:"startTime"`
+	Updated   time.Time       `json:"updated"`
+
+	// Why the generation failed, if it did; the kind is one of the IDs from GetGenerationErrorKind
+	FailureKind    string `json:"failureKind,omitempty"`
+	FailureMessage string `json:"failureMessage,omitempty"`
+}
+
+// Save a generation status to the provided path
+func SaveGenerationStatus(path string, status GenerationStatus) error {
+	bytes, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("error serializing generation status: %w", err)
+	}
+	err = files.WriteFileAtomic(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error saving generation status to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Load the generation status at the provided path. Returns nil if there hasn't been a generation yet.
+func LoadGenerationStatus(path string) (*GenerationStatus, error) {
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading generation status %s: %w", path, err)
+	}
+	var status GenerationStatus
+	err = json.Unmarshal(bytes, &status)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing generation status %s: %w", path, err)
+	}
+	return &status, nil
+}
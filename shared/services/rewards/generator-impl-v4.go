@@ -2,9 +2,9 @@ package rewards
 
 import (
 	"context"
-	"encoding/hex"
 	"fmt"
 	"math/big"
+	"runtime"
 	"sort"
 	"time"
 
@@ -19,13 +19,13 @@ import (
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/settings/protocol"
 	tnsettings "github.com/rocket-pool/rocketpool-go/settings/trustednode"
+	"github.com/rocket-pool/rocketpool-go/storage"
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
-	"github.com/wealdtech/go-merkletree"
-	"github.com/wealdtech/go-merkletree/keccak256"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -59,6 +59,17 @@ type treeGeneratorImpl_v4 struct {
 	maxCollateralFraction  *big.Int
 	stakingMinipoolPubkeys []rptypes.ValidatorPubkey
 	nodeStakes             []*big.Int
+	rewardsSplitOverride   *RewardsSplit
+	useMulticall           bool
+	checkpointPath         string
+	threads                int
+	progressCallback       func(processed int, total int)
+	phaseCallback          func(phase GenerationPhase, duration time.Duration)
+	includeMinipoolRewards bool
+	beaconCache            *BeaconCache
+	nodeFilter             *common.Address
+	previousSnapshotPath   string
+	nodeSnapshotPath       string
 }
 
 // Create a new tree generator
@@ -81,9 +92,10 @@ func newTreeGeneratorImpl_v4(log log.ColorLogger, logPrefix string, index uint64
 				PoolStakerSmoothingPoolEth:   NewQuotedBigInt(0),
 				NodeOperatorSmoothingPoolEth: NewQuotedBigInt(0),
 			},
-			NetworkRewards:      map[uint64]*NetworkRewardsInfo{},
-			NodeRewards:         map[common.Address]*NodeRewardsInfo{},
-			InvalidNetworkNodes: map[common.Address]uint64{},
+			NetworkRewards:                 map[uint64]*NetworkRewardsInfo{},
+			NodeRewards:                    map[common.Address]*NodeRewardsInfo{},
+			InvalidNetworkNodes:            map[common.Address]uint64{},
+			UndeterminedSmoothingPoolNodes: map[common.Address]string{},
 			MinipoolPerformanceFile: MinipoolPerformanceFile{
 				Index:               index,
 				StartTime:           startTime.UTC(),
@@ -114,6 +126,7 @@ func (r *treeGeneratorImpl_v4) generateTree(rp *rocketpool.RocketPool, cfg *conf
 	r.rp = rp
 	r.cfg = cfg
 	r.bc = bc
+	r.useMulticall = (cfg.Smartnode.RewardsEcCallStrategy.Value == cfgtypes.EcCallStrategy_Multicall)
 	r.validNetworkCache = map[uint64]bool{
 		0: true,
 	}
@@ -124,7 +137,7 @@ func (r *treeGeneratorImpl_v4) generateTree(rp *rocketpool.RocketPool, cfg *conf
 
 	// Get the Beacon config
 	var err error
-	r.beaconConfig, err = r.bc.GetEth2Config()
+	r.beaconConfig, err = r.getEth2Config()
 	if err != nil {
 		return nil, err
 	}
@@ -155,25 +168,52 @@ func (r *treeGeneratorImpl_v4) generateTree(rp *rocketpool.RocketPool, cfg *conf
 	}
 
 	// Calculate the RPL rewards
+	phaseStart := time.Now()
 	err = r.calculateRplRewards()
 	if err != nil {
 		return nil, fmt.Errorf("Error calculating RPL rewards: %w", err)
 	}
+	r.reportPhase(GenerationPhase_RplRewards, phaseStart)
 
 	// Calculate the ETH rewards
+	phaseStart = time.Now()
 	err = r.calculateEthRewards(true)
 	if err != nil {
 		return nil, fmt.Errorf("Error calculating ETH rewards: %w", err)
 	}
+	r.reportPhase(GenerationPhase_EthRewards, phaseStart)
 
 	// Calculate the network reward map and the totals
 	r.updateNetworksAndTotals()
 
+	// Drop every other node if only one node's rewards were requested
+	if r.nodeFilter != nil {
+		nodeRewards, exists := r.rewardsFile.NodeRewards[*r.nodeFilter]
+		r.rewardsFile.NodeRewards = map[common.Address]*NodeRewardsInfo{}
+		if exists {
+			r.rewardsFile.NodeRewards[*r.nodeFilter] = nodeRewards
+		}
+	}
+
+	// Record where each node's rewards will actually be sent
+	err = r.resolveWithdrawalAddresses()
+	if err != nil {
+		return nil, fmt.Errorf("Error getting node withdrawal addresses: %w", err)
+	}
+
+	// The Merkle tree isn't needed for a single node's rewards
+	if r.nodeFilter != nil {
+		r.log.Printlnf("%s Only keeping the rewards for node %s, so the Merkle tree won't be built.", r.logPrefix, r.nodeFilter.Hex())
+		return r.rewardsFile, nil
+	}
+
 	// Generate the Merkle Tree
+	phaseStart = time.Now()
 	err = r.generateMerkleTree()
 	if err != nil {
 		return nil, fmt.Errorf("Error generating Merkle tree: %w", err)
 	}
+	r.reportPhase(GenerationPhase_MerkleTree, phaseStart)
 
 	// Sort all of the missed attestations so the files are always generated in the same state
 	for _, minipoolInfo := range r.rewardsFile.MinipoolPerformanceFile.MinipoolPerformance {
@@ -194,6 +234,7 @@ func (r *treeGeneratorImpl_v4) approximateStakerShareOfSmoothingPool(rp *rocketp
 	r.rp = rp
 	r.cfg = cfg
 	r.bc = bc
+	r.useMulticall = (cfg.Smartnode.RewardsEcCallStrategy.Value == cfgtypes.EcCallStrategy_Multicall)
 	r.validNetworkCache = map[uint64]bool{
 		0: true,
 	}
@@ -204,7 +245,7 @@ func (r *treeGeneratorImpl_v4) approximateStakerShareOfSmoothingPool(rp *rocketp
 
 	// Get the Beacon config
 	var err error
-	r.beaconConfig, err = r.bc.GetEth2Config()
+	r.beaconConfig, err = r.getEth2Config()
 	if err != nil {
 		return nil, err
 	}
@@ -245,74 +286,7 @@ func (r *treeGeneratorImpl_v4) approximateStakerShareOfSmoothingPool(rp *rocketp
 
 // Generates a merkle tree from the provided rewards map
 func (r *treeGeneratorImpl_v4) generateMerkleTree() error {
-
-	// Generate the leaf data for each node
-	totalData := make([][]byte, 0, len(r.rewardsFile.NodeRewards))
-	for address, rewardsForNode := range r.rewardsFile.NodeRewards {
-		// Ignore nodes that didn't receive any rewards
-		zero := big.NewInt(0)
-		if rewardsForNode.CollateralRpl.Cmp(zero) == 0 && rewardsForNode.OracleDaoRpl.Cmp(zero) == 0 && rewardsForNode.SmoothingPoolEth.Cmp(zero) == 0 {
-			continue
-		}
-
-		// Node data is address[20] :: network[32] :: RPL[32] :: ETH[32]
-		nodeData := make([]byte, 0, 20+32*3)
-
-		// Node address
-		addressBytes := address.Bytes()
-		nodeData = append(nodeData, addressBytes...)
-
-		// Node network
-		network := big.NewInt(0).SetUint64(rewardsForNode.RewardNetwork)
-		networkBytes := make([]byte, 32)
-		network.FillBytes(networkBytes)
-		nodeData = append(nodeData, networkBytes...)
-
-		// RPL rewards
-		rplRewards := big.NewInt(0)
-		rplRewards.Add(&rewardsForNode.CollateralRpl.Int, &rewardsForNode.OracleDaoRpl.Int)
-		rplRewardsBytes := make([]byte, 32)
-		rplRewards.FillBytes(rplRewardsBytes)
-		nodeData = append(nodeData, rplRewardsBytes...)
-
-		// ETH rewards
-		ethRewardsBytes := make([]byte, 32)
-		rewardsForNode.SmoothingPoolEth.FillBytes(ethRewardsBytes)
-		nodeData = append(nodeData, ethRewardsBytes...)
-
-		// Assign it to the node rewards tracker and add it to the leaf data slice
-		rewardsForNode.MerkleData = nodeData
-		totalData = append(totalData, nodeData)
-	}
-
-	// Generate the tree
-	tree, err := merkletree.NewUsing(totalData, keccak256.New(), false, true)
-	if err != nil {
-		return fmt.Errorf("error generating Merkle Tree: %w", err)
-	}
-
-	// Generate the proofs for each node
-	for address, rewardsForNode := range r.rewardsFile.NodeRewards {
-		// Get the proof
-		proof, err := tree.GenerateProof(rewardsForNode.MerkleData, 0)
-		if err != nil {
-			return fmt.Errorf("error generating proof for node %s: %w", address.Hex(), err)
-		}
-
-		// Convert the proof into hex strings
-		proofStrings := make([]string, len(proof.Hashes))
-		for i, hash := range proof.Hashes {
-			proofStrings[i] = fmt.Sprintf("0x%s", hex.EncodeToString(hash))
-		}
-
-		// Assign the hex strings to the node rewards struct
-		rewardsForNode.MerkleProof = proofStrings
-	}
-
-	r.rewardsFile.MerkleTree = tree
-	r.rewardsFile.MerkleRoot = common.BytesToHash(tree.Root()).Hex()
-	return nil
-
+	return buildMerkleTree(r.rewardsFile)
 }
 
 // Calculates the per-network distribution amounts and the total reward amounts
@@ -341,6 +315,31 @@ func (r *treeGeneratorImpl_v4) updateNetworksAndTotals() {
 
 }
 
+// Gets the RPL rewards split for the interval from chain state at the snapshot block, unless it's been overridden
+func (r *treeGeneratorImpl_v4) getRewardsSplit() (RewardsSplit, error) {
+	var split RewardsSplit
+	if r.rewardsSplitOverride != nil {
+		split = *r.rewardsSplitOverride
+		r.log.Printlnf("%s WARNING: using an overridden rewards split instead of the on-chain one. This tree is only valid for testing!", r.logPrefix)
+	} else {
+		var err error
+		split, err = GetRewardsSplit(r.cfg, r.rewardsFile.Index, r.rp, r.opts)
+		if err != nil {
+			return RewardsSplit{}, err
+		}
+	}
+
+	err := split.Validate()
+	if err != nil {
+		return RewardsSplit{}, fmt.Errorf("invalid rewards split: %w", err)
+	}
+	if total := split.Total(); total.Cmp(eth.EthToWei(1)) < 0 {
+		r.log.Printlnf("%s WARNING: the rewards split only adds up to %.6f, so part of the RPL inflation won't be assigned.", r.logPrefix, eth.WeiToEth(total))
+	}
+	r.log.Printlnf("%s Rewards split: node operators %.6f, Oracle DAO %.6f, Protocol DAO %.6f", r.logPrefix, eth.WeiToEth(split.NodeOperator), eth.WeiToEth(split.TrustedNode), eth.WeiToEth(split.ProtocolDao))
+	return split, nil
+}
+
 // Calculates the RPL rewards for the given interval
 func (r *treeGeneratorImpl_v4) calculateRplRewards() error {
 
@@ -364,11 +363,14 @@ func (r *treeGeneratorImpl_v4) calculateRplRewards() error {
 		return fmt.Errorf("error getting maximum per minipool stake: %w", err)
 	}
 
-	// Handle node operator rewards
-	nodeOpPercent, err := GetNodeOperatorRewardsPercent(r.cfg, r.rewardsFile.Index, r.rp, r.opts)
+	// Get the rewards split
+	split, err := r.getRewardsSplit()
 	if err != nil {
 		return err
 	}
+	nodeOpPercent := split.NodeOperator
+
+	// Handle node operator rewards
 	pendingRewards, err := GetPendingRPLRewards(r.cfg, r.rewardsFile.Index, r.rp, r.opts)
 	if err != nil {
 		return err
@@ -379,74 +381,75 @@ func (r *treeGeneratorImpl_v4) calculateRplRewards() error {
 	totalNodeRewards.Div(totalNodeRewards, eth.EthToWei(1))
 	r.log.Printlnf("%s Approx. total collateral RPL rewards: %s (%.3f)", r.logPrefix, totalNodeRewards.String(), eth.WeiToEth(totalNodeRewards))
 
-	// Get the effective stakes of each node
-	effectiveStakes, err := r.getNodeEffectiveRPLStakes()
+	// Resume from a checkpoint if an earlier run was interrupted
+	nodeCount := len(r.nodeAddresses)
+	checkpoint, err := r.loadRplRewardsCheckpoint()
 	if err != nil {
-		return fmt.Errorf("error calculating effective RPL stakes: %w", err)
+		return err
 	}
-
-	// Calculate the true effective stake of each node based on their participation in this interval
+	var trueNodeEffectiveStakes map[common.Address]*big.Int
 	totalNodeEffectiveStake := big.NewInt(0)
-	trueNodeEffectiveStakes := map[common.Address]*big.Int{}
-	intervalDurationBig := big.NewInt(int64(intervalDuration.Seconds()))
-	r.log.Printlnf("%s Calculating true total collateral rewards (progress is reported every 100 nodes)", r.logPrefix)
-	nodesDone := 0
-	startTime := time.Now()
-	nodeCount := len(r.nodeAddresses)
-	for i, address := range r.nodeAddresses {
-		if nodesDone == 100 {
-			timeTaken := time.Since(startTime)
-			r.log.Printlnf("%s On Node %d of %d (%.2f%%)... (%s so far)", r.logPrefix, i, nodeCount, float64(i)/float64(nodeCount)*100.0, timeTaken)
-			nodesDone = 0
+	startIndex := 0
+	if checkpoint != nil {
+		r.log.Printlnf("%s Resuming collateral rewards calculation from the checkpoint at node %d of %d", r.logPrefix, checkpoint.NextNodeIndex, nodeCount)
+		trueNodeEffectiveStakes = map[common.Address]*big.Int{}
+		for i, address := range r.nodeAddresses {
+			nodeStake := &checkpoint.TrueNodeEffectiveStakes[i].Int
+			trueNodeEffectiveStakes[address] = nodeStake
+			totalNodeEffectiveStake.Add(totalNodeEffectiveStake, nodeStake)
 		}
-		// Get the node's effective stake
-		nodeStake := effectiveStakes[i]
-
-		// Get the timestamp of the node's registration
-		regTime, err := node.GetNodeRegistrationTime(r.rp, address, r.opts)
-		if err != nil {
-			return fmt.Errorf("error getting registration time for node %s: %w", address, err)
+		if checkpoint.NodeRewards != nil {
+			r.rewardsFile.NodeRewards = checkpoint.NodeRewards
 		}
-
-		// Get the actual effective stake, scaled based on participation
-		eligibleDuration := snapshotBlockTime.Sub(regTime)
-		if eligibleDuration < intervalDuration {
-			eligibleSeconds := big.NewInt(int64(eligibleDuration / time.Second))
-			nodeStake.Mul(nodeStake, eligibleSeconds)
-			nodeStake.Div(nodeStake, intervalDurationBig)
+		if checkpoint.NetworkRewards != nil {
+			r.rewardsFile.NetworkRewards = checkpoint.NetworkRewards
+		}
+		if checkpoint.InvalidNetworkNodes != nil {
+			r.rewardsFile.InvalidNetworkNodes = checkpoint.InvalidNetworkNodes
+		}
+		startIndex = checkpoint.NextNodeIndex
+	} else {
+		trueNodeEffectiveStakes, totalNodeEffectiveStake, err = r.getTrueNodeEffectiveStakes(snapshotBlockTime, intervalDuration)
+		if err != nil {
+			return err
 		}
-		trueNodeEffectiveStakes[address] = nodeStake
-
-		// Add it to the total
-		totalNodeEffectiveStake.Add(totalNodeEffectiveStake, nodeStake)
-
-		nodesDone++
 	}
 
-	r.log.Printlnf("%s Calculating individual collateral rewards (progress is reported every 100 nodes)", r.logPrefix)
-	nodesDone = 0
-	startTime = time.Now()
-	for i, address := range r.nodeAddresses {
-		if nodesDone == 100 {
+	// Process the nodes in chunks; each node in a chunk is calculated in parallel, then the results are merged in node order
+	// so the rewards maps (and thus the Merkle root) don't depend on the number of threads
+	threads := r.getThreadCount()
+	r.log.Printlnf("%s Calculating individual collateral rewards with %d threads (progress is reported every %d nodes)", r.logPrefix, threads, rplRewardsCheckpointInterval)
+	startTime := time.Now()
+	lastProgressTime := startTime
+	for chunkStart := startIndex; chunkStart < nodeCount; chunkStart += rplRewardsCheckpointInterval {
+		chunkEnd := chunkStart + rplRewardsCheckpointInterval
+		if chunkEnd > nodeCount {
+			chunkEnd = nodeCount
+		}
+		if chunkStart > startIndex {
 			timeTaken := time.Since(startTime)
-			r.log.Printlnf("%s On Node %d of %d (%.2f%%)... (%s so far)", r.logPrefix, i, nodeCount, float64(i)/float64(nodeCount)*100.0, timeTaken)
-			nodesDone = 0
+			r.log.Printlnf("%s On Node %d of %d (%.2f%%)... (%s so far)", r.logPrefix, chunkStart, nodeCount, float64(chunkStart)/float64(nodeCount)*100.0, timeTaken)
+		}
+		if r.progressCallback != nil && time.Since(lastProgressTime) >= rplRewardsProgressInterval {
+			r.progressCallback(chunkStart, nodeCount)
+			lastProgressTime = time.Now()
 		}
 
-		// Get how much RPL goes to this node: (true effective stake) * (total node rewards) / (total true effective stake)
-		nodeRplRewards := big.NewInt(0)
-		nodeRplRewards.Mul(trueNodeEffectiveStakes[address], totalNodeRewards)
-		nodeRplRewards.Div(nodeRplRewards, totalNodeEffectiveStake)
+		results, err := r.calculateNodeRplRewards(r.nodeAddresses[chunkStart:chunkEnd], trueNodeEffectiveStakes, totalNodeRewards, totalNodeEffectiveStake, threads)
+		if err != nil {
+			return err
+		}
 
-		// If there are pending rewards, add it to the map
-		if nodeRplRewards.Cmp(big.NewInt(0)) == 1 {
+		for i, result := range results {
+			// If there are pending rewards, add it to the map
+			if result.rewards.Cmp(big.NewInt(0)) != 1 {
+				continue
+			}
+			address := r.nodeAddresses[chunkStart+i]
 			rewardsForNode, exists := r.rewardsFile.NodeRewards[address]
 			if !exists {
 				// Get the network the rewards should go to
-				network, err := node.GetRewardNetwork(r.rp, address, r.opts)
-				if err != nil {
-					return err
-				}
+				network := result.network
 				validNetwork, err := r.validateNetwork(network)
 				if err != nil {
 					return err
@@ -464,7 +467,7 @@ func (r *treeGeneratorImpl_v4) calculateRplRewards() error {
 				}
 				r.rewardsFile.NodeRewards[address] = rewardsForNode
 			}
-			rewardsForNode.CollateralRpl.Add(&rewardsForNode.CollateralRpl.Int, nodeRplRewards)
+			rewardsForNode.CollateralRpl.Add(&rewardsForNode.CollateralRpl.Int, result.rewards)
 
 			// Add the rewards to the running total for the specified network
 			rewardsForNetwork, exists := r.rewardsFile.NetworkRewards[rewardsForNode.RewardNetwork]
@@ -476,10 +479,20 @@ func (r *treeGeneratorImpl_v4) calculateRplRewards() error {
 				}
 				r.rewardsFile.NetworkRewards[rewardsForNode.RewardNetwork] = rewardsForNetwork
 			}
-			rewardsForNetwork.CollateralRpl.Add(&rewardsForNetwork.CollateralRpl.Int, nodeRplRewards)
+			rewardsForNetwork.CollateralRpl.Add(&rewardsForNetwork.CollateralRpl.Int, result.rewards)
 		}
 
-		nodesDone++
+		// Save progress periodically so an interrupted run can pick up from here
+		if chunkEnd%rplRewardsCheckpointInterval == 0 {
+			err = r.saveRplRewardsCheckpoint(trueNodeEffectiveStakes, chunkEnd)
+			if err != nil {
+				r.log.Printlnf("%s WARNING: couldn't save checkpoint: %s", r.logPrefix, err.Error())
+			}
+		}
+	}
+
+	if r.progressCallback != nil && nodeCount > 0 {
+		r.progressCallback(nodeCount, nodeCount)
 	}
 
 	// Sanity check to make sure we arrived at the correct total
@@ -496,10 +509,7 @@ func (r *treeGeneratorImpl_v4) calculateRplRewards() error {
 	r.log.Printlnf("%s Calculated rewards:           %s (error = %s wei)", r.logPrefix, totalCalculatedNodeRewards.String(), delta.String())
 
 	// Handle Oracle DAO rewards
-	oDaoPercent, err := GetTrustedNodeOperatorRewardsPercent(r.cfg, r.rewardsFile.Index, r.rp, r.opts)
-	if err != nil {
-		return err
-	}
+	oDaoPercent := split.TrustedNode
 	totalODaoRewards := big.NewInt(0)
 	totalODaoRewards.Mul(pendingRewards, oDaoPercent)
 	totalODaoRewards.Div(totalODaoRewards, eth.EthToWei(1))
@@ -511,6 +521,7 @@ func (r *treeGeneratorImpl_v4) calculateRplRewards() error {
 	}
 
 	// Calculate the true effective time of each oDAO node based on their participation in this interval
+	intervalDurationBig := big.NewInt(int64(intervalDuration.Seconds()))
 	totalODaoNodeTime := big.NewInt(0)
 	trueODaoNodeTimes := map[common.Address]*big.Int{}
 	for _, address := range oDaoAddresses {
@@ -592,10 +603,7 @@ func (r *treeGeneratorImpl_v4) calculateRplRewards() error {
 	r.log.Printlnf("%s Calculated rewards:           %s (error = %s wei)", r.logPrefix, totalCalculatedOdaoRewards.String(), delta.String())
 
 	// Get expected Protocol DAO rewards
-	pDaoPercent, err := GetProtocolDaoRewardsPercent(r.cfg, r.rewardsFile.Index, r.rp, r.opts)
-	if err != nil {
-		return err
-	}
+	pDaoPercent := split.ProtocolDao
 	pDaoRewards := NewQuotedBigInt(0)
 	pDaoRewards.Mul(pendingRewards, pDaoPercent)
 	pDaoRewards.Div(&pDaoRewards.Int, eth.EthToWei(1))
@@ -744,6 +752,11 @@ func (r *treeGeneratorImpl_v4) calculateEthRewards(checkBeaconPerformance bool)
 				r.rewardsFile.MinipoolPerformanceFile.MinipoolPerformance[minipoolInfo.Address] = performance
 			}
 
+			// Add the per-minipool breakdown if requested
+			if r.includeMinipoolRewards {
+				r.addMinipoolRewards(nodeInfo)
+			}
+
 			// Add the rewards to the running total for the specified network
 			rewardsForNetwork, exists := r.rewardsFile.NetworkRewards[rewardsForNode.RewardNetwork]
 			if !exists {
@@ -766,6 +779,24 @@ func (r *treeGeneratorImpl_v4) calculateEthRewards(checkBeaconPerformance bool)
 
 }
 
+// Record how much of a node's smoothing pool ETH each of its minipools contributed
+func (r *treeGeneratorImpl_v4) addMinipoolRewards(nodeInfo *NodeSmoothingDetails) {
+	if r.rewardsFile.MinipoolRewards == nil {
+		r.rewardsFile.MinipoolRewards = map[common.Address]map[common.Address]*QuotedBigInt{}
+	}
+	minipoolRewards := map[common.Address]*QuotedBigInt{}
+	for _, minipoolInfo := range nodeInfo.Minipools {
+		// Minipools that weren't active don't have a normalized share
+		if minipoolInfo.EndSlot-minipoolInfo.StartSlot == 0 {
+			continue
+		}
+		share := NewQuotedBigInt(0)
+		share.Set(minipoolInfo.MinipoolShare)
+		minipoolRewards[minipoolInfo.Address] = share
+	}
+	r.rewardsFile.MinipoolRewards[nodeInfo.Address] = minipoolRewards
+}
+
 // Calculate the distribution of Smoothing Pool ETH to each node
 func (r *treeGeneratorImpl_v4) calculateNodeRewards() (*big.Int, *big.Int, error) {
 
@@ -1063,9 +1094,7 @@ func (r *treeGeneratorImpl_v4) createMinipoolIndexMap() error {
 
 	// Get the status for all uncached minipool validators and add them to the cache
 	r.validatorIndexMap = map[uint64]*MinipoolInfo{}
-	statusMap, err := r.bc.GetValidatorStatuses(uncachedMinipoolPubkeys, &beacon.ValidatorStatusOptions{
-		Slot: &r.rewardsFile.ConsensusEndBlock,
-	})
+	statusMap, err := r.getValidatorStatuses(uncachedMinipoolPubkeys, r.rewardsFile.ConsensusEndBlock)
 	for pubkey, status := range r.validatorStatusMap {
 		statusMap[pubkey] = status
 	}
@@ -1203,11 +1232,7 @@ func (r *treeGeneratorImpl_v4) getSmoothingPoolNodeDetails() error {
 				if err != nil {
 					return fmt.Errorf("Error getting smoothing pool registration change time for node %s: %w", nodeDetails.Address.Hex(), err)
 				}
-				if nodeDetails.StatusChangeTime == time.Unix(0, 0) {
-					changeSlot = 0
-				} else {
-					changeSlot = uint64(nodeDetails.StatusChangeTime.Sub(genesisTime).Seconds()) / r.beaconConfig.SecondsPerSlot
-				}
+				changeSlot, nodeDetails.UndeterminedReason = r.getRegistrationChangeSlot(nodeDetails.IsOptedIn, nodeDetails.StatusChangeTime, genesisTime)
 
 				// If the node isn't opted into the Smoothing Pool and they didn't opt out during this interval, ignore them
 				if r.rewardsFile.ConsensusStartBlock > changeSlot && !nodeDetails.IsOptedIn {
@@ -1293,10 +1318,40 @@ func (r *treeGeneratorImpl_v4) getSmoothingPoolNodeDetails() error {
 		nodesDone += SmoothingPoolDetailsBatchSize
 	}
 
+	// Record the nodes whose opt-in window couldn't be determined so they can be reported as warnings
+	for _, nodeDetails := range r.nodeDetails {
+		if nodeDetails.UndeterminedReason != "" {
+			r.rewardsFile.UndeterminedSmoothingPoolNodes[nodeDetails.Address] = nodeDetails.UndeterminedReason
+		}
+	}
+
 	return nil
 
 }
 
+// Get the Beacon slot of a node's last smoothing pool registration change. Nodes only get one change per interval, so
+// this is enough to tell which part of the interval they were opted in for: from the change onward if they're opted in
+// now, or up to the change if they opted out. The slot math is part of ruleset v4 and can't change without a new ruleset,
+// so a change time that doesn't make sense for the node's state is only reported through the returned reason.
+func (r *treeGeneratorImpl_v4) getRegistrationChangeSlot(isOptedIn bool, changeTime time.Time, genesisTime time.Time) (uint64, string) {
+	if changeTime == time.Unix(0, 0) {
+		reason := ""
+		if isOptedIn {
+			reason = "it's opted into the Smoothing Pool but has no registration change time"
+		}
+		return 0, reason
+	}
+	changeSlot := uint64(changeTime.Sub(genesisTime).Seconds()) / r.beaconConfig.SecondsPerSlot
+	if changeTime.Before(genesisTime) {
+		return changeSlot, fmt.Sprintf("its registration change time (%s) is before the Beacon chain genesis", changeTime.UTC().Format(time.RFC3339))
+	}
+	snapshotTime := time.Unix(int64(r.elSnapshotHeader.Time), 0)
+	if changeTime.After(snapshotTime) {
+		return changeSlot, fmt.Sprintf("its registration change time (%s) is after the interval's EL snapshot block", changeTime.UTC().Format(time.RFC3339))
+	}
+	return changeSlot, ""
+}
+
 // Validates that the provided network is legal
 func (r *treeGeneratorImpl_v4) validateNetwork(network uint64) (bool, error) {
 	valid, exists := r.validNetworkCache[network]
@@ -1364,12 +1419,24 @@ func (r *treeGeneratorImpl_v4) cacheMinipoolDetails() error {
 	r.stakingMinipoolPubkeys = []rptypes.ValidatorPubkey{}
 	nodesDone := uint64(0)
 	startTime := time.Now()
+
+	// Reuse the previous interval's details for any node that hasn't changed since then
+	previousSnapshot, changedNodes := r.loadPreviousNodeSnapshot()
+	reusedNodes := map[common.Address]bool{}
+	if previousSnapshot != nil {
+		for _, address := range r.nodeAddresses {
+			if _, exists := previousSnapshot.Nodes[address]; exists && !changedNodes[address] {
+				reusedNodes[address] = true
+			}
+		}
+		r.log.Printlnf("%s Reusing the details of %d of %d nodes from the snapshot for interval %d", r.logPrefix, len(reusedNodes), len(r.nodeAddresses), previousSnapshot.Index)
+	}
 	r.log.Printlnf("%s Querying minipool info for nodes (progress is reported every 100 nodes)", r.logPrefix)
 
 	nodeCount := uint64(len(r.nodeAddresses))
 	stakingMinipoolDetailsList := make([][]minipool.MinipoolDetails, nodeCount)
 	pubkeyList := make([][]rptypes.ValidatorPubkey, nodeCount)
-	r.nodeStakes = make([]*big.Int, nodeCount)
+	minipoolAddressList := make([][]common.Address, nodeCount)
 
 	// Get the details for each minipool in each node
 	for batchStartIndex := uint64(0); batchStartIndex < nodeCount; batchStartIndex += SmoothingPoolDetailsBatchSize {
@@ -1393,14 +1460,28 @@ func (r *treeGeneratorImpl_v4) cacheMinipoolDetails() error {
 			iterationIndex := iterationIndex
 			wg.Go(func() error {
 				address := r.nodeAddresses[iterationIndex]
+				if reusedNodes[address] {
+					entry := previousSnapshot.Nodes[address]
+					minipoolPubkeys := make([]rptypes.ValidatorPubkey, 0, len(entry.StakingMinipools))
+					for _, mpd := range entry.StakingMinipools {
+						minipoolPubkeys = append(minipoolPubkeys, mpd.Pubkey)
+					}
+					stakingMinipoolDetailsList[iterationIndex] = entry.StakingMinipools
+					pubkeyList[iterationIndex] = minipoolPubkeys
+					minipoolAddressList[iterationIndex] = entry.Minipools
+					return nil
+				}
+
 				minipoolDetails, err := minipool.GetNodeMinipools(r.rp, address, r.opts)
 				if err != nil {
 					return fmt.Errorf("Error getting minipool details for node %s: %w", address, err)
 				}
 				stakingMinipools := make([]minipool.MinipoolDetails, 0, len(minipoolDetails))
 				minipoolPubkeys := make([]rptypes.ValidatorPubkey, 0, len(minipoolDetails))
+				minipoolAddresses := make([]common.Address, 0, len(minipoolDetails))
 				for _, mpd := range minipoolDetails {
 					if mpd.Exists {
+						minipoolAddresses = append(minipoolAddresses, mpd.Address)
 						mp, err := minipool.NewMinipool(r.rp, mpd.Address, r.opts)
 						if err != nil {
 							return fmt.Errorf("Error creating minipool wrapper for minipool %s on node %s: %w", mpd.Address.Hex(), address.Hex(), err)
@@ -1417,13 +1498,7 @@ func (r *treeGeneratorImpl_v4) cacheMinipoolDetails() error {
 				}
 				stakingMinipoolDetailsList[iterationIndex] = stakingMinipools
 				pubkeyList[iterationIndex] = minipoolPubkeys
-
-				// Cache the node stake since we're processing each node here anyway
-				nodeStake, err := node.GetNodeRPLStake(r.rp, address, r.opts)
-				if err != nil {
-					return fmt.Errorf("error getting RPL stake for node %s: %w", address.Hex(), err)
-				}
-				r.nodeStakes[iterationIndex] = nodeStake
+				minipoolAddressList[iterationIndex] = minipoolAddresses
 
 				return nil
 			})
@@ -1436,25 +1511,131 @@ func (r *treeGeneratorImpl_v4) cacheMinipoolDetails() error {
 		nodesDone += SmoothingPoolDetailsBatchSize
 	}
 
+	// Cache the node stakes
+	var nodeStakes []*big.Int
+	var err error
+	if previousSnapshot == nil {
+		nodeStakes, err = r.getNodeUint256Values("rocketNodeStaking", "getNodeRPLStake", func(address common.Address) (*big.Int, error) {
+			return node.GetNodeRPLStake(r.rp, address, r.opts)
+		})
+	} else {
+		nodeStakes, err = r.getChangedNodeRPLStakes(previousSnapshot, reusedNodes)
+	}
+	if err != nil {
+		return fmt.Errorf("error getting node RPL stakes: %w", err)
+	}
+	r.nodeStakes = nodeStakes
+
 	// Cache the minipool details and aggregate the pubkeys
 	for i, address := range r.nodeAddresses {
 		r.stakingMinipoolMap[address] = stakingMinipoolDetailsList[i]
 		r.stakingMinipoolPubkeys = append(r.stakingMinipoolPubkeys, pubkeyList[i]...)
 	}
 
+	// Save the details for the next interval to build on
+	if r.nodeSnapshotPath != "" {
+		snapshot := &nodeSnapshot{
+			Index:         r.rewardsFile.Index,
+			ElBlockNumber: r.elSnapshotHeader.Number.Uint64(),
+			Nodes:         map[common.Address]*nodeSnapshotEntry{},
+		}
+		for i, address := range r.nodeAddresses {
+			rplStake := &QuotedBigInt{}
+			rplStake.Set(nodeStakes[i])
+			snapshot.Nodes[address] = &nodeSnapshotEntry{
+				RplStake:         rplStake,
+				Minipools:        minipoolAddressList[i],
+				StakingMinipools: stakingMinipoolDetailsList[i],
+			}
+		}
+		err = saveNodeSnapshot(r.nodeSnapshotPath, snapshot)
+		if err != nil {
+			r.log.Printlnf("%s WARNING: couldn't save node snapshot: %s", r.logPrefix, err.Error())
+		}
+	}
+
 	return nil
 
 }
 
+// Load the previous interval's node snapshot and find the nodes that changed since it was taken. Returns nil if incremental
+// generation is disabled or the snapshot can't be used, in which case every node has to be queried.
+func (r *treeGeneratorImpl_v4) loadPreviousNodeSnapshot() (*nodeSnapshot, map[common.Address]bool) {
+
+	if r.previousSnapshotPath == "" {
+		return nil, nil
+	}
+	snapshot, err := loadNodeSnapshot(r.previousSnapshotPath)
+	if err != nil {
+		r.log.Printlnf("%s WARNING: %s, querying every node instead.", r.logPrefix, err.Error())
+		return nil, nil
+	}
+	if snapshot == nil {
+		r.log.Printlnf("%s No node snapshot from the previous interval was found, querying every node.", r.logPrefix)
+		return nil, nil
+	}
+	if snapshot.Index >= r.rewardsFile.Index || snapshot.ElBlockNumber > r.elSnapshotHeader.Number.Uint64() {
+		r.log.Printlnf("%s The node snapshot at %s is for interval %d (EL block %d), which isn't before this one; querying every node instead.", r.logPrefix, r.previousSnapshotPath, snapshot.Index, snapshot.ElBlockNumber)
+		return nil, nil
+	}
+
+	changedNodes, err := getNodesChangedSinceSnapshot(r.rp, r.cfg, snapshot, r.elSnapshotHeader.Number.Uint64(), r.opts)
+	if err != nil {
+		r.log.Printlnf("%s WARNING: couldn't check which nodes changed since interval %d (%s), querying every node instead.", r.logPrefix, snapshot.Index, err.Error())
+		return nil, nil
+	}
+	return snapshot, changedNodes
+
+}
+
+// Get the RPL stake of every node, taking the stakes of the reused nodes from the previous snapshot and only querying the rest
+func (r *treeGeneratorImpl_v4) getChangedNodeRPLStakes(previousSnapshot *nodeSnapshot, reusedNodes map[common.Address]bool) ([]*big.Int, error) {
+
+	nodeCount := uint64(len(r.nodeAddresses))
+	nodeStakes := make([]*big.Int, nodeCount)
+	for batchStartIndex := uint64(0); batchStartIndex < nodeCount; batchStartIndex += SmoothingPoolDetailsBatchSize {
+
+		// Get batch start & end index
+		iterationStartIndex := batchStartIndex
+		iterationEndIndex := batchStartIndex + SmoothingPoolDetailsBatchSize
+		if iterationEndIndex > nodeCount {
+			iterationEndIndex = nodeCount
+		}
+
+		// Load the stakes
+		var wg errgroup.Group
+		for iterationIndex := iterationStartIndex; iterationIndex < iterationEndIndex; iterationIndex++ {
+			iterationIndex := iterationIndex
+			address := r.nodeAddresses[iterationIndex]
+			if reusedNodes[address] {
+				nodeStakes[iterationIndex] = big.NewInt(0).Set(&previousSnapshot.Nodes[address].RplStake.Int)
+				continue
+			}
+			wg.Go(func() error {
+				nodeStake, err := node.GetNodeRPLStake(r.rp, address, r.opts)
+				if err != nil {
+					return fmt.Errorf("error getting RPL stake for node %s: %w", address.Hex(), err)
+				}
+				nodeStakes[iterationIndex] = nodeStake
+				return nil
+			})
+		}
+		if err := wg.Wait(); err != nil {
+			return nil, err
+		}
+	}
+
+	return nodeStakes, nil
+
+}
+
 // Get the effective stake of a node based on the status of its validators
 func (r *treeGeneratorImpl_v4) getNodeEffectiveRPLStakes() ([]*big.Int, error) {
 
 	// Get the status for all staking minipool validators
 	r.log.Printlnf("%s Getting validator statuses for all eligible minipools", r.logPrefix)
 	r.validatorIndexMap = map[uint64]*MinipoolInfo{}
-	statusMap, err := r.bc.GetValidatorStatuses(r.stakingMinipoolPubkeys, &beacon.ValidatorStatusOptions{
-		Slot: &r.rewardsFile.ConsensusEndBlock,
-	})
+	statusMap, err := r.getValidatorStatuses(r.stakingMinipoolPubkeys, r.rewardsFile.ConsensusEndBlock)
 	if err != nil {
 		return nil, fmt.Errorf("can't get validator statuses: %w", err)
 	}
@@ -1518,3 +1699,304 @@ func (r *treeGeneratorImpl_v4) getNodeEffectiveRPLStakes() ([]*big.Int, error) {
 	return effectiveStakes, nil
 
 }
+
+// Calculates the true effective stake of each node based on their participation in this interval, along with the total
+func (r *treeGeneratorImpl_v4) getTrueNodeEffectiveStakes(snapshotBlockTime time.Time, intervalDuration time.Duration) (map[common.Address]*big.Int, *big.Int, error) {
+
+	// Get the effective stakes of each node
+	effectiveStakes, err := r.getNodeEffectiveRPLStakes()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error calculating effective RPL stakes: %w", err)
+	}
+
+	// Get the registration time of each node
+	regTimes, err := r.getNodeUint256Values("rocketNodeManager", "getNodeRegistrationTime", func(address common.Address) (*big.Int, error) {
+		regTime, err := node.GetNodeRegistrationTime(r.rp, address, r.opts)
+		if err != nil {
+			return nil, err
+		}
+		return big.NewInt(regTime.Unix()), nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting node registration times: %w", err)
+	}
+
+	// Calculate the true effective stake of each node based on their participation in this interval
+	totalNodeEffectiveStake := big.NewInt(0)
+	trueNodeEffectiveStakes := map[common.Address]*big.Int{}
+	intervalDurationBig := big.NewInt(int64(intervalDuration.Seconds()))
+	r.log.Printlnf("%s Calculating true total collateral rewards (progress is reported every 100 nodes)", r.logPrefix)
+	nodesDone := 0
+	startTime := time.Now()
+	nodeCount := len(r.nodeAddresses)
+	for i, address := range r.nodeAddresses {
+		if nodesDone == 100 {
+			timeTaken := time.Since(startTime)
+			r.log.Printlnf("%s On Node %d of %d (%.2f%%)... (%s so far)", r.logPrefix, i, nodeCount, float64(i)/float64(nodeCount)*100.0, timeTaken)
+			nodesDone = 0
+		}
+		// Get the node's effective stake
+		nodeStake := effectiveStakes[i]
+
+		// Get the timestamp of the node's registration
+		regTime := time.Unix(regTimes[i].Int64(), 0)
+
+		// Get the actual effective stake, scaled based on participation
+		eligibleDuration := snapshotBlockTime.Sub(regTime)
+		if eligibleDuration < intervalDuration {
+			eligibleSeconds := big.NewInt(int64(eligibleDuration / time.Second))
+			nodeStake.Mul(nodeStake, eligibleSeconds)
+			nodeStake.Div(nodeStake, intervalDurationBig)
+		}
+		trueNodeEffectiveStakes[address] = nodeStake
+
+		// Add it to the total
+		totalNodeEffectiveStake.Add(totalNodeEffectiveStake, nodeStake)
+
+		nodesDone++
+	}
+
+	return trueNodeEffectiveStakes, totalNodeEffectiveStake, nil
+
+}
+
+// The collateral RPL rewards for a single node, and the network they should go to
+type nodeRplRewardsResult struct {
+	rewards *big.Int
+	network uint64
+}
+
+// Calculate the collateral RPL rewards for each of the provided nodes in parallel. Results are in the same order as the addresses.
+// Only nodes with rewards that don't already have an entry in the rewards file have their reward network looked up.
+func (r *treeGeneratorImpl_v4) calculateNodeRplRewards(addresses []common.Address, trueNodeEffectiveStakes map[common.Address]*big.Int, totalNodeRewards *big.Int, totalNodeEffectiveStake *big.Int, threads int) ([]nodeRplRewardsResult, error) {
+
+	results := make([]nodeRplRewardsResult, len(addresses))
+	var wg errgroup.Group
+	wg.SetLimit(threads)
+	for i, address := range addresses {
+		i := i
+		address := address
+		_, hasRewards := r.rewardsFile.NodeRewards[address]
+		wg.Go(func() error {
+			// Get how much RPL goes to this node: (true effective stake) * (total node rewards) / (total true effective stake)
+			nodeRplRewards := big.NewInt(0)
+			nodeRplRewards.Mul(trueNodeEffectiveStakes[address], totalNodeRewards)
+			nodeRplRewards.Div(nodeRplRewards, totalNodeEffectiveStake)
+			results[i].rewards = nodeRplRewards
+
+			if hasRewards || nodeRplRewards.Cmp(big.NewInt(0)) != 1 {
+				return nil
+			}
+			network, err := node.GetRewardNetwork(r.rp, address, r.opts)
+			if err != nil {
+				return err
+			}
+			results[i].network = network
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+	return results, nil
+
+}
+
+// Get the Beacon chain config, using the Beacon cache if there is one
+func (r *treeGeneratorImpl_v4) getEth2Config() (beacon.Eth2Config, error) {
+	if r.beaconCache != nil {
+		return r.beaconCache.GetEth2Config()
+	}
+	return r.bc.GetEth2Config()
+}
+
+// Get the statuses of the provided validators at a slot, using the Beacon cache if there is one
+func (r *treeGeneratorImpl_v4) getValidatorStatuses(pubkeys []rptypes.ValidatorPubkey, slot uint64) (map[rptypes.ValidatorPubkey]beacon.ValidatorStatus, error) {
+	if r.beaconCache != nil {
+		return r.beaconCache.GetValidatorStatuses(pubkeys, slot)
+	}
+	return r.bc.GetValidatorStatuses(pubkeys, &beacon.ValidatorStatusOptions{
+		Slot: &slot,
+	})
+}
+
+// Report how long a phase of the generation took, if anything is listening
+func (r *treeGeneratorImpl_v4) reportPhase(phase GenerationPhase, start time.Time) {
+	if r.phaseCallback != nil {
+		r.phaseCallback(phase, time.Since(start))
+	}
+}
+
+// Get the number of threads to use for the per-node calculations
+func (r *treeGeneratorImpl_v4) getThreadCount() int {
+	if r.threads > 0 {
+		return r.threads
+	}
+	threads := int(r.cfg.Smartnode.RewardsTreeThreads.Value.(uint64))
+	if threads > 0 {
+		return threads
+	}
+	return runtime.NumCPU()
+}
+
+// Load the collateral RPL checkpoint for this interval if checkpointing is enabled and one exists
+func (r *treeGeneratorImpl_v4) loadRplRewardsCheckpoint() (*rplRewardsCheckpoint, error) {
+	if r.checkpointPath == "" {
+		return nil, nil
+	}
+	return loadRplRewardsCheckpoint(r.checkpointPath, r.rewardsFile.Index, r.elSnapshotHeader.Number.Uint64(), r.elSnapshotHeader.Hash(), len(r.nodeAddresses))
+}
+
+// Save the collateral RPL progress if checkpointing is enabled
+func (r *treeGeneratorImpl_v4) saveRplRewardsCheckpoint(trueNodeEffectiveStakes map[common.Address]*big.Int, nextNodeIndex int) error {
+	if r.checkpointPath == "" {
+		return nil
+	}
+	stakes := make([]*QuotedBigInt, len(r.nodeAddresses))
+	for i, address := range r.nodeAddresses {
+		stakes[i] = &QuotedBigInt{}
+		stakes[i].Set(trueNodeEffectiveStakes[address])
+	}
+	return saveRplRewardsCheckpoint(r.checkpointPath, &rplRewardsCheckpoint{
+		Index:                   r.rewardsFile.Index,
+		ElBlockNumber:           r.elSnapshotHeader.Number.Uint64(),
+		ElBlockHash:             r.elSnapshotHeader.Hash(),
+		NodeCount:               len(r.nodeAddresses),
+		TrueNodeEffectiveStakes: stakes,
+		NextNodeIndex:           nextNodeIndex,
+		NodeRewards:             r.rewardsFile.NodeRewards,
+		NetworkRewards:          r.rewardsFile.NetworkRewards,
+		InvalidNetworkNodes:     r.rewardsFile.InvalidNetworkNodes,
+	})
+}
+
+// Look up the withdrawal address of every node that earned rewards, as of the snapshot block. Claimed rewards are sent
+// there, so it's recorded for any node where it's different from the node address.
+func (r *treeGeneratorImpl_v4) resolveWithdrawalAddresses() error {
+
+	addresses := make([]common.Address, 0, len(r.rewardsFile.NodeRewards))
+	for address := range r.rewardsFile.NodeRewards {
+		addresses = append(addresses, address)
+	}
+	nodeCount := uint64(len(addresses))
+	withdrawalAddresses := make([]common.Address, nodeCount)
+	for batchStartIndex := uint64(0); batchStartIndex < nodeCount; batchStartIndex += SmoothingPoolDetailsBatchSize {
+
+		// Get batch start & end index
+		iterationStartIndex := batchStartIndex
+		iterationEndIndex := batchStartIndex + SmoothingPoolDetailsBatchSize
+		if iterationEndIndex > nodeCount {
+			iterationEndIndex = nodeCount
+		}
+
+		// Load the addresses
+		var wg errgroup.Group
+		for iterationIndex := iterationStartIndex; iterationIndex < iterationEndIndex; iterationIndex++ {
+			iterationIndex := iterationIndex
+			wg.Go(func() error {
+				withdrawalAddress, err := storage.GetNodeWithdrawalAddress(r.rp, addresses[iterationIndex], r.opts)
+				if err != nil {
+					return err
+				}
+				withdrawalAddresses[iterationIndex] = withdrawalAddress
+				return nil
+			})
+		}
+		if err := wg.Wait(); err != nil {
+			return err
+		}
+	}
+
+	for i, address := range addresses {
+		if withdrawalAddresses[i] != address {
+			withdrawalAddress := withdrawalAddresses[i]
+			r.rewardsFile.NodeRewards[address].WithdrawalAddress = &withdrawalAddress
+		}
+	}
+	return nil
+
+}
+
+// Get the value of a uint256 view method that takes a node address for every node.
+// This uses multicall if it's enabled; if the multicall fails, it logs a warning and downgrades to individual calls
+// for the rest of the generation run.
+func (r *treeGeneratorImpl_v4) getNodeUint256Values(contractName string, method string, getIndividual func(common.Address) (*big.Int, error)) ([]*big.Int, error) {
+
+	if r.useMulticall {
+		values, err := r.getNodeUint256ValuesWithMulticall(contractName, method)
+		if err == nil {
+			return values, nil
+		}
+		r.log.Printlnf("%s WARNING: multicall failed (%s), switching to individual calls for the rest of generation.", r.logPrefix, err.Error())
+		r.useMulticall = false
+	}
+
+	nodeCount := uint64(len(r.nodeAddresses))
+	values := make([]*big.Int, nodeCount)
+	for batchStartIndex := uint64(0); batchStartIndex < nodeCount; batchStartIndex += SmoothingPoolDetailsBatchSize {
+
+		// Get batch start & end index
+		iterationStartIndex := batchStartIndex
+		iterationEndIndex := batchStartIndex + SmoothingPoolDetailsBatchSize
+		if iterationEndIndex > nodeCount {
+			iterationEndIndex = nodeCount
+		}
+
+		// Load the values
+		var wg errgroup.Group
+		for iterationIndex := iterationStartIndex; iterationIndex < iterationEndIndex; iterationIndex++ {
+			iterationIndex := iterationIndex
+			wg.Go(func() error {
+				address := r.nodeAddresses[iterationIndex]
+				value, err := getIndividual(address)
+				if err != nil {
+					return fmt.Errorf("error calling %s for node %s: %w", method, address.Hex(), err)
+				}
+				values[iterationIndex] = value
+				return nil
+			})
+		}
+		if err := wg.Wait(); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+
+}
+
+// Get the value of a uint256 view method that takes a node address for every node using multicall
+func (r *treeGeneratorImpl_v4) getNodeUint256ValuesWithMulticall(contractName string, method string) ([]*big.Int, error) {
+
+	contract, err := r.rp.GetContract(contractName, r.opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting %s contract: %w", contractName, err)
+	}
+	mc, err := newMulticaller(r.rp.Client, r.cfg.Smartnode.GetMulticallAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	argSets := make([][]interface{}, len(r.nodeAddresses))
+	for i, address := range r.nodeAddresses {
+		argSets[i] = []interface{}{address}
+	}
+	outputs, err := mc.callEach(r.opts, contract, method, argSets)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]*big.Int, len(outputs))
+	for i, output := range outputs {
+		if len(output) != 1 {
+			return nil, fmt.Errorf("%s returned %d values, expected 1", method, len(output))
+		}
+		value, ok := output[0].(*big.Int)
+		if !ok {
+			return nil, fmt.Errorf("%s returned a %T, expected a uint256", method, output[0])
+		}
+		values[i] = value
+	}
+	return values, nil
+
+}
@@ -3,8 +3,10 @@ package rewards
 import (
 	"fmt"
 	"math/big"
+	"os"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
@@ -143,7 +145,7 @@ func NewTreeGenerator(logger log.ColorLogger, logPrefix string, rp *rocketpool.R
 }
 
 func (t *TreeGenerator) GenerateTree() (*RewardsFile, error) {
-	return t.generatorImpl.generateTree(t.rp, t.cfg, t.bc)
+	return t.generateTreeImpl(t.generatorImpl)
 }
 
 func (t *TreeGenerator) ApproximateStakerShareOfSmoothingPool() (*big.Int, error) {
@@ -164,7 +166,33 @@ func (t *TreeGenerator) GenerateTreeWithRuleset(ruleset uint64) (*RewardsFile, e
 		return nil, fmt.Errorf("ruleset v%d does not exist", ruleset)
 	}
 
-	return info.generator.generateTree(t.rp, t.cfg, t.bc)
+	return t.generateTreeImpl(info.generator)
+}
+
+// Generate the tree with the provided implementation, then make sure the EL snapshot block wasn't reorged out while it
+// was running. A tree built on an orphaned block is discarded, along with any node snapshot saved from it, so the
+// next attempt starts over from the canonical chain.
+func (t *TreeGenerator) generateTreeImpl(impl treeGeneratorImpl) (*RewardsFile, error) {
+	rewardsFile, err := impl.generateTree(t.rp, t.cfg, t.bc)
+	if err != nil {
+		return nil, err
+	}
+	if t.elSnapshotHeader == nil {
+		return rewardsFile, nil
+	}
+
+	err = CheckELBlockCanonical(t.rp, t.elSnapshotHeader)
+	if err != nil {
+		t.logger.Printlnf("%s WARNING: %s; discarding the tree so it can be generated again.", t.logPrefix, err.Error())
+		if v4, ok := impl.(*treeGeneratorImpl_v4); ok && v4.nodeSnapshotPath != "" {
+			removeErr := os.Remove(v4.nodeSnapshotPath)
+			if removeErr != nil && !os.IsNotExist(removeErr) {
+				t.logger.Printlnf("%s WARNING: couldn't remove the node snapshot at %s: %s", t.logPrefix, v4.nodeSnapshotPath, removeErr.Error())
+			}
+		}
+		return nil, err
+	}
+	return rewardsFile, nil
 }
 
 func (t *TreeGenerator) ApproximateStakerShareOfSmoothingPoolWithRuleset(ruleset uint64) (*big.Int, error) {
@@ -175,3 +203,121 @@ func (t *TreeGenerator) ApproximateStakerShareOfSmoothingPoolWithRuleset(ruleset
 
 	return info.generator.approximateStakerShareOfSmoothingPool(t.rp, t.cfg, t.bc)
 }
+
+// Get the ruleset v4 generator, which the optional generation settings below apply to
+func (t *TreeGenerator) getV4Impl() (*treeGeneratorImpl_v4, error) {
+	info, exists := t.rewardsIntervalInfos[4]
+	if !exists {
+		return nil, fmt.Errorf("ruleset v4 does not exist")
+	}
+	impl, ok := info.generator.(*treeGeneratorImpl_v4)
+	if !ok {
+		return nil, fmt.Errorf("ruleset v4 has an unexpected generator type")
+	}
+	return impl, nil
+}
+
+// Overrides the RPL rewards split the generator would normally read from chain. This is only meant for generating trees for
+// synthetic test scenarios, and is never used by the daemons; a tree built with it will not match the canonical one.
+// It only applies to ruleset v4 and later. Pass nil to go back to using the on-chain split.
+func (t *TreeGenerator) SetRewardsSplitOverride(split *RewardsSplit) error {
+	impl, err := t.getV4Impl()
+	if err != nil {
+		return err
+	}
+	impl.rewardsSplitOverride = split
+	return nil
+}
+
+// Enables checkpointing of the collateral RPL calculation to the provided path, so a run that gets interrupted can resume
+// where it left off the next time the tree is generated for the same interval and EL block. It only applies to ruleset v4
+// and later. The caller is responsible for removing the checkpoint once the tree has been saved.
+func (t *TreeGenerator) SetCheckpointPath(path string) error {
+	impl, err := t.getV4Impl()
+	if err != nil {
+		return err
+	}
+	impl.checkpointPath = path
+	return nil
+}
+
+// Sets a function that will be called periodically with the number of nodes whose collateral RPL rewards have been calculated
+// so far. The callback is optional and only applies to ruleset v4 and later.
+func (t *TreeGenerator) SetProgressCallback(callback func(processed int, total int)) error {
+	impl, err := t.getV4Impl()
+	if err != nil {
+		return err
+	}
+	impl.progressCallback = callback
+	return nil
+}
+
+// Sets a function that will be called with the duration of each phase of the generation once it finishes.
+// The callback is optional and only applies to ruleset v4 and later.
+func (t *TreeGenerator) SetPhaseCallback(callback func(phase GenerationPhase, duration time.Duration)) error {
+	impl, err := t.getV4Impl()
+	if err != nil {
+		return err
+	}
+	impl.phaseCallback = callback
+	return nil
+}
+
+// Uses a shared cache for Beacon client queries, so generating several trees in a row doesn't repeat them.
+// It only applies to ruleset v4 and later.
+func (t *TreeGenerator) SetBeaconCache(cache *BeaconCache) error {
+	impl, err := t.getV4Impl()
+	if err != nil {
+		return err
+	}
+	impl.beaconCache = cache
+	return nil
+}
+
+// Includes a per-minipool breakdown of each node's smoothing pool ETH in the rewards file.
+// It only applies to ruleset v4 and later.
+func (t *TreeGenerator) SetIncludeMinipoolRewards(include bool) error {
+	impl, err := t.getV4Impl()
+	if err != nil {
+		return err
+	}
+	impl.includeMinipoolRewards = include
+	return nil
+}
+
+// Only keeps the provided node's entry in the rewards file and skips building the Merkle tree, for quickly checking a
+// single node's rewards. Every node's rewards still have to be calculated since they depend on each other.
+// It only applies to ruleset v4 and later.
+func (t *TreeGenerator) SetNodeFilter(address common.Address) error {
+	impl, err := t.getV4Impl()
+	if err != nil {
+		return err
+	}
+	impl.nodeFilter = &address
+	return nil
+}
+
+// Overrides the number of threads used to calculate the per-node rewards; 0 uses the Smartnode setting.
+// It only applies to ruleset v4 and later.
+func (t *TreeGenerator) SetThreadCount(threads int) error {
+	impl, err := t.getV4Impl()
+	if err != nil {
+		return err
+	}
+	impl.threads = threads
+	return nil
+}
+
+// Enables incremental generation: the per-node details from the snapshot at the previous path are reused for every node
+// that hasn't had its RPL stake or minipools change since, and the details used for this interval are saved to the
+// new path for the next one. Leave the previous path blank to query every node but still save a snapshot. It only
+// applies to ruleset v4 and later.
+func (t *TreeGenerator) SetNodeSnapshotPaths(previousPath string, path string) error {
+	impl, err := t.getV4Impl()
+	if err != nil {
+		return err
+	}
+	impl.previousSnapshotPath = previousPath
+	impl.nodeSnapshotPath = path
+	return nil
+}
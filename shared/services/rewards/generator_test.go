@@ -0,0 +1,63 @@
+package rewards
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/fatih/color"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+func TestRewardsSplitOverride(t *testing.T) {
+	cfg := config.NewRocketPoolConfig(t.TempDir(), true)
+	header := &types.Header{
+		Number: big.NewInt(1),
+	}
+	treegen, err := NewTreeGenerator(log.NewColorLogger(color.FgWhite), "[Test]", nil, cfg, nil, MainnetV4Interval, time.Unix(0, 0), time.Unix(1, 0), 1, header, 1)
+	if err != nil {
+		t.Fatalf("error creating tree generator: %s", err.Error())
+	}
+	impl, err := treegen.getV4Impl()
+	if err != nil {
+		t.Fatalf("error getting the v4 generator: %s", err.Error())
+	}
+
+	// The overridden split is used instead of the on-chain one, which would need a client to read
+	override := RewardsSplit{
+		NodeOperator: eth.EthToWei(0.7),
+		TrustedNode:  eth.EthToWei(0.05),
+		ProtocolDao:  eth.EthToWei(0.25),
+	}
+	err = treegen.SetRewardsSplitOverride(&override)
+	if err != nil {
+		t.Fatalf("error overriding the rewards split: %s", err.Error())
+	}
+	split, err := impl.getRewardsSplit()
+	if err != nil {
+		t.Fatalf("error getting the rewards split: %s", err.Error())
+	}
+	if split.NodeOperator.Cmp(override.NodeOperator) != 0 || split.TrustedNode.Cmp(override.TrustedNode) != 0 || split.ProtocolDao.Cmp(override.ProtocolDao) != 0 {
+		t.Fatalf("got a split of %s/%s/%s instead of the overridden one", split.NodeOperator, split.TrustedNode, split.ProtocolDao)
+	}
+
+	// Splits that add up to less than 1 are allowed, but ones that are incomplete or add up to more than 1 aren't
+	override.ProtocolDao = eth.EthToWei(0.2)
+	_, err = impl.getRewardsSplit()
+	if err != nil {
+		t.Fatalf("a split that adds up to less than 1 should have been allowed: %s", err.Error())
+	}
+	override.ProtocolDao = eth.EthToWei(0.3)
+	_, err = impl.getRewardsSplit()
+	if err == nil {
+		t.Fatalf("a split that adds up to more than 1 should have been rejected")
+	}
+	override.ProtocolDao = nil
+	_, err = impl.getRewardsSplit()
+	if err == nil {
+		t.Fatalf("a split with a missing value should have been rejected")
+	}
+}
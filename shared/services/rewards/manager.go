@@ -2,13 +2,16 @@
 package rewards
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
-	"math"
 	"math/big"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
@@ -22,10 +25,17 @@ import (
 	"github.com/rocket-pool/rocketpool-go/rewards"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
 )
 
 const (
 	scanningWindowSize uint64 = 10000
+
+	// The timeout for downloading a rewards file from a URL
+	rewardsFileDownloadTimeout time.Duration = 5 * time.Minute
+
+	// The largest rewards file that will be downloaded from a URL, both as sent and once it's decompressed
+	maxRewardsFileDownloadSize int64 = 1 << 30
 )
 
 // Gets the intervals the node can claim and the intervals that have already been claimed
@@ -95,9 +105,10 @@ func GetIntervalInfo(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, no
 	info.StartTime = event.IntervalStartTime
 	info.EndTime = event.IntervalEndTime
 	merkleRootCanon := event.MerkleRoot
+	info.MerkleRoot = merkleRootCanon
 
 	// Check if the tree file exists
-	info.TreeFilePath = cfg.Smartnode.GetRewardsTreePath(interval, true)
+	info.TreeFilePath = cfg.Smartnode.GetExistingRewardsTreePath(interval, true)
 	_, err = os.Stat(info.TreeFilePath)
 	if os.IsNotExist(err) {
 		info.TreeFileExists = false
@@ -107,17 +118,11 @@ func GetIntervalInfo(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, no
 	info.TreeFileExists = true
 
 	// Unmarshal it
-	fileBytes, err := ioutil.ReadFile(info.TreeFilePath)
-	if err != nil {
-		err = fmt.Errorf("error reading %s: %w", info.TreeFilePath, err)
-		return
-	}
-	var proofWrapper RewardsFile
-	err = json.Unmarshal(fileBytes, &proofWrapper)
+	proofWrapper, err := LoadRewardsFile(info.TreeFilePath)
 	if err != nil {
-		err = fmt.Errorf("error deserializing %s: %w", info.TreeFilePath, err)
 		return
 	}
+	info.RewardsFile = proofWrapper
 
 	// Make sure the Merkle root has the expected value
 	merkleRootFromFile := common.HexToHash(proofWrapper.MerkleRoot)
@@ -168,6 +173,15 @@ func GetRewardSnapshotEvent(rp *rocketpool.RocketPool, cfg *config.RocketPoolCon
 		// Get the event details for this interval
 		return GetUpgradedRewardSnapshotEvent(cfg, rp, interval, big.NewInt(1), blockNumber, blockNumber)
 	} else {
+		// Intervals that haven't been submitted yet don't have an event to search for
+		currentIndex, err := rewards.GetRewardIndex(rp, nil)
+		if err != nil {
+			return rewards.RewardsEvent{}, fmt.Errorf("error getting current rewards index: %w", err)
+		}
+		if interval >= currentIndex.Uint64() {
+			return rewards.RewardsEvent{}, NewGenerationError(ErrIntervalNotFound, fmt.Errorf("rewards interval %d hasn't been submitted yet; the current interval is %d", interval, currentIndex.Uint64()))
+		}
+
 		var latestKnownBlock uint64
 		var numberOfIntervalsPassed uint64
 		if len(prerecordedIntervals) == 0 {
@@ -221,11 +235,14 @@ func GetRewardSnapshotEvent(rp *rocketpool.RocketPool, cfg *config.RocketPoolCon
 			}
 			// Scan the window around that block
 			startBlock := big.NewInt(0).Sub(headerToCheck.Number, scanningWindow)
+			if startBlock.Sign() < 0 {
+				startBlock.SetUint64(0)
+			}
 			endBlock := big.NewInt(0).Add(headerToCheck.Number, scanningWindow)
 			if endBlock.Uint64() > currentBlock.Number.Uint64() {
 				endBlock = big.NewInt(0).Set(currentBlock.Number)
 			}
-			event, err = GetUpgradedRewardSnapshotEvent(cfg, rp, interval, big.NewInt(int64(eventLogInterval)), startBlock, endBlock)
+			event, err = getRewardSnapshotEventAdaptive(cfg, rp, interval, uint64(eventLogInterval), startBlock.Uint64(), endBlock.Uint64())
 			if err != nil {
 				if err.Error() == fmt.Sprintf("reward snapshot for interval %d not found", interval) {
 					// This isn't a great way to check if an event wasn't found, but it'll do for now
@@ -243,7 +260,7 @@ func GetRewardSnapshotEvent(rp *rocketpool.RocketPool, cfg *config.RocketPoolCon
 		}
 
 		if !found {
-			err = fmt.Errorf("rewards event for interval %d could not be found", interval)
+			err = fmt.Errorf("rewards event for interval %d could not be found even though the interval has been submitted; your Execution client may be missing its event logs", interval)
 			return event, err
 		}
 	}
@@ -252,83 +269,113 @@ func GetRewardSnapshotEvent(rp *rocketpool.RocketPool, cfg *config.RocketPoolCon
 
 }
 
-// Get the number of the latest EL block that was created before the given timestamp
+// The average time between EL blocks, used to estimate where a timestamp falls before searching for it
+const averageElBlockTime uint64 = 12
+
+// How many blocks on either side of the estimate the initial search bounds cover
+const elBlockSearchMargin uint64 = 64
+
+// Get the header of the latest EL block that was created at or before the given timestamp.
+// The search is seeded with an estimate from the average block time, then bisects on the block timestamps, so it
+// only needs a few dozen requests at most.
 func GetELBlockHeaderForTime(targetTime time.Time, rp *rocketpool.RocketPool) (*types.Header, error) {
 
-	// Get the latest block's timestamp
-	latestBlockHeader, err := rp.Client.HeaderByNumber(context.Background(), nil)
-	if err != nil {
-		return nil, fmt.Errorf("error getting latest block header: %w", err)
+	target := uint64(targetTime.Unix())
+	getHeader := func(number uint64) (*types.Header, error) {
+		header, err := rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(number))
+		if err != nil {
+			return nil, fmt.Errorf("error getting EL block %d: %w", number, err)
+		}
+		return header, nil
 	}
-	latestBlock := latestBlockHeader.Number
 
-	// Get the block that Rocket Pool deployed to the chain on, use that as the search start
-	deployBlockHash := crypto.Keccak256Hash([]byte("deploy.block"))
-	deployBlock, err := rp.RocketStorage.GetUint(nil, deployBlockHash)
+	// Get the latest block, which is the answer if the target time hasn't been reached yet
+	latestHeader, err := rp.Client.HeaderByNumber(context.Background(), nil)
 	if err != nil {
-		return nil, fmt.Errorf("error getting Rocket Pool deployment block: %w", err)
+		return nil, fmt.Errorf("error getting latest block header: %w", err)
 	}
+	if latestHeader.Time <= target {
+		return latestHeader, nil
+	}
+	latestBlock := latestHeader.Number.Uint64()
 
-	// Get half the distance between the protocol deployment and right now
-	delta := big.NewInt(0).Sub(latestBlock, deployBlock)
-	delta.Div(delta, big.NewInt(2))
-
-	// Start at the halfway point
-	candidateBlockNumber := big.NewInt(0).Sub(latestBlock, delta)
-	candidateBlock, err := rp.Client.HeaderByNumber(context.Background(), candidateBlockNumber)
-	if err != nil {
-		return nil, fmt.Errorf("error getting EL block %d: %w", candidateBlock, err)
+	// Estimate the target block from the average block time
+	blocksBack := (latestHeader.Time - target) / averageElBlockTime
+	if blocksBack > latestBlock {
+		blocksBack = latestBlock
 	}
-	bestBlock := candidateBlock
-	pivotSize := candidateBlock.Number.Uint64()
-	minimumDistance := +math.Inf(1)
-	targetTimeUnix := float64(targetTime.Unix())
+	estimate := latestBlock - blocksBack
 
+	// Widen the lower bound until it's at or before the target time
+	margin := elBlockSearchMargin
+	high := latestBlock
+	low := estimate
+	var lowHeader *types.Header
 	for {
-		// Get the distance from the candidate block to the target time
-		candidateTime := float64(candidateBlock.Time)
-		delta := targetTimeUnix - candidateTime
-		distance := math.Abs(delta)
-
-		// If it's better, replace the best candidate with it
-		if distance < minimumDistance {
-			minimumDistance = distance
-			bestBlock = candidateBlock
-		} else if pivotSize == 1 {
-			// If the pivot is down to size 1 and we didn't find anything better after another iteration, this is the best block!
-			for candidateTime > targetTimeUnix {
-				// Get the previous block if this one happened after the target time
-				candidateBlockNumber.Sub(candidateBlockNumber, big.NewInt(1))
-				candidateBlock, err = rp.Client.HeaderByNumber(context.Background(), candidateBlockNumber)
-				if err != nil {
-					return nil, fmt.Errorf("error getting EL block %d: %w", candidateBlock, err)
-				}
-				candidateTime = float64(candidateBlock.Time)
-				bestBlock = candidateBlock
-			}
-			return bestBlock, nil
-		}
-
-		// Iterate over the correct half, setting the pivot to the halfway point of that half (rounded up)
-		pivotSize = uint64(math.Ceil(float64(pivotSize) / 2))
-		if delta < 0 {
-			// Go left
-			candidateBlockNumber.Sub(candidateBlockNumber, big.NewInt(int64(pivotSize)))
+		if low > margin {
+			low -= margin
 		} else {
-			// Go right
-			candidateBlockNumber.Add(candidateBlockNumber, big.NewInt(int64(pivotSize)))
+			low = 0
+		}
+		lowHeader, err = getHeader(low)
+		if err != nil {
+			return nil, err
 		}
+		if lowHeader.Time <= target {
+			break
+		}
+		if low == 0 {
+			return nil, fmt.Errorf("the target time %s is before the first EL block", targetTime)
+		}
+		high = low
+		margin *= 2
+	}
 
-		// Clamp the new candidate to the latest block
-		if candidateBlockNumber.Uint64() > (latestBlock.Uint64() - 1) {
-			candidateBlockNumber.SetUint64(latestBlock.Uint64() - 1)
+	// Narrow the upper bound down to a block after the target time, widening it if the estimate was too low
+	margin = elBlockSearchMargin
+	for candidate := estimate + margin; candidate < high; candidate = estimate + margin {
+		header, err := getHeader(candidate)
+		if err != nil {
+			return nil, err
+		}
+		if header.Time > target {
+			high = candidate
+			break
 		}
+		low = candidate
+		lowHeader = header
+		margin *= 2
+	}
 
-		candidateBlock, err = rp.Client.HeaderByNumber(context.Background(), candidateBlockNumber)
+	// Bisect until the bounds are adjacent; the lower bound is always at or before the target, the upper bound after it
+	for high-low > 1 {
+		mid := low + (high-low)/2
+		header, err := getHeader(mid)
 		if err != nil {
-			return nil, fmt.Errorf("error getting EL block %d: %w", candidateBlock, err)
+			return nil, err
 		}
+		if header.Time <= target {
+			low = mid
+			lowHeader = header
+		} else {
+			high = mid
+		}
+	}
+	return lowHeader, nil
+
+}
+
+// Check that the provided EL block is still the canonical block at its height. Returns an error if a chain reorg has
+// replaced it, since anything calculated at that block would be based on an orphaned chain.
+func CheckELBlockCanonical(rp *rocketpool.RocketPool, header *types.Header) error {
+	canonicalHeader, err := rp.Client.HeaderByNumber(context.Background(), header.Number)
+	if err != nil {
+		return NewGenerationError(ErrELBlockNotFound, fmt.Errorf("error getting EL block %s to check for a reorg: %w", header.Number.String(), err))
+	}
+	if canonicalHeader.Hash() != header.Hash() {
+		return NewGenerationError(ErrChainReorg, fmt.Errorf("chain reorg detected: EL block %s had hash %s when generation started, but the canonical block at that height is now %s", header.Number.String(), header.Hash().Hex(), canonicalHeader.Hash().Hex()))
 	}
+	return nil
 }
 
 // Downloads a single rewards file
@@ -377,7 +424,11 @@ func DownloadRewardsFile(cfg *config.RocketPoolConfig, interval uint64, cid stri
 			}
 
 			// Write the file
-			err = ioutil.WriteFile(rewardsTreePath, decompressedBytes, 0644)
+			fileMode, err := cfg.Smartnode.GetRewardsTreeFileMode()
+			if err != nil {
+				return fmt.Errorf("error getting rewards tree file permissions: %w", err)
+			}
+			err = files.WriteFileAtomic(rewardsTreePath, decompressedBytes, fileMode)
 			if err != nil {
 				return fmt.Errorf("error saving interval %d file to %s: %w", interval, rewardsTreePath, err)
 			}
@@ -389,6 +440,139 @@ func DownloadRewardsFile(cfg *config.RocketPoolConfig, interval uint64, cid stri
 
 }
 
+// Load a rewards file from disk, decompressing it first if it has one of the compressed file extensions
+func LoadRewardsFile(path string) (*RewardsFile, error) {
+	if strings.HasSuffix(path, config.RewardsTreeBinaryExtension) {
+		return LoadRewardsFileBinary(path)
+	}
+
+	fileBytes, err := ReadRewardsFileBytes(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rewardsFile RewardsFile
+	err = json.Unmarshal(fileBytes, &rewardsFile)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing %s: %w", path, err)
+	}
+	return &rewardsFile, nil
+}
+
+// Read the serialized JSON of the rewards file at the provided path, decompressing it if its extension says it's compressed
+func ReadRewardsFileBytes(path string) ([]byte, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	fileBytes, err = decompressRewardsFileBytes(path, fileBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing %s: %w", path, err)
+	}
+	return fileBytes, nil
+}
+
+// Gzip a serialized rewards file and save it to the provided path
+func SaveRewardsFileGzip(fileBytes []byte, path string, mode os.FileMode) error {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	_, err := writer.Write(fileBytes)
+	if err != nil {
+		return fmt.Errorf("error compressing rewards file: %w", err)
+	}
+	err = writer.Close()
+	if err != nil {
+		return fmt.Errorf("error compressing rewards file: %w", err)
+	}
+	err = files.WriteFileAtomic(path, buffer.Bytes(), mode)
+	if err != nil {
+		return fmt.Errorf("error saving compressed rewards file to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Decompresses a gzipped rewards file
+func gunzipFile(compressedBytes []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(compressedBytes))
+	if err != nil {
+		return nil, fmt.Errorf("error creating gzip reader: %w", err)
+	}
+	defer reader.Close()
+	decompressedBytes, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing gzipped rewards file: %w", err)
+	}
+	return decompressedBytes, nil
+}
+
+// Download a rewards file from an http(s) URL, such as a community mirror, and return it only if its node rewards produce
+// the canonical Merkle root for the interval and every node's proof leads to it. The file may be plain JSON, zstd compressed
+// with the IPFS file extension, or gzipped, and is rejected if it's larger than maxRewardsFileDownloadSize before or
+// after decompression.
+func LoadRewardsFileFromUrl(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, interval uint64, fileUrl string) (*RewardsFile, error) {
+
+	// Check the URL
+	parsedUrl, err := url.Parse(fileUrl)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing URL %s: %w", fileUrl, err)
+	}
+	if parsedUrl.Scheme != "http" && parsedUrl.Scheme != "https" {
+		return nil, fmt.Errorf("%s is not an http or https URL", fileUrl)
+	}
+
+	// Get the canonical root
+	event, err := GetRewardSnapshotEvent(rp, cfg, interval)
+	if err != nil {
+		return nil, fmt.Errorf("error getting event for interval %d: %w", interval, err)
+	}
+
+	// Download the file
+	client := http.Client{
+		Timeout: rewardsFileDownloadTimeout,
+	}
+	resp, err := client.Get(fileUrl)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading %s: %w", fileUrl, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading %s failed with status %s", fileUrl, resp.Status)
+	}
+	fileBytes, err := readAllLimited(resp.Body, maxRewardsFileDownloadSize)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response bytes from %s: %w", fileUrl, err)
+	}
+	fileBytes, err = decompressRewardsFileBytesLimited(parsedUrl.Path, fileBytes, maxRewardsFileDownloadSize)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing %s: %w", fileUrl, err)
+	}
+
+	// Deserialize it
+	var rewardsFile RewardsFile
+	err = json.Unmarshal(fileBytes, &rewardsFile)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing %s: %w", fileUrl, err)
+	}
+	err = VerifyRewardsFile(&rewardsFile, fileUrl, interval, event.MerkleRoot)
+	if err != nil {
+		return nil, err
+	}
+	return &rewardsFile, nil
+
+}
+
+// Decompresses the bytes of a rewards file based on the extension of its name; files without a compression extension
+// are returned as-is
+func decompressRewardsFileBytes(name string, fileBytes []byte) ([]byte, error) {
+	if strings.HasSuffix(name, config.RewardsTreeIpfsExtension) {
+		return decompressFile(fileBytes)
+	}
+	if strings.HasSuffix(name, config.RewardsTreeGzipExtension) {
+		return gunzipFile(fileBytes)
+	}
+	return fileBytes, nil
+}
+
 // Decompresses a rewards file
 func decompressFile(compressedBytes []byte) ([]byte, error) {
 	decoder, err := zstd.NewReader(nil)
@@ -403,3 +587,39 @@ func decompressFile(compressedBytes []byte) ([]byte, error) {
 
 	return decompressedBytes, nil
 }
+
+// Decompresses the bytes of a rewards file based on the extension of its name like decompressRewardsFileBytes, but fails
+// instead of using more than the provided number of bytes for the decompressed file
+func decompressRewardsFileBytesLimited(name string, fileBytes []byte, limit int64) ([]byte, error) {
+	var reader io.Reader
+	if strings.HasSuffix(name, config.RewardsTreeIpfsExtension) {
+		decoder, err := zstd.NewReader(bytes.NewReader(fileBytes))
+		if err != nil {
+			return nil, fmt.Errorf("error creating compression decoder: %w", err)
+		}
+		defer decoder.Close()
+		reader = decoder
+	} else if strings.HasSuffix(name, config.RewardsTreeGzipExtension) {
+		gzipReader, err := gzip.NewReader(bytes.NewReader(fileBytes))
+		if err != nil {
+			return nil, fmt.Errorf("error creating gzip reader: %w", err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	} else {
+		return fileBytes, nil
+	}
+	return readAllLimited(reader, limit)
+}
+
+// Reads everything from the reader, failing if there's more than the provided number of bytes
+func readAllLimited(reader io.Reader, limit int64) ([]byte, error) {
+	readBytes, err := io.ReadAll(io.LimitReader(reader, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(readBytes)) > limit {
+		return nil, fmt.Errorf("the file is larger than the %d byte limit", limit)
+	}
+	return readBytes, nil
+}
@@ -0,0 +1,62 @@
+package rewards
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+func TestLoadGzipOnlyRewardsTree(t *testing.T) {
+	dataPath := t.TempDir()
+	err := os.MkdirAll(filepath.Join(dataPath, config.RewardsTreesFolder), 0755)
+	if err != nil {
+		t.Fatalf("error creating the rewards trees folder: %s", err.Error())
+	}
+	cfg := config.NewRocketPoolConfig(dataPath, true)
+	cfg.Smartnode.DataPath.Value = dataPath
+
+	// Save the tree the way the watchtower does when compression is enabled, so there's no JSON copy
+	rewardsFile := makeTestRewardsFile(20)
+	rewardsFile.Index = 3
+	err = buildMerkleTree(rewardsFile)
+	if err != nil {
+		t.Fatalf("error building Merkle tree: %s", err.Error())
+	}
+	fileBytes, err := json.Marshal(rewardsFile)
+	if err != nil {
+		t.Fatalf("error serializing rewards file: %s", err.Error())
+	}
+	gzipPath := cfg.Smartnode.GetRewardsTreeGzipPath(rewardsFile.Index, true)
+	err = SaveRewardsFileGzip(fileBytes, gzipPath, 0644)
+	if err != nil {
+		t.Fatalf("error saving gzipped rewards file: %s", err.Error())
+	}
+
+	// The claim path has to find the gzipped file and get the same tree back out of it
+	path := cfg.Smartnode.GetExistingRewardsTreePath(rewardsFile.Index, true)
+	if path != gzipPath {
+		t.Fatalf("expected the existing tree path to be %s but it was %s", gzipPath, path)
+	}
+	loadedFile, err := LoadRewardsFile(path)
+	if err != nil {
+		t.Fatalf("error loading %s: %s", path, err.Error())
+	}
+	err = VerifyRewardsFile(loadedFile, path, rewardsFile.Index, common.HexToHash(rewardsFile.MerkleRoot))
+	if err != nil {
+		t.Fatalf("error verifying %s: %s", path, err.Error())
+	}
+
+	// Changing a node's rewards has to break verification even though the recorded root is left alone
+	for _, rewardsForNode := range loadedFile.NodeRewards {
+		rewardsForNode.SmoothingPoolEth = NewQuotedBigInt(1)
+		break
+	}
+	err = VerifyRewardsFile(loadedFile, path, rewardsFile.Index, common.HexToHash(rewardsFile.MerkleRoot))
+	if err == nil {
+		t.Fatalf("verifying a tampered copy of %s succeeded", path)
+	}
+}
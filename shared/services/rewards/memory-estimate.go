@@ -0,0 +1,76 @@
+package rewards
+
+import (
+	"math/big"
+	"math/bits"
+	"unsafe"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+const (
+	// Rough per-entry bookkeeping cost of a Go map (bucket share, tophash, overflow pointers)
+	mapEntryOverhead uint64 = 48
+
+	// A big.Int holding a wei value uses a slice of 4 64-bit words on top of its header
+	bigIntSize uint64 = uint64(unsafe.Sizeof(big.Int{})) + 4*8
+
+	// Size of a serialized Merkle leaf: address + network + RPL + ETH
+	merkleLeafSize uint64 = common.AddressLength + 32 + 32 + 32
+
+	// Approximate size of a node's entry in the JSON rewards file, excluding its proof
+	jsonNodeEntrySize uint64 = 320
+
+	// The length of a hex-encoded hash in a proof, including the 0x prefix and the JSON quotes
+	jsonProofEntrySize uint64 = 2 + 2 + 2*common.HashLength + 1
+)
+
+// An estimate of the memory used by the tree generator
+type MemoryEstimate struct {
+	NodeCount           uint64
+	MinipoolCount       uint64
+	RplCalculationBytes uint64
+	MerkleTreeBytes     uint64
+	SerializationBytes  uint64
+	PeakBytes           uint64
+}
+
+// Estimates the peak memory the tree generator will need for a network with the provided number of nodes and minipools.
+// This is based on the sizes of the per-node and per-minipool structures kept in memory during calculateRplRewards,
+// generateMerkleTree, and serialization; it won't account for runtime overhead like garbage that hasn't been collected yet.
+func EstimateTreeGenerationMemory(nodeCount uint64, minipoolCount uint64) MemoryEstimate {
+
+	estimate := MemoryEstimate{
+		NodeCount:     nodeCount,
+		MinipoolCount: minipoolCount,
+	}
+
+	// Per-node structures used during the RPL calculation: the address list, the effective stake, and the rewards entry
+	nodeRewardsInfoSize := uint64(unsafe.Sizeof(NodeRewardsInfo{})) + 3*bigIntSize + common.AddressLength + 8 + mapEntryOverhead
+	perNodeRpl := common.AddressLength + (8 + bigIntSize) + nodeRewardsInfoSize
+
+	// Per-minipool structures: the staking minipool details, the validator status, and the pubkey list entry
+	perMinipool := uint64(unsafe.Sizeof(minipool.MinipoolDetails{})) +
+		uint64(unsafe.Sizeof(beacon.ValidatorStatus{})) + uint64(unsafe.Sizeof(rptypes.ValidatorPubkey{})) + mapEntryOverhead +
+		uint64(unsafe.Sizeof(rptypes.ValidatorPubkey{}))
+
+	estimate.RplCalculationBytes = nodeCount*perNodeRpl + minipoolCount*perMinipool
+
+	// The Merkle tree holds every leaf plus a full binary tree of hashes over them, and each node gets a proof
+	proofDepth := uint64(bits.Len64(nodeCount))
+	leaves := nodeCount * (merkleLeafSize + 24)
+	hashes := 2 * nodeCount * common.HashLength
+	proofs := nodeCount * proofDepth * (uint64(unsafe.Sizeof("")) + 2 + 2*common.HashLength)
+	estimate.MerkleTreeBytes = leaves + hashes + proofs
+
+	// Serializing the file produces the JSON buffer while everything else is still alive
+	estimate.SerializationBytes = nodeCount * (jsonNodeEntrySize + proofDepth*jsonProofEntrySize)
+
+	// Nothing is released until the file has been written, so the peak is the sum of everything
+	estimate.PeakBytes = estimate.RplCalculationBytes + estimate.MerkleTreeBytes + estimate.SerializationBytes
+	return estimate
+
+}
@@ -0,0 +1,123 @@
+package rewards
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// The number of calls to bundle into a single multicall request
+const MulticallBatchSize int = 500
+
+// The aggregate3 function of the Multicall3 contract
+const multicall3Abi string = `[{"inputs":[{"components":[{"internalType":"address","name":"target","type":"address"},{"internalType":"bool","name":"allowFailure","type":"bool"},{"internalType":"bytes","name":"callData","type":"bytes"}],"internalType":"struct Multicall3.Call3[]","name":"calls","type":"tuple[]"}],"name":"aggregate3","outputs":[{"components":[{"internalType":"bool","name":"success","type":"bool"},{"internalType":"bytes","name":"returnData","type":"bytes"}],"internalType":"struct Multicall3.Result[]","name":"returnData","type":"tuple[]"}],"stateMutability":"payable","type":"function"}]`
+
+type multicall3Call struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+type multicall3Result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// Bundles contract calls into Multicall3 requests
+type multicaller struct {
+	client  rocketpool.ExecutionClient
+	address common.Address
+	abi     abi.ABI
+}
+
+// Create a new multicaller for the Multicall3 contract at the provided address
+func newMulticaller(client rocketpool.ExecutionClient, address common.Address) (*multicaller, error) {
+	multicallAbi, err := abi.JSON(strings.NewReader(multicall3Abi))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Multicall3 ABI: %w", err)
+	}
+	return &multicaller{
+		client:  client,
+		address: address,
+		abi:     multicallAbi,
+	}, nil
+}
+
+// Call a contract method once for each set of arguments, bundling the calls into batches of MulticallBatchSize.
+// Returns the unpacked outputs of each call in the same order as the arguments. Any failed call fails the whole set.
+func (m *multicaller) callEach(opts *bind.CallOpts, contract *rocketpool.Contract, method string, argSets [][]interface{}) ([][]interface{}, error) {
+
+	ctx := context.Background()
+	var blockNumber *big.Int
+	if opts != nil {
+		if opts.Context != nil {
+			ctx = opts.Context
+		}
+		blockNumber = opts.BlockNumber
+	}
+
+	outputs := make([][]interface{}, 0, len(argSets))
+	for batchStart := 0; batchStart < len(argSets); batchStart += MulticallBatchSize {
+		batchEnd := batchStart + MulticallBatchSize
+		if batchEnd > len(argSets) {
+			batchEnd = len(argSets)
+		}
+
+		// Encode the calls
+		calls := make([]multicall3Call, 0, batchEnd-batchStart)
+		for _, args := range argSets[batchStart:batchEnd] {
+			callData, err := contract.ABI.Pack(method, args...)
+			if err != nil {
+				return nil, fmt.Errorf("error encoding %s call: %w", method, err)
+			}
+			calls = append(calls, multicall3Call{
+				Target:       *contract.Address,
+				AllowFailure: false,
+				CallData:     callData,
+			})
+		}
+		input, err := m.abi.Pack("aggregate3", calls)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding multicall: %w", err)
+		}
+
+		// Run the batch
+		response, err := m.client.CallContract(ctx, ethereum.CallMsg{
+			To:   &m.address,
+			Data: input,
+		}, blockNumber)
+		if err != nil {
+			return nil, fmt.Errorf("error running multicall for %s: %w", method, err)
+		}
+		unpacked, err := m.abi.Unpack("aggregate3", response)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding multicall response for %s: %w", method, err)
+		}
+		results := *abi.ConvertType(unpacked[0], new([]multicall3Result)).(*[]multicall3Result)
+		if len(results) != len(calls) {
+			return nil, fmt.Errorf("multicall for %s returned %d results but %d calls were made", method, len(results), len(calls))
+		}
+
+		// Decode each result
+		for i, result := range results {
+			if !result.Success {
+				return nil, fmt.Errorf("call %d of the multicall for %s failed", batchStart+i, method)
+			}
+			output, err := contract.ABI.Unpack(method, result.ReturnData)
+			if err != nil {
+				return nil, fmt.Errorf("error decoding %s result: %w", method, err)
+			}
+			outputs = append(outputs, output)
+		}
+	}
+
+	return outputs, nil
+
+}
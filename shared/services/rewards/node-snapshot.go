@@ -0,0 +1,183 @@
+package rewards
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// The number of minipool addresses to include in a single status update log query
+const minipoolLogAddressBatchSize int = 1000
+
+// The per-node Execution layer details a rewards interval was calculated with, saved so the next interval only has to
+// query the nodes that changed in between
+type nodeSnapshot struct {
+	Index         uint64                                `json:"index"`
+	ElBlockNumber uint64                                `json:"elBlockNumber"`
+	Nodes         map[common.Address]*nodeSnapshotEntry `json:"nodes"`
+}
+
+// The details of a single node in a node snapshot
+type nodeSnapshotEntry struct {
+	RplStake         *QuotedBigInt              `json:"rplStake"`
+	Minipools        []common.Address           `json:"minipools"`
+	StakingMinipools []minipool.MinipoolDetails `json:"stakingMinipools"`
+}
+
+// Load the node snapshot at the provided path. Returns nil if there isn't one.
+func loadNodeSnapshot(path string) (*nodeSnapshot, error) {
+
+	fileBytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading node snapshot %s: %w", path, err)
+	}
+
+	var snapshot nodeSnapshot
+	err = json.Unmarshal(fileBytes, &snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing node snapshot %s: %w", path, err)
+	}
+	return &snapshot, nil
+
+}
+
+// Save a node snapshot to the provided path
+func saveNodeSnapshot(path string, snapshot *nodeSnapshot) error {
+	bytes, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("error serializing node snapshot: %w", err)
+	}
+	err = files.WriteFileAtomic(path, bytes, 0644)
+	if err != nil {
+		return fmt.Errorf("error saving node snapshot to %s: %w", path, err)
+	}
+	return nil
+}
+
+// Get the nodes whose RPL stake or minipools may have changed after the snapshot was taken, up to and including the
+// provided block. This covers RPL being staked, withdrawn, or slashed, minipools being created or destroyed, and any
+// status change of a minipool the snapshot knows about.
+func getNodesChangedSinceSnapshot(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, snapshot *nodeSnapshot, toBlock uint64, opts *bind.CallOpts) (map[common.Address]bool, error) {
+
+	changedNodes := map[common.Address]bool{}
+	if toBlock <= snapshot.ElBlockNumber {
+		return changedNodes, nil
+	}
+	fromBlockBig := big.NewInt(0).SetUint64(snapshot.ElBlockNumber + 1)
+	toBlockBig := big.NewInt(0).SetUint64(toBlock)
+
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+	intervalSize := big.NewInt(int64(eventLogInterval))
+
+	// RPL stake changes, which all have the node as the first indexed topic
+	rocketNodeStaking, err := rp.GetContract("rocketNodeStaking", opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rocketNodeStaking contract: %w", err)
+	}
+	stakeEventIds, err := getEventIds(rocketNodeStaking.ABI, "RPLStaked", "RPLWithdrawn", "RPLSlashed")
+	if err != nil {
+		return nil, err
+	}
+	logs, err := eth.FilterContractLogs(rp, "rocketNodeStaking", eth.FilterQuery{
+		FromBlock: fromBlockBig,
+		ToBlock:   toBlockBig,
+		Topics:    [][]common.Hash{stakeEventIds},
+	}, intervalSize, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting RPL stake events: %w", err)
+	}
+	for _, log := range logs {
+		if len(log.Topics) > 1 {
+			changedNodes[common.BytesToAddress(log.Topics[1].Bytes())] = true
+		}
+	}
+
+	// Minipool creation and destruction, which have the node as the second indexed topic
+	rocketMinipoolManager, err := rp.GetContract("rocketMinipoolManager", opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rocketMinipoolManager contract: %w", err)
+	}
+	minipoolEventIds, err := getEventIds(rocketMinipoolManager.ABI, "MinipoolCreated", "MinipoolDestroyed")
+	if err != nil {
+		return nil, err
+	}
+	logs, err = eth.FilterContractLogs(rp, "rocketMinipoolManager", eth.FilterQuery{
+		FromBlock: fromBlockBig,
+		ToBlock:   toBlockBig,
+		Topics:    [][]common.Hash{minipoolEventIds},
+	}, intervalSize, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool creation events: %w", err)
+	}
+	for _, log := range logs {
+		if len(log.Topics) > 2 {
+			changedNodes[common.BytesToAddress(log.Topics[2].Bytes())] = true
+		}
+	}
+
+	// Status changes of existing minipools, such as a minipool starting to stake or becoming withdrawable
+	minipoolAbi, err := rp.GetABI("rocketMinipool", opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rocketMinipool ABI: %w", err)
+	}
+	statusEventIds, err := getEventIds(minipoolAbi, "StatusUpdated")
+	if err != nil {
+		return nil, err
+	}
+	minipoolOwners := map[common.Address]common.Address{}
+	minipoolAddresses := []common.Address{}
+	for nodeAddress, entry := range snapshot.Nodes {
+		for _, minipoolAddress := range entry.Minipools {
+			minipoolOwners[minipoolAddress] = nodeAddress
+			minipoolAddresses = append(minipoolAddresses, minipoolAddress)
+		}
+	}
+	for batchStart := 0; batchStart < len(minipoolAddresses); batchStart += minipoolLogAddressBatchSize {
+		batchEnd := batchStart + minipoolLogAddressBatchSize
+		if batchEnd > len(minipoolAddresses) {
+			batchEnd = len(minipoolAddresses)
+		}
+		logs, err := eth.GetLogs(rp, minipoolAddresses[batchStart:batchEnd], [][]common.Hash{statusEventIds}, intervalSize, fromBlockBig, toBlockBig, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting minipool status events: %w", err)
+		}
+		for _, log := range logs {
+			nodeAddress, exists := minipoolOwners[log.Address]
+			if exists {
+				changedNodes[nodeAddress] = true
+			}
+		}
+	}
+
+	return changedNodes, nil
+
+}
+
+// Get the IDs of the provided events in a contract ABI
+func getEventIds(contractAbi *abi.ABI, names ...string) ([]common.Hash, error) {
+	ids := make([]common.Hash, 0, len(names))
+	for _, name := range names {
+		event, exists := contractAbi.Events[name]
+		if !exists {
+			return nil, fmt.Errorf("event %s was not found in the contract ABI", name)
+		}
+		ids = append(ids, event.ID)
+	}
+	return ids, nil
+}
@@ -0,0 +1,128 @@
+package rewards
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Get the Merkle leaf data for a node's rewards.
+// Node data is address[20] :: network[32] :: RPL[32] :: ETH[32]
+func GetNodeMerkleData(address common.Address, rewardsForNode *NodeRewardsInfo) []byte {
+	nodeData := make([]byte, 0, 20+32*3)
+	nodeData = append(nodeData, address.Bytes()...)
+
+	networkBytes := make([]byte, 32)
+	big.NewInt(0).SetUint64(rewardsForNode.RewardNetwork).FillBytes(networkBytes)
+	nodeData = append(nodeData, networkBytes...)
+
+	rplRewards := big.NewInt(0)
+	rplRewards.Add(&rewardsForNode.CollateralRpl.Int, &rewardsForNode.OracleDaoRpl.Int)
+	rplRewardsBytes := make([]byte, 32)
+	rplRewards.FillBytes(rplRewardsBytes)
+	nodeData = append(nodeData, rplRewardsBytes...)
+
+	ethRewardsBytes := make([]byte, 32)
+	rewardsForNode.SmoothingPoolEth.FillBytes(ethRewardsBytes)
+	nodeData = append(nodeData, ethRewardsBytes...)
+
+	return nodeData
+}
+
+// Verify a node's Merkle proof against the provided root.
+// The tree is built with sorted pairs, so each level hashes the lower of the two values first; this matches what the
+// RocketMerkleDistributorMainnet contract does when a claim is submitted.
+func VerifyNodeMerkleProof(address common.Address, rewardsForNode *NodeRewardsInfo, root common.Hash) (bool, error) {
+	path, err := GetNodeMerkleProofPath(address, rewardsForNode)
+	if err != nil {
+		return false, err
+	}
+	return path[len(path)-1] == root, nil
+}
+
+// Walk a node's Merkle proof up from its leaf, returning the leaf hash followed by the hash at each level of the proof.
+// The last hash is the root the proof leads to.
+func GetNodeMerkleProofPath(address common.Address, rewardsForNode *NodeRewardsInfo) ([]common.Hash, error) {
+	proof, err := rewardsForNode.GetMerkleProof()
+	if err != nil {
+		return nil, fmt.Errorf("error getting Merkle proof for node %s: %w", address.Hex(), err)
+	}
+
+	hash := crypto.Keccak256(GetNodeMerkleData(address, rewardsForNode))
+	path := make([]common.Hash, 0, len(proof)+1)
+	path = append(path, common.BytesToHash(hash))
+	for _, proofHash := range proof {
+		if bytes.Compare(hash, proofHash.Bytes()) <= 0 {
+			hash = crypto.Keccak256(hash, proofHash.Bytes())
+		} else {
+			hash = crypto.Keccak256(proofHash.Bytes(), hash)
+		}
+		path = append(path, common.BytesToHash(hash))
+	}
+	return path, nil
+}
+
+// Recompute the Merkle root of a rewards file from its node rewards, ignoring the root recorded in the file itself.
+// The tree is built with buildMerkleTree on a copy of the node rewards, so the file's own proofs and root are left alone.
+// Nodes without any rewards aren't part of the tree, matching how the generator builds it.
+func ComputeMerkleRoot(rewardsFile *RewardsFile) (common.Hash, error) {
+	zero := big.NewInt(0)
+	scratchFile := RewardsFile{
+		NodeRewards: make(map[common.Address]*NodeRewardsInfo, len(rewardsFile.NodeRewards)),
+	}
+	for address, rewardsForNode := range rewardsFile.NodeRewards {
+		if rewardsForNode.CollateralRpl.Cmp(zero) == 0 && rewardsForNode.OracleDaoRpl.Cmp(zero) == 0 && rewardsForNode.SmoothingPoolEth.Cmp(zero) == 0 {
+			continue
+		}
+		nodeRewardsCopy := *rewardsForNode
+		scratchFile.NodeRewards[address] = &nodeRewardsCopy
+	}
+
+	err := buildMerkleTree(&scratchFile)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return common.BytesToHash(scratchFile.MerkleTree.Root()), nil
+}
+
+// Verify that a rewards file matches the canonical Merkle root for the interval, by recomputing the root from the file's
+// node rewards rather than trusting the one it records, and by checking that every node's proof leads to it.
+// The name is only used in error messages. Use this on downloaded files before claiming.
+func VerifyRewardsFile(rewardsFile *RewardsFile, name string, interval uint64, root common.Hash) error {
+
+	if rewardsFile.Index != interval {
+		return fmt.Errorf("%s is the rewards file for interval %d, not interval %d", name, rewardsFile.Index, interval)
+	}
+
+	// Check the recomputed root
+	computedRoot, err := ComputeMerkleRoot(rewardsFile)
+	if err != nil {
+		return fmt.Errorf("error computing the Merkle root of %s: %w", name, err)
+	}
+	if computedRoot != root {
+		return fmt.Errorf("the node rewards in %s produce a Merkle root of %s, but the canonical root for interval %d is %s", name, computedRoot.Hex(), interval, root.Hex())
+	}
+	if common.HexToHash(rewardsFile.MerkleRoot) != root {
+		return fmt.Errorf("%s records a Merkle root of %s, but the canonical root for interval %d is %s", name, rewardsFile.MerkleRoot, interval, root.Hex())
+	}
+
+	// Claims use the per-node proofs, so make sure each one leads to the canonical root too
+	zero := big.NewInt(0)
+	for address, rewardsForNode := range rewardsFile.NodeRewards {
+		if rewardsForNode.CollateralRpl.Cmp(zero) == 0 && rewardsForNode.OracleDaoRpl.Cmp(zero) == 0 && rewardsForNode.SmoothingPoolEth.Cmp(zero) == 0 {
+			continue
+		}
+		valid, err := VerifyNodeMerkleProof(address, rewardsForNode, root)
+		if err != nil {
+			return fmt.Errorf("error verifying the Merkle proof for node %s in %s: %w", address.Hex(), name, err)
+		}
+		if !valid {
+			return fmt.Errorf("the Merkle proof for node %s in %s doesn't lead to the canonical root for interval %d", address.Hex(), name, interval)
+		}
+	}
+	return nil
+
+}
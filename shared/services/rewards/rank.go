@@ -0,0 +1,79 @@
+package rewards
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Where a node's RPL rewards place it among every node in an interval
+type RewardRank struct {
+	NodeExists bool
+	NodeRpl    *big.Int
+	Rank       uint64
+	NodeCount  uint64
+	Percentile float64
+	MedianRpl  *big.Int
+	MaxRpl     *big.Int
+}
+
+// Get a node's total RPL rewards (collateral and Oracle DAO) from a rewards entry
+func getTotalRpl(rewardsForNode *NodeRewardsInfo) *big.Int {
+	total := big.NewInt(0)
+	total.Add(&rewardsForNode.CollateralRpl.Int, &rewardsForNode.OracleDaoRpl.Int)
+	return total
+}
+
+// Rank a node's RPL rewards against every other node in the rewards file.
+// Rank 1 is the highest; nodes with identical rewards share a rank. The percentile is the share of nodes that earned less.
+func GetNodeRewardRank(rewardsFile *RewardsFile, nodeAddress common.Address) RewardRank {
+
+	rank := RewardRank{
+		NodeRpl:   big.NewInt(0),
+		MedianRpl: big.NewInt(0),
+		MaxRpl:    big.NewInt(0),
+	}
+
+	// Get everyone's rewards in descending order
+	amounts := make([]*big.Int, 0, len(rewardsFile.NodeRewards))
+	for _, rewardsForNode := range rewardsFile.NodeRewards {
+		amounts = append(amounts, getTotalRpl(rewardsForNode))
+	}
+	sort.Slice(amounts, func(i, j int) bool {
+		return amounts[i].Cmp(amounts[j]) > 0
+	})
+	rank.NodeCount = uint64(len(amounts))
+	if rank.NodeCount == 0 {
+		return rank
+	}
+
+	// Get the max and median
+	rank.MaxRpl = amounts[0]
+	middle := len(amounts) / 2
+	if len(amounts)%2 == 1 {
+		rank.MedianRpl = amounts[middle]
+	} else {
+		rank.MedianRpl.Add(amounts[middle-1], amounts[middle])
+		rank.MedianRpl.Div(rank.MedianRpl, big.NewInt(2))
+	}
+
+	// Find the node's place
+	rewardsForNode, exists := rewardsFile.NodeRewards[nodeAddress]
+	if !exists {
+		return rank
+	}
+	rank.NodeExists = true
+	rank.NodeRpl = getTotalRpl(rewardsForNode)
+
+	higher := sort.Search(len(amounts), func(i int) bool {
+		return amounts[i].Cmp(rank.NodeRpl) <= 0
+	})
+	lower := len(amounts) - sort.Search(len(amounts), func(i int) bool {
+		return amounts[i].Cmp(rank.NodeRpl) < 0
+	})
+	rank.Rank = uint64(higher) + 1
+	rank.Percentile = float64(lower) / float64(len(amounts)) * 100
+	return rank
+
+}
@@ -0,0 +1,130 @@
+package rewards
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+)
+
+// A token bucket that refills at a fixed rate, holding up to one second's worth of requests so short bursts are smoothed
+type tokenBucket struct {
+	lock     sync.Mutex
+	rate     float64
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+// Create a new token bucket that allows the provided number of requests per second
+func newTokenBucket(requestsPerSecond uint64) *tokenBucket {
+	rate := float64(requestsPerSecond)
+	return &tokenBucket{
+		rate:     rate,
+		capacity: rate,
+		tokens:   rate,
+		last:     time.Now(),
+	}
+}
+
+// Take a token, waiting for one to become available if the bucket is empty
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		b.lock.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+		b.last = now
+		if b.tokens >= 1 {
+			b.tokens--
+			b.lock.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+		b.lock.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// An execution client that throttles its read calls with a shared token bucket. Calls that aren't overridden here
+// go straight to the underlying client.
+type rateLimitedClient struct {
+	rocketpool.ExecutionClient
+	bucket *tokenBucket
+}
+
+// Wrap an execution client so it makes at most the provided number of read calls per second, across every goroutine using it
+func NewRateLimitedClient(client rocketpool.ExecutionClient, requestsPerSecond uint64) rocketpool.ExecutionClient {
+	return &rateLimitedClient{
+		ExecutionClient: client,
+		bucket:          newTokenBucket(requestsPerSecond),
+	}
+}
+
+func (c *rateLimitedClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	if err := c.bucket.wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.ExecutionClient.CodeAt(ctx, contract, blockNumber)
+}
+
+func (c *rateLimitedClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if err := c.bucket.wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.ExecutionClient.CallContract(ctx, call, blockNumber)
+}
+
+func (c *rateLimitedClient) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	if err := c.bucket.wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.ExecutionClient.HeaderByHash(ctx, hash)
+}
+
+func (c *rateLimitedClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if err := c.bucket.wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.ExecutionClient.HeaderByNumber(ctx, number)
+}
+
+func (c *rateLimitedClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	if err := c.bucket.wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.ExecutionClient.FilterLogs(ctx, query)
+}
+
+func (c *rateLimitedClient) BlockNumber(ctx context.Context) (uint64, error) {
+	if err := c.bucket.wait(ctx); err != nil {
+		return 0, err
+	}
+	return c.ExecutionClient.BlockNumber(ctx)
+}
+
+func (c *rateLimitedClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	if err := c.bucket.wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.ExecutionClient.BalanceAt(ctx, account, blockNumber)
+}
+
+func (c *rateLimitedClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	if err := c.bucket.wait(ctx); err != nil {
+		return 0, err
+	}
+	return c.ExecutionClient.NonceAt(ctx, account, blockNumber)
+}
@@ -0,0 +1,119 @@
+package rewards
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/wealdtech/go-merkletree"
+	"github.com/wealdtech/go-merkletree/keccak256"
+)
+
+// Get the addresses of every node in a node rewards map, sorted in ascending byte order
+func getOrderedNodeAddresses(nodeRewards map[common.Address]*NodeRewardsInfo) []common.Address {
+	addresses := make([]common.Address, 0, len(nodeRewards))
+	for address := range nodeRewards {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i].Bytes(), addresses[j].Bytes()) < 0
+	})
+	return addresses
+}
+
+// Generates the Merkle tree and each node's proof from the rewards file's node rewards map.
+// The leaves are built in ascending node address order rather than map order, then sorted by hash when the tree is
+// constructed (as the on-chain verifier expects), so the same rewards always produce the same tree and root.
+func buildMerkleTree(rewardsFile *RewardsFile) error {
+
+	// Generate the leaf data for each node
+	addresses := getOrderedNodeAddresses(rewardsFile.NodeRewards)
+	totalData := make([][]byte, 0, len(addresses))
+	for _, address := range addresses {
+		rewardsForNode := rewardsFile.NodeRewards[address]
+		// Ignore nodes that didn't receive any rewards
+		zero := big.NewInt(0)
+		if rewardsForNode.CollateralRpl.Cmp(zero) == 0 && rewardsForNode.OracleDaoRpl.Cmp(zero) == 0 && rewardsForNode.SmoothingPoolEth.Cmp(zero) == 0 {
+			continue
+		}
+
+		// Node data is address[20] :: network[32] :: RPL[32] :: ETH[32]
+		nodeData := make([]byte, 0, 20+32*3)
+
+		// Node address
+		addressBytes := address.Bytes()
+		nodeData = append(nodeData, addressBytes...)
+
+		// Node network
+		network := big.NewInt(0).SetUint64(rewardsForNode.RewardNetwork)
+		networkBytes := make([]byte, 32)
+		network.FillBytes(networkBytes)
+		nodeData = append(nodeData, networkBytes...)
+
+		// RPL rewards
+		rplRewards := big.NewInt(0)
+		rplRewards.Add(&rewardsForNode.CollateralRpl.Int, &rewardsForNode.OracleDaoRpl.Int)
+		rplRewardsBytes := make([]byte, 32)
+		rplRewards.FillBytes(rplRewardsBytes)
+		nodeData = append(nodeData, rplRewardsBytes...)
+
+		// ETH rewards
+		ethRewardsBytes := make([]byte, 32)
+		rewardsForNode.SmoothingPoolEth.FillBytes(ethRewardsBytes)
+		nodeData = append(nodeData, ethRewardsBytes...)
+
+		// Assign it to the node rewards tracker and add it to the leaf data slice
+		rewardsForNode.MerkleData = nodeData
+		totalData = append(totalData, nodeData)
+	}
+
+	// Generate the tree
+	tree, err := merkletree.NewUsing(totalData, keccak256.New(), false, true)
+	if err != nil {
+		return fmt.Errorf("error generating Merkle Tree: %w", err)
+	}
+
+	// Generate the proofs for each node
+	for _, address := range addresses {
+		rewardsForNode := rewardsFile.NodeRewards[address]
+
+		// Get the proof
+		proof, err := tree.GenerateProof(rewardsForNode.MerkleData, 0)
+		if err != nil {
+			return fmt.Errorf("error generating proof for node %s: %w", address.Hex(), err)
+		}
+
+		// Convert the proof into hex strings
+		proofStrings := make([]string, len(proof.Hashes))
+		for i, hash := range proof.Hashes {
+			proofStrings[i] = fmt.Sprintf("0x%s", hex.EncodeToString(hash))
+		}
+
+		// Assign the hex strings to the node rewards struct
+		rewardsForNode.MerkleProof = proofStrings
+	}
+
+	rewardsFile.MerkleTree = tree
+	rewardsFile.MerkleRoot = common.BytesToHash(tree.Root()).Hex()
+	return nil
+
+}
+
+// Rebuild the Merkle tree and proofs of a rewards file from the reward amounts it already has, without recalculating
+// them. This recovers a tree file whose proofs or root were damaged, as long as the amounts are intact.
+func RebuildMerkleProofs(rewardsFile *RewardsFile) error {
+	if len(rewardsFile.NodeRewards) == 0 {
+		return fmt.Errorf("the rewards file doesn't have any node rewards to rebuild the tree from")
+	}
+	for address, rewardsForNode := range rewardsFile.NodeRewards {
+		if rewardsForNode == nil || rewardsForNode.CollateralRpl == nil || rewardsForNode.OracleDaoRpl == nil || rewardsForNode.SmoothingPoolEth == nil {
+			return fmt.Errorf("the rewards for node %s are incomplete", address.Hex())
+		}
+		rewardsForNode.MerkleData = nil
+		rewardsForNode.MerkleProof = nil
+	}
+	return buildMerkleTree(rewardsFile)
+}
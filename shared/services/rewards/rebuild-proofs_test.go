@@ -0,0 +1,65 @@
+package rewards
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Make a rewards file with a set of nodes that have pseudo-random rewards
+func makeTestRewardsFile(nodeCount int) *RewardsFile {
+	random := rand.New(rand.NewSource(1))
+	rewardsFile := &RewardsFile{
+		NodeRewards: make(map[common.Address]*NodeRewardsInfo, nodeCount),
+	}
+	for i := 0; i < nodeCount; i++ {
+		var address common.Address
+		random.Read(address[:])
+		rewardsFile.NodeRewards[address] = &NodeRewardsInfo{
+			RewardNetwork:    uint64(random.Intn(2)),
+			CollateralRpl:    NewQuotedBigInt(random.Int63()),
+			OracleDaoRpl:     NewQuotedBigInt(random.Int63n(1000)),
+			SmoothingPoolEth: NewQuotedBigInt(random.Int63()),
+		}
+	}
+	return rewardsFile
+}
+
+func TestBuildMerkleTreeIsDeterministic(t *testing.T) {
+	rewardsFile := makeTestRewardsFile(100)
+
+	// Map iteration order changes between runs, so building the tree repeatedly would catch any dependence on it
+	err := buildMerkleTree(rewardsFile)
+	if err != nil {
+		t.Fatalf("error building Merkle tree: %s", err.Error())
+	}
+	expectedRoot := rewardsFile.MerkleRoot
+	for i := 0; i < 10; i++ {
+		err := buildMerkleTree(rewardsFile)
+		if err != nil {
+			t.Fatalf("error building Merkle tree on run %d: %s", i, err.Error())
+		}
+		if rewardsFile.MerkleRoot != expectedRoot {
+			t.Fatalf("run %d produced a root of %s instead of %s", i, rewardsFile.MerkleRoot, expectedRoot)
+		}
+	}
+
+	// The recomputed root and every node's proof have to agree with the built tree
+	root, err := ComputeMerkleRoot(rewardsFile)
+	if err != nil {
+		t.Fatalf("error computing Merkle root: %s", err.Error())
+	}
+	if root != common.HexToHash(expectedRoot) {
+		t.Fatalf("computed root %s doesn't match built root %s", root.Hex(), expectedRoot)
+	}
+	for address, rewardsForNode := range rewardsFile.NodeRewards {
+		valid, err := VerifyNodeMerkleProof(address, rewardsForNode, root)
+		if err != nil {
+			t.Fatalf("error verifying proof for node %s: %s", address.Hex(), err.Error())
+		}
+		if !valid {
+			t.Fatalf("proof for node %s doesn't lead to the root", address.Hex())
+		}
+	}
+}
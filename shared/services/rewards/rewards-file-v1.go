@@ -42,6 +42,11 @@ type NodeRewardsInfo struct {
 	SmoothingPoolEligibilityRate float64       `json:"smoothingPoolEligibilityRate"`
 	MerkleData                   []byte        `json:"-"`
 	MerkleProof                  []string      `json:"merkleProof"`
+
+	// Where the node's claimed rewards are sent, if it's not the node address itself. It's left out of the JSON file
+	// because that file's CID is submitted on-chain and has to match across Oracle DAO members; it's saved to a
+	// separate file instead so accounting tools can match rewards to the address that receives them.
+	WithdrawalAddress *common.Address `json:"-"`
 }
 
 // Rewards per network
@@ -82,7 +87,17 @@ type RewardsFile struct {
 	NodeRewards                map[common.Address]*NodeRewardsInfo `json:"nodeRewards"`
 	MinipoolPerformanceFile    MinipoolPerformanceFile             `json:"-"`
 
+	// Optional per-minipool breakdown of each node's smoothing pool ETH, keyed by node then minipool address.
+	// It isn't part of the Merkle tree, so parsers that only read the proofs can ignore it.
+	MinipoolRewards map[common.Address]map[common.Address]*QuotedBigInt `json:"minipoolRewards,omitempty"`
+
 	// Non-serialized fields
+
+	// The tree's leaves are address[20] :: network[32] :: RPL[32] :: ETH[32] for each node with rewards, sorted by their
+	// keccak256 hash; they're gathered in ascending node address order first, so the tree never depends on map order
 	MerkleTree          *merkletree.MerkleTree    `json:"-"`
 	InvalidNetworkNodes map[common.Address]uint64 `json:"-"`
+
+	// Nodes whose smoothing pool opt-in window couldn't be determined, with the reason; these are only reported as warnings
+	UndeterminedSmoothingPoolNodes map[common.Address]string `json:"-"`
 }
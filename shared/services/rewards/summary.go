@@ -0,0 +1,44 @@
+package rewards
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// Build a human-readable summary of a rewards file, for auditing it without parsing the full JSON
+func FormatRewardsSummary(rewardsFile *RewardsFile, matchesCanonical bool) string {
+	totalRpl := big.NewInt(0)
+	totalRpl.Add(totalRpl, &rewardsFile.TotalRewards.TotalCollateralRpl.Int)
+	totalRpl.Add(totalRpl, &rewardsFile.TotalRewards.TotalOracleDaoRpl.Int)
+	totalRpl.Add(totalRpl, &rewardsFile.TotalRewards.ProtocolDaoRpl.Int)
+
+	canonical := "no"
+	if matchesCanonical {
+		canonical = "yes"
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Interval:               %d\n", rewardsFile.Index)
+	fmt.Fprintf(&builder, "Network:                %s\n", rewardsFile.Network)
+	fmt.Fprintf(&builder, "Execution block:        %d\n", rewardsFile.ExecutionEndBlock)
+	fmt.Fprintf(&builder, "Total RPL distributed:  %.6f RPL (%s wei)\n", eth.WeiToEth(totalRpl), totalRpl.String())
+	fmt.Fprintf(&builder, "Smoothing pool ETH:     %.6f ETH (%s wei)\n", eth.WeiToEth(&rewardsFile.TotalRewards.TotalSmoothingPoolEth.Int), rewardsFile.TotalRewards.TotalSmoothingPoolEth.String())
+	fmt.Fprintf(&builder, "Eligible nodes:         %d\n", len(rewardsFile.NodeRewards))
+	fmt.Fprintf(&builder, "Merkle root:            %s\n", rewardsFile.MerkleRoot)
+	fmt.Fprintf(&builder, "Matches canonical root: %s\n", canonical)
+	return builder.String()
+}
+
+// Write the summary of a rewards file to the provided path
+func SaveRewardsSummary(rewardsFile *RewardsFile, matchesCanonical bool, path string, mode os.FileMode) error {
+	err := files.WriteFileAtomic(path, []byte(FormatRewardsSummary(rewardsFile, matchesCanonical)), mode)
+	if err != nil {
+		return fmt.Errorf("error saving rewards summary to %s: %w", path, err)
+	}
+	return nil
+}
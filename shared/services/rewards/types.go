@@ -10,6 +10,15 @@ import (
 	"github.com/rocket-pool/rocketpool-go/types"
 )
 
+// A phase of rewards tree generation that gets timed
+type GenerationPhase string
+
+const (
+	GenerationPhase_RplRewards GenerationPhase = "rplRewards"
+	GenerationPhase_EthRewards GenerationPhase = "ethRewards"
+	GenerationPhase_MerkleTree GenerationPhase = "merkleTree"
+)
+
 // Information about an interval
 type IntervalInfo struct {
 	Index                  uint64        `json:"index"`
@@ -24,6 +33,8 @@ type IntervalInfo struct {
 	ODaoRplAmount          *QuotedBigInt `json:"oDaoRplAmount"`
 	SmoothingPoolEthAmount *QuotedBigInt `json:"smoothingPoolEthAmount"`
 	MerkleProof            []common.Hash `json:"merkleProof"`
+	MerkleRoot             common.Hash   `json:"merkleRoot"`
+	RewardsFile            *RewardsFile  `json:"-"`
 }
 
 type MinipoolInfo struct {
@@ -69,6 +80,9 @@ type NodeSmoothingDetails struct {
 	EndSlot          uint64
 	SmoothingPoolEth *big.Int
 	RewardsNetwork   uint64
+
+	// Why the node's opt-in window couldn't be determined, if it couldn't
+	UndeterminedReason string
 }
 
 type QuotedBigInt struct {
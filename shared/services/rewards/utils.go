@@ -9,6 +9,7 @@ import (
 	rewards_v150rc1 "github.com/rocket-pool/rocketpool-go/legacy/v1.5.0-rc1/rewards"
 	"github.com/rocket-pool/rocketpool-go/rewards"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 )
@@ -112,6 +113,59 @@ func GetProtocolDaoRewardsPercent(cfg *config.RocketPoolConfig, index uint64, rp
 	return rewards.GetProtocolDaoRewardsPercent(rp, opts)
 }
 
+// The share of each interval's RPL inflation that goes to node operators, the Oracle DAO, and the Protocol DAO.
+// Each one is a fraction of 1 ETH (1e18 wei), so together they can't add up to more than 1e18. Mainnet uses all of it,
+// but testnets can leave part of the inflation unassigned.
+type RewardsSplit struct {
+	NodeOperator *big.Int
+	TrustedNode  *big.Int
+	ProtocolDao  *big.Int
+}
+
+// Gets the RPL rewards split from chain state at the block in the provided call options. None of these are constants, since
+// testnets can (and do) set them differently from mainnet.
+func GetRewardsSplit(cfg *config.RocketPoolConfig, index uint64, rp *rocketpool.RocketPool, opts *bind.CallOpts) (RewardsSplit, error) {
+	nodeOpPercent, err := GetNodeOperatorRewardsPercent(cfg, index, rp, opts)
+	if err != nil {
+		return RewardsSplit{}, fmt.Errorf("error getting node operator rewards percent: %w", err)
+	}
+	oDaoPercent, err := GetTrustedNodeOperatorRewardsPercent(cfg, index, rp, opts)
+	if err != nil {
+		return RewardsSplit{}, fmt.Errorf("error getting Oracle DAO rewards percent: %w", err)
+	}
+	pDaoPercent, err := GetProtocolDaoRewardsPercent(cfg, index, rp, opts)
+	if err != nil {
+		return RewardsSplit{}, fmt.Errorf("error getting Protocol DAO rewards percent: %w", err)
+	}
+	return RewardsSplit{
+		NodeOperator: nodeOpPercent,
+		TrustedNode:  oDaoPercent,
+		ProtocolDao:  pDaoPercent,
+	}, nil
+}
+
+// Gets the sum of the node operator, Oracle DAO, and Protocol DAO shares
+func (s RewardsSplit) Total() *big.Int {
+	total := big.NewInt(0)
+	total.Add(total, s.NodeOperator)
+	total.Add(total, s.TrustedNode)
+	total.Add(total, s.ProtocolDao)
+	return total
+}
+
+// Checks that the split doesn't add up to more than 100% of the inflation
+func (s RewardsSplit) Validate() error {
+	if s.NodeOperator == nil || s.TrustedNode == nil || s.ProtocolDao == nil {
+		return fmt.Errorf("the rewards split is missing a value")
+	}
+	total := s.Total()
+	if total.Cmp(eth.EthToWei(1)) > 0 {
+		return fmt.Errorf("the rewards split adds up to %.6f, which is more than 1 (node operators %.6f, Oracle DAO %.6f, Protocol DAO %.6f)",
+			eth.WeiToEth(total), eth.WeiToEth(s.NodeOperator), eth.WeiToEth(s.TrustedNode), eth.WeiToEth(s.ProtocolDao))
+	}
+	return nil
+}
+
 // TODO: temp until rocketpool-go supports RocketStorage contract address lookups per block
 func GetPendingRPLRewards(cfg *config.RocketPoolConfig, index uint64, rp *rocketpool.RocketPool, opts *bind.CallOpts) (*big.Int, error) {
 	switch cfg.Smartnode.Network.Value.(cfgtypes.Network) {
@@ -124,3 +178,30 @@ func GetPendingRPLRewards(cfg *config.RocketPoolConfig, index uint64, rp *rocket
 
 	return rewards.GetPendingRPLRewards(rp, opts)
 }
+
+// The genesis time of the first Beacon Chain (mainnet); no network's genesis can be earlier than this
+const earliestGenesisTime uint64 = 1606824023
+
+// Checks that the genesis time reported by the Beacon Node is plausible for the configured network, since a bad one would
+// silently produce wrong block times and thus a wrong tree
+func ValidateGenesisTime(cfg *config.RocketPoolConfig, genesisTime uint64) error {
+	if genesisTime == 0 {
+		return fmt.Errorf("your Beacon Node reported a genesis time of 0; it is likely misconfigured or not fully started")
+	}
+
+	expectedGenesisTime := cfg.Smartnode.GetBeaconGenesisTime()
+	if expectedGenesisTime != 0 {
+		if genesisTime != expectedGenesisTime {
+			return fmt.Errorf("your Beacon Node reported a genesis time of %s, but the genesis time for the %s network is %s; it is likely configured for a different network",
+				time.Unix(int64(genesisTime), 0).UTC(), cfg.Smartnode.Network.Value, time.Unix(int64(expectedGenesisTime), 0).UTC())
+		}
+		return nil
+	}
+
+	// The genesis time for this network isn't known, so just make sure it's in a sane range
+	now := uint64(time.Now().Unix())
+	if genesisTime < earliestGenesisTime || genesisTime > now {
+		return fmt.Errorf("your Beacon Node reported a genesis time of %s, which is not plausible; it is likely misconfigured", time.Unix(int64(genesisTime), 0).UTC())
+	}
+	return nil
+}
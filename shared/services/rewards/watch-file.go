@@ -0,0 +1,162 @@
+package rewards
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// A single file with a node's rewards and proofs for every interval, for dashboards to poll
+type RewardsWatchFile struct {
+	NodeAddress common.Address          `json:"nodeAddress"`
+	Network     string                  `json:"network"`
+	LastUpdated time.Time               `json:"lastUpdated"`
+	Intervals   []*RewardsWatchInterval `json:"intervals"`
+}
+
+// A node's rewards for one interval in the watch file
+type RewardsWatchInterval struct {
+	Index            uint64        `json:"index"`
+	StartTime        time.Time     `json:"startTime"`
+	EndTime          time.Time     `json:"endTime"`
+	MerkleRoot       string        `json:"merkleRoot"`
+	NodeExists       bool          `json:"nodeExists"`
+	RewardNetwork    uint64        `json:"rewardNetwork"`
+	CollateralRpl    *QuotedBigInt `json:"collateralRpl"`
+	OracleDaoRpl     *QuotedBigInt `json:"oracleDaoRpl"`
+	SmoothingPoolEth *QuotedBigInt `json:"smoothingPoolEth"`
+	MerkleProof      []string      `json:"merkleProof"`
+}
+
+// Adds every interval that isn't in the node's watch file yet but has a local rewards tree available.
+// Each interval's proof is verified against the canonical Merkle root before it's added.
+// Returns the intervals that were added.
+func UpdateRewardsWatchFile(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, nodeAddress common.Address) ([]uint64, error) {
+
+	network := string(cfg.Smartnode.Network.Value.(cfgtypes.Network))
+	path := cfg.Smartnode.GetRewardsWatchFilePath(true)
+
+	// Load the existing file if there is one; start over if it's for a different node or network
+	watchFile := &RewardsWatchFile{}
+	fileBytes, err := ioutil.ReadFile(path)
+	if err == nil {
+		err = json.Unmarshal(fileBytes, watchFile)
+		if err != nil {
+			return nil, fmt.Errorf("error deserializing watch file %s: %w", path, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error reading watch file %s: %w", path, err)
+	}
+	if watchFile.NodeAddress != nodeAddress || watchFile.Network != network {
+		watchFile = &RewardsWatchFile{
+			NodeAddress: nodeAddress,
+			Network:     network,
+			Intervals:   []*RewardsWatchInterval{},
+		}
+	}
+	existingIntervals := map[uint64]bool{}
+	for _, interval := range watchFile.Intervals {
+		existingIntervals[interval.Index] = true
+	}
+
+	// Get the current interval
+	currentIndexBig, err := rewards.GetRewardIndex(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting current rewards index: %w", err)
+	}
+	currentIndex := currentIndexBig.Uint64()
+
+	added := []uint64{}
+	for i := uint64(0); i < currentIndex; i++ {
+		if existingIntervals[i] {
+			continue
+		}
+
+		// Only add intervals that have a tree available
+		treePath := cfg.Smartnode.GetExistingRewardsTreePath(i, true)
+		_, err := os.Stat(treePath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		rewardsFile, err := LoadRewardsFile(treePath)
+		if err != nil {
+			return nil, err
+		}
+
+		// Make sure the tree matches the canonical one
+		event, err := GetRewardSnapshotEvent(rp, cfg, i)
+		if err != nil {
+			return nil, fmt.Errorf("error getting event for interval %d: %w", i, err)
+		}
+		if common.HexToHash(rewardsFile.MerkleRoot) != event.MerkleRoot {
+			return nil, fmt.Errorf("the rewards tree for interval %d has a root of %s, but the canonical root is %s", i, rewardsFile.MerkleRoot, event.MerkleRoot.Hex())
+		}
+
+		interval := &RewardsWatchInterval{
+			Index:            i,
+			StartTime:        event.IntervalStartTime.UTC(),
+			EndTime:          event.IntervalEndTime.UTC(),
+			MerkleRoot:       event.MerkleRoot.Hex(),
+			CollateralRpl:    NewQuotedBigInt(0),
+			OracleDaoRpl:     NewQuotedBigInt(0),
+			SmoothingPoolEth: NewQuotedBigInt(0),
+			MerkleProof:      []string{},
+		}
+
+		// Verify and add the node's rewards
+		nodeRewards, exists := rewardsFile.NodeRewards[nodeAddress]
+		if exists {
+			valid, err := VerifyNodeMerkleProof(nodeAddress, nodeRewards, event.MerkleRoot)
+			if err != nil {
+				return nil, fmt.Errorf("error verifying proof for interval %d: %w", i, err)
+			}
+			if !valid {
+				return nil, fmt.Errorf("the proof for node %s in interval %d does not match the canonical root %s", nodeAddress.Hex(), i, event.MerkleRoot.Hex())
+			}
+			interval.NodeExists = true
+			interval.RewardNetwork = nodeRewards.RewardNetwork
+			interval.CollateralRpl = nodeRewards.CollateralRpl
+			interval.OracleDaoRpl = nodeRewards.OracleDaoRpl
+			interval.SmoothingPoolEth = nodeRewards.SmoothingPoolEth
+			interval.MerkleProof = nodeRewards.MerkleProof
+		}
+
+		watchFile.Intervals = append(watchFile.Intervals, interval)
+		added = append(added, i)
+	}
+
+	if len(added) == 0 {
+		return added, nil
+	}
+
+	// Save the file
+	sort.Slice(watchFile.Intervals, func(i, j int) bool {
+		return watchFile.Intervals[i].Index < watchFile.Intervals[j].Index
+	})
+	watchFile.LastUpdated = time.Now().UTC()
+	fileBytes, err = json.Marshal(watchFile)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing watch file: %w", err)
+	}
+	fileMode, err := cfg.Smartnode.GetRewardsTreeFileMode()
+	if err != nil {
+		return nil, fmt.Errorf("error getting rewards tree file permissions: %w", err)
+	}
+	err = files.WriteFileAtomic(path, fileBytes, fileMode)
+	if err != nil {
+		return nil, fmt.Errorf("error saving watch file to %s: %w", path, err)
+	}
+
+	return added, nil
+
+}
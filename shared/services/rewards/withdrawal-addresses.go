@@ -0,0 +1,30 @@
+package rewards
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/smartnode/shared/utils/files"
+)
+
+// Save the withdrawal address of every node in the rewards file that has one set, keyed by node address. These are kept
+// out of the rewards file itself so its CID stays the same as the one other Oracle DAO members submit.
+func SaveWithdrawalAddresses(rewardsFile *RewardsFile, path string, mode os.FileMode) error {
+	withdrawalAddresses := map[common.Address]common.Address{}
+	for address, nodeRewards := range rewardsFile.NodeRewards {
+		if nodeRewards.WithdrawalAddress != nil {
+			withdrawalAddresses[address] = *nodeRewards.WithdrawalAddress
+		}
+	}
+	bytes, err := json.Marshal(withdrawalAddresses)
+	if err != nil {
+		return fmt.Errorf("error serializing withdrawal addresses: %w", err)
+	}
+	err = files.WriteFileAtomic(path, bytes, mode)
+	if err != nil {
+		return fmt.Errorf("error saving withdrawal addresses to %s: %w", path, err)
+	}
+	return nil
+}
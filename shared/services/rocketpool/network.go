@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 )
 
@@ -24,6 +25,22 @@ func (c *Client) NodeFee() (api.NodeFeeResponse, error) {
 	return response, nil
 }
 
+// Get the timing of the current and next rewards intervals
+func (c *Client) RewardIntervalCalendar() (api.RewardIntervalCalendarResponse, error) {
+	responseBytes, err := c.callAPI("network reward-interval-calendar")
+	if err != nil {
+		return api.RewardIntervalCalendarResponse{}, fmt.Errorf("Could not get reward interval calendar: %w", err)
+	}
+	var response api.RewardIntervalCalendarResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RewardIntervalCalendarResponse{}, fmt.Errorf("Could not decode reward interval calendar response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RewardIntervalCalendarResponse{}, fmt.Errorf("Could not get reward interval calendar: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Get network RPL price
 func (c *Client) RplPrice() (api.RplPriceResponse, error) {
 	responseBytes, err := c.callAPI("network rpl-price")
@@ -98,8 +115,25 @@ func (c *Client) CanGenerateRewardsTree(index uint64) (api.CanNetworkGenerateRew
 }
 
 // Set a request marker for the watchtower to generate the rewards tree for the given interval
-func (c *Client) GenerateRewardsTree(index uint64) (api.NetworkGenerateRewardsTreeResponse, error) {
-	responseBytes, err := c.callAPI(fmt.Sprintf("network generate-rewards-tree %d", index))
+func (c *Client) GenerateRewardsTree(index uint64, threads uint64, outputDir string, proofsOnly bool, nodeAddress *common.Address, force bool, elBlock uint64) (api.NetworkGenerateRewardsTreeResponse, error) {
+	otherArgs := []string{}
+	if outputDir != "" {
+		otherArgs = append(otherArgs, "--output-dir", outputDir)
+	}
+	if proofsOnly {
+		otherArgs = append(otherArgs, "--proofs-only")
+	}
+	if nodeAddress != nil {
+		otherArgs = append(otherArgs, "--node-address", nodeAddress.Hex())
+	}
+	if force {
+		otherArgs = append(otherArgs, "--force")
+	}
+	if elBlock > 0 {
+		otherArgs = append(otherArgs, "--el-block", fmt.Sprint(elBlock))
+	}
+	otherArgs = append(otherArgs, fmt.Sprint(index), fmt.Sprint(threads))
+	responseBytes, err := c.callAPI("network generate-rewards-tree", otherArgs...)
 	if err != nil {
 		return api.NetworkGenerateRewardsTreeResponse{}, fmt.Errorf("Could not initialize rewards tree generation: %w", err)
 	}
@@ -113,6 +147,107 @@ func (c *Client) GenerateRewardsTree(index uint64) (api.NetworkGenerateRewardsTr
 	return response, nil
 }
 
+// Set a request marker for the watchtower to generate the rewards tree for the given interval and check its root without saving it
+func (c *Client) DryRunRewardsTree(index uint64, threads uint64, elBlock uint64) (api.NetworkDryRunRewardsTreeResponse, error) {
+	otherArgs := []string{}
+	if elBlock > 0 {
+		otherArgs = append(otherArgs, "--el-block", fmt.Sprint(elBlock))
+	}
+	otherArgs = append(otherArgs, fmt.Sprint(index), fmt.Sprint(threads))
+	responseBytes, err := c.callAPI("network dry-run-rewards-tree", otherArgs...)
+	if err != nil {
+		return api.NetworkDryRunRewardsTreeResponse{}, fmt.Errorf("Could not initialize rewards tree dry run: %w", err)
+	}
+	var response api.NetworkDryRunRewardsTreeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkDryRunRewardsTreeResponse{}, fmt.Errorf("Could not decode rewards tree dry run response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkDryRunRewardsTreeResponse{}, fmt.Errorf("Could not initialize rewards tree dry run: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Set a request marker for the watchtower to verify that generating the rewards tree for the given interval is deterministic
+func (c *Client) VerifyRewardsTree(index uint64) (api.NetworkVerifyRewardsTreeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network verify-rewards-tree %d", index))
+	if err != nil {
+		return api.NetworkVerifyRewardsTreeResponse{}, fmt.Errorf("Could not initialize rewards tree verification: %w", err)
+	}
+	var response api.NetworkVerifyRewardsTreeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkVerifyRewardsTreeResponse{}, fmt.Errorf("Could not decode rewards tree verification response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkVerifyRewardsTreeResponse{}, fmt.Errorf("Could not initialize rewards tree verification: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the state of each step of the rewards pipeline for an interval on a test network
+func (c *Client) GetClaimRoundTripStatus(index uint64) (api.NetworkClaimRoundTripStatusResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network claim-round-trip-status %d", index))
+	if err != nil {
+		return api.NetworkClaimRoundTripStatusResponse{}, fmt.Errorf("Could not get claim round-trip status: %w", err)
+	}
+	var response api.NetworkClaimRoundTripStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkClaimRoundTripStatusResponse{}, fmt.Errorf("Could not decode claim round-trip status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkClaimRoundTripStatusResponse{}, fmt.Errorf("Could not get claim round-trip status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// List the rewards tree files on disk and check each one against its canonical root
+func (c *Client) GetLocalRewardsTrees() (api.NetworkLocalRewardsTreesResponse, error) {
+	responseBytes, err := c.callAPI("network local-rewards-trees")
+	if err != nil {
+		return api.NetworkLocalRewardsTreesResponse{}, fmt.Errorf("Could not get local rewards trees: %w", err)
+	}
+	var response api.NetworkLocalRewardsTreesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkLocalRewardsTreesResponse{}, fmt.Errorf("Could not decode local rewards trees response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkLocalRewardsTreesResponse{}, fmt.Errorf("Could not get local rewards trees: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the status of the watchtower's latest manual rewards tree generation
+func (c *Client) GetRewardsTreeGenerationStatus() (api.NetworkRewardsTreeGenerationStatusResponse, error) {
+	responseBytes, err := c.callAPI("network rewards-tree-generation-status")
+	if err != nil {
+		return api.NetworkRewardsTreeGenerationStatusResponse{}, fmt.Errorf("Could not get rewards tree generation status: %w", err)
+	}
+	var response api.NetworkRewardsTreeGenerationStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkRewardsTreeGenerationStatusResponse{}, fmt.Errorf("Could not decode rewards tree generation status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkRewardsTreeGenerationStatusResponse{}, fmt.Errorf("Could not get rewards tree generation status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// List the watchtower's control files, optionally removing stale ones
+func (c *Client) GetWatchtowerFiles(clean bool) (api.NetworkWatchtowerFilesResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("network watchtower-files %t", clean))
+	if err != nil {
+		return api.NetworkWatchtowerFilesResponse{}, fmt.Errorf("Could not get watchtower files: %w", err)
+	}
+	var response api.NetworkWatchtowerFilesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NetworkWatchtowerFilesResponse{}, fmt.Errorf("Could not decode watchtower files response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NetworkWatchtowerFilesResponse{}, fmt.Errorf("Could not get watchtower files: %s", response.Error)
+	}
+	return response, nil
+}
+
 // GetActiveDAOProposals fetches information about active DAO proposals
 func (c *Client) GetActiveDAOProposals() (api.NetworkDAOProposalsResponse, error) {
 	responseBytes, err := c.callAPI("network dao-proposals")
@@ -749,6 +749,38 @@ func (c *Client) GetRewardsInfo() (api.NodeGetRewardsInfoResponse, error) {
 	return response, nil
 }
 
+// Add any new intervals to the node's rewards watch file
+func (c *Client) UpdateRewardsWatchFile() (api.NodeUpdateRewardsWatchFileResponse, error) {
+	responseBytes, err := c.callAPI("node update-rewards-watch-file")
+	if err != nil {
+		return api.NodeUpdateRewardsWatchFileResponse{}, fmt.Errorf("Could not update rewards watch file: %w", err)
+	}
+	var response api.NodeUpdateRewardsWatchFileResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeUpdateRewardsWatchFileResponse{}, fmt.Errorf("Could not decode update rewards watch file response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeUpdateRewardsWatchFileResponse{}, fmt.Errorf("Could not update rewards watch file: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node's RPL reward rank among all nodes in an interval
+func (c *Client) NodeRewardRank(index uint64) (api.NodeRewardRankResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node reward-rank %d", index))
+	if err != nil {
+		return api.NodeRewardRankResponse{}, fmt.Errorf("Could not get reward rank: %w", err)
+	}
+	var response api.NodeRewardRankResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeRewardRankResponse{}, fmt.Errorf("Could not decode reward rank response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeRewardRankResponse{}, fmt.Errorf("Could not get reward rank: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check if the rewards for the given intervals can be claimed
 func (c *Client) CanNodeClaimRewards(indices []uint64) (api.CanNodeClaimRewardsResponse, error) {
 	indexStrings := []string{}
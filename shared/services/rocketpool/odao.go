@@ -739,3 +739,67 @@ func (c *Client) GetTNDAOMinipoolSettings() (api.GetTNDAOMinipoolSettingsRespons
 	}
 	return response, nil
 }
+
+// Get the status of any challenge against the node
+func (c *Client) TNDAOChallengeStatus() (api.TNDAOChallengeStatusResponse, error) {
+	responseBytes, err := c.callAPI("odao challenge-status")
+	if err != nil {
+		return api.TNDAOChallengeStatusResponse{}, fmt.Errorf("Could not get oracle DAO challenge status: %w", err)
+	}
+	var response api.TNDAOChallengeStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.TNDAOChallengeStatusResponse{}, fmt.Errorf("Could not decode oracle DAO challenge status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.TNDAOChallengeStatusResponse{}, fmt.Errorf("Could not get oracle DAO challenge status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Broadcast a challenge response that was signed offline
+func (c *Client) BroadcastChallengeResponse(signedTx string) (api.BroadcastChallengeResponseResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("odao broadcast-challenge-response %s", signedTx))
+	if err != nil {
+		return api.BroadcastChallengeResponseResponse{}, fmt.Errorf("Could not broadcast challenge response: %w", err)
+	}
+	var response api.BroadcastChallengeResponseResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.BroadcastChallengeResponseResponse{}, fmt.Errorf("Could not decode broadcast-challenge-response response: %w", err)
+	}
+	if response.Error != "" {
+		return api.BroadcastChallengeResponseResponse{}, fmt.Errorf("Could not broadcast challenge response: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check whether the node can decide the challenge against a member
+func (c *Client) CanDecideTNDAOChallenge(memberAddress common.Address) (api.CanDecideTNDAOChallengeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("odao can-decide-challenge %s", memberAddress.Hex()))
+	if err != nil {
+		return api.CanDecideTNDAOChallengeResponse{}, fmt.Errorf("Could not get can decide oracle DAO challenge status: %w", err)
+	}
+	var response api.CanDecideTNDAOChallengeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanDecideTNDAOChallengeResponse{}, fmt.Errorf("Could not decode can decide oracle DAO challenge response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanDecideTNDAOChallengeResponse{}, fmt.Errorf("Could not get can decide oracle DAO challenge status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Decide the challenge against a member whose response window has passed
+func (c *Client) DecideTNDAOChallenge(memberAddress common.Address) (api.DecideTNDAOChallengeResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("odao decide-challenge %s", memberAddress.Hex()))
+	if err != nil {
+		return api.DecideTNDAOChallengeResponse{}, fmt.Errorf("Could not decide oracle DAO challenge: %w", err)
+	}
+	var response api.DecideTNDAOChallengeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.DecideTNDAOChallengeResponse{}, fmt.Errorf("Could not decode decide oracle DAO challenge response: %w", err)
+	}
+	if response.Error != "" {
+		return api.DecideTNDAOChallengeResponse{}, fmt.Errorf("Could not decide oracle DAO challenge: %s", response.Error)
+	}
+	return response, nil
+}
@@ -39,3 +39,35 @@ func (c *Client) GetClientStatus() (api.ClientStatusResponse, error) {
 	}
 	return response, nil
 }
+
+// Checks that the Smartnode's Docker containers match the configured images, ports, and volume mounts
+func (c *Client) ValidateContainers() (api.ValidateContainersResponse, error) {
+	responseBytes, err := c.callAPI("service validate-containers")
+	if err != nil {
+		return api.ValidateContainersResponse{}, fmt.Errorf("Could not validate containers: %w", err)
+	}
+	var response api.ValidateContainersResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ValidateContainersResponse{}, fmt.Errorf("Could not decode validate-containers response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ValidateContainersResponse{}, fmt.Errorf("Could not validate containers: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Checks that the node can reach its Execution client, its Beacon node, and the Rocket Pool contracts
+func (c *Client) SelfTest() (api.SelfTestResponse, error) {
+	responseBytes, err := c.callAPI("service self-test")
+	if err != nil {
+		return api.SelfTestResponse{}, fmt.Errorf("Could not run self-test: %w", err)
+	}
+	var response api.SelfTestResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.SelfTestResponse{}, fmt.Errorf("Could not decode self-test response: %w", err)
+	}
+	if response.Error != "" {
+		return api.SelfTestResponse{}, fmt.Errorf("Could not run self-test: %s", response.Error)
+	}
+	return response, nil
+}
@@ -164,9 +164,30 @@ func (c *Client) RebuildWallet() (api.RebuildWalletResponse, error) {
 	return response, nil
 }
 
+// Get a one-time token that must be presented to purge the node wallet
+func (c *Client) GetPurgeToken() (api.GetPurgeTokenResponse, error) {
+	responseBytes, err := c.callAPI("wallet get-purge-token")
+	if err != nil {
+		return api.GetPurgeTokenResponse{}, fmt.Errorf("Could not get purge token: %w", err)
+	}
+	var response api.GetPurgeTokenResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GetPurgeTokenResponse{}, fmt.Errorf("Could not decode get purge token response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GetPurgeTokenResponse{}, fmt.Errorf("Could not get purge token: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Purge the node wallet and validator keys
-func (c *Client) Purge() (api.PurgeResponse, error) {
-	responseBytes, err := c.callAPI("wallet purge")
+func (c *Client) Purge(token string, backupPath string, backupPassword string) (api.PurgeResponse, error) {
+	otherArgs := []string{}
+	if backupPath != "" {
+		otherArgs = append(otherArgs, "--backup-path", backupPath, "--backup-password", backupPassword)
+	}
+	otherArgs = append(otherArgs, token)
+	responseBytes, err := c.callAPI("wallet purge", otherArgs...)
 	if err != nil {
 		return api.PurgeResponse{}, fmt.Errorf("Could not purge wallet and keys: %w", err)
 	}
@@ -180,6 +201,43 @@ func (c *Client) Purge() (api.PurgeResponse, error) {
 	return response, nil
 }
 
+// Find out what a purge would delete, without deleting anything
+func (c *Client) PurgeDryRun() (api.PurgeResponse, error) {
+	responseBytes, err := c.callAPI("wallet purge --dry-run")
+	if err != nil {
+		return api.PurgeResponse{}, fmt.Errorf("Could not run purge dry run: %w", err)
+	}
+	var response api.PurgeResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PurgeResponse{}, fmt.Errorf("Could not decode purge dry run response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PurgeResponse{}, fmt.Errorf("Could not run purge dry run: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Copy the node wallet keystore and the custom validator keystores into a backup directory
+func (c *Client) ExportKeystores(backupDir string, force bool) (api.ExportKeystoresResponse, error) {
+	otherArgs := []string{}
+	if force {
+		otherArgs = append(otherArgs, "--force")
+	}
+	otherArgs = append(otherArgs, backupDir)
+	responseBytes, err := c.callAPI("wallet export-keystores", otherArgs...)
+	if err != nil {
+		return api.ExportKeystoresResponse{}, fmt.Errorf("Could not export keystores: %w", err)
+	}
+	var response api.ExportKeystoresResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ExportKeystoresResponse{}, fmt.Errorf("Could not decode export-keystores response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ExportKeystoresResponse{}, fmt.Errorf("Could not export keystores: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Estimate the gas required to set an ENS reverse record to a name
 func (c *Client) EstimateGasSetEnsName(name string) (api.SetEnsNameResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("wallet estimate-gas-set-ens-name %s", name))
@@ -227,3 +285,83 @@ func (c *Client) ExportWallet() (api.ExportWalletResponse, error) {
 	}
 	return response, nil
 }
+
+// Check whether each custom keystore can be decrypted with the node password and with its custom key password
+func (c *Client) TestCustomKeyPasswords() (api.TestCustomKeyPasswordsResponse, error) {
+	responseBytes, err := c.callAPI("wallet test-custom-key-passwords")
+	if err != nil {
+		return api.TestCustomKeyPasswordsResponse{}, fmt.Errorf("Could not test custom key passwords: %w", err)
+	}
+	var response api.TestCustomKeyPasswordsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.TestCustomKeyPasswordsResponse{}, fmt.Errorf("Could not decode test-custom-key-passwords response: %w", err)
+	}
+	if response.Error != "" {
+		return api.TestCustomKeyPasswordsResponse{}, fmt.Errorf("Could not test custom key passwords: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Encrypt each plain custom keystore with the node password
+func (c *Client) EncryptCustomKeys() (api.EncryptCustomKeysResponse, error) {
+	responseBytes, err := c.callAPI("wallet encrypt-custom-keys")
+	if err != nil {
+		return api.EncryptCustomKeysResponse{}, fmt.Errorf("Could not encrypt custom keys: %w", err)
+	}
+	var response api.EncryptCustomKeysResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.EncryptCustomKeysResponse{}, fmt.Errorf("Could not decode encrypt-custom-keys response: %w", err)
+	}
+	if response.Error != "" {
+		return api.EncryptCustomKeysResponse{}, fmt.Errorf("Could not encrypt custom keys: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Re-encrypt every custom keystore with a new password
+func (c *Client) ReencryptCustomKeys(oldPassword string, newPassword string) (api.ReencryptCustomKeysResponse, error) {
+	responseBytes, err := c.callAPI("wallet reencrypt-custom-keys", oldPassword, newPassword)
+	if err != nil {
+		return api.ReencryptCustomKeysResponse{}, fmt.Errorf("Could not re-encrypt custom keys: %w", err)
+	}
+	var response api.ReencryptCustomKeysResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ReencryptCustomKeysResponse{}, fmt.Errorf("Could not decode reencrypt-custom-keys response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ReencryptCustomKeysResponse{}, fmt.Errorf("Could not re-encrypt custom keys: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Check the permissions of the custom key directory and its keystores, optionally tightening them
+func (c *Client) AuditCustomKeyPermissions(fix bool) (api.AuditCustomKeyPermissionsResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("wallet audit-custom-key-permissions %t", fix))
+	if err != nil {
+		return api.AuditCustomKeyPermissionsResponse{}, fmt.Errorf("Could not audit custom key permissions: %w", err)
+	}
+	var response api.AuditCustomKeyPermissionsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.AuditCustomKeyPermissionsResponse{}, fmt.Errorf("Could not decode audit-custom-key-permissions response: %w", err)
+	}
+	if response.Error != "" {
+		return api.AuditCustomKeyPermissionsResponse{}, fmt.Errorf("Could not audit custom key permissions: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Restart the Validator client and check that it loaded every custom key
+func (c *Client) VerifyCustomKeysLoaded() (api.VerifyCustomKeysLoadedResponse, error) {
+	responseBytes, err := c.callAPI("wallet verify-custom-keys")
+	if err != nil {
+		return api.VerifyCustomKeysLoadedResponse{}, fmt.Errorf("Could not verify custom keys: %w", err)
+	}
+	var response api.VerifyCustomKeysLoadedResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.VerifyCustomKeysLoadedResponse{}, fmt.Errorf("Could not decode verify-custom-keys response: %w", err)
+	}
+	if response.Error != "" {
+		return api.VerifyCustomKeysLoadedResponse{}, fmt.Errorf("Could not verify custom keys: %s", response.Error)
+	}
+	return response, nil
+}
@@ -175,7 +175,7 @@ func getConfig(c *cli.Context) (*config.RocketPoolConfig, error) {
 
 func getPasswordManager(cfg *config.RocketPoolConfig) *passwords.PasswordManager {
 	initPasswordManager.Do(func() {
-		passwordManager = passwords.NewPasswordManager(os.ExpandEnv(cfg.Smartnode.GetPasswordPath()))
+		passwordManager = passwords.NewPasswordManager(os.ExpandEnv(cfg.Smartnode.GetPasswordPath()), cfg.Smartnode.GetPasswordPolicy())
 	})
 	return passwordManager
 }
@@ -254,6 +254,56 @@ func (w *Wallet) Save() error {
 
 }
 
+// Write a copy of the wallet store to the provided path, encrypted with its own password instead of the node password.
+// The wallet only stores the seed derived from the mnemonic, so the backup can be restored by putting it back in place
+// as the wallet file and setting the node password to the backup password.
+func (w *Wallet) SaveBackup(path string, password string) error {
+
+	// Check wallet is initialized
+	if !w.IsInitialized() {
+		return errors.New("Wallet is not initialized")
+	}
+	if len(password) < passwords.MinPasswordLength {
+		return fmt.Errorf("Backup password must be at least %d characters long", passwords.MinPasswordLength)
+	}
+
+	// Encrypt seed with the backup password
+	encryptedSeed, err := w.encryptor.Encrypt(w.seed, password)
+	if err != nil {
+		return fmt.Errorf("Could not encrypt wallet seed: %w", err)
+	}
+	backup := *w.ws
+	backup.Crypto = encryptedSeed
+
+	// Encode wallet store
+	backupBytes, err := json.Marshal(backup)
+	if err != nil {
+		return fmt.Errorf("Could not encode wallet backup: %w", err)
+	}
+
+	// Write it to disk, refusing to overwrite anything that's already there
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, FileMode)
+	if err != nil {
+		return fmt.Errorf("Could not create wallet backup file: %w", err)
+	}
+	_, err = file.Write(backupBytes)
+	if err == nil {
+		err = file.Sync()
+	}
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return fmt.Errorf("Could not write wallet backup to disk: %w", err)
+	}
+
+	// Return
+	return nil
+
+}
+
 // Delete the wallet store from disk
 func (w *Wallet) Delete() error {
 
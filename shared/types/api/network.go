@@ -2,6 +2,7 @@ package api
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -15,6 +16,21 @@ type NodeFeeResponse struct {
 	MaxNodeFee    float64 `json:"maxNodeFee"`
 }
 
+type RewardIntervalCalendarResponse struct {
+	Status                string        `json:"status"`
+	Error                 string        `json:"error"`
+	CurrentIndex          uint64        `json:"currentIndex"`
+	IntervalStart         time.Time     `json:"intervalStart"`
+	IntervalEnd           time.Time     `json:"intervalEnd"`
+	IntervalTime          time.Duration `json:"intervalTime"`
+	IntervalsPassed       uint64        `json:"intervalsPassed"`
+	NextIntervalEnd       time.Time     `json:"nextIntervalEnd"`
+	LatestBlockTime       time.Time     `json:"latestBlockTime"`
+	PreviousIntervalFound bool          `json:"previousIntervalFound"`
+	PreviousIntervalStart time.Time     `json:"previousIntervalStart"`
+	PreviousIntervalEnd   time.Time     `json:"previousIntervalEnd"`
+}
+
 type RplPriceResponse struct {
 	Status                 string   `json:"status"`
 	Error                  string   `json:"error"`
@@ -64,10 +80,41 @@ type CanNetworkGenerateRewardsTreeResponse struct {
 }
 
 type NetworkGenerateRewardsTreeResponse struct {
+	Status      string `json:"status"`
+	Error       string `json:"error"`
+	Index       uint64 `json:"index"`
+	RequestFile string `json:"requestFile"`
+}
+
+type NetworkVerifyRewardsTreeResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
 
+type NetworkDryRunRewardsTreeResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+type NetworkClaimRoundTripStatusResponse struct {
+	Status           string         `json:"status"`
+	Error            string         `json:"error"`
+	Index            uint64         `json:"index"`
+	CurrentIndex     uint64         `json:"currentIndex"`
+	NodeAddress      common.Address `json:"nodeAddress"`
+	IsTrusted        bool           `json:"isTrusted"`
+	HasSubmittedRoot bool           `json:"hasSubmittedRoot"`
+	TreeFilePath     string         `json:"treeFilePath"`
+	TreeFileExists   bool           `json:"treeFileExists"`
+	MerkleRootValid  bool           `json:"merkleRootValid"`
+	NodeExists       bool           `json:"nodeExists"`
+	ProofValid       bool           `json:"proofValid"`
+	IsClaimed        bool           `json:"isClaimed"`
+	CollateralRpl    *big.Int       `json:"collateralRpl"`
+	OracleDaoRpl     *big.Int       `json:"oracleDaoRpl"`
+	SmoothingPoolEth *big.Int       `json:"smoothingPoolEth"`
+}
+
 type NetworkDAOProposalsResponse struct {
 	Status                  string                 `json:"status"`
 	Error                   string                 `json:"error"`
@@ -76,3 +123,49 @@ type NetworkDAOProposalsResponse struct {
 	ActiveSnapshotProposals []SnapshotProposal     `json:"activeSnapshotProposals"`
 	ProposalVotes           []SnapshotProposalVote `json:"proposalVotes"`
 }
+
+type WatchtowerFileInfo struct {
+	Name     string        `json:"name"`
+	Purpose  string        `json:"purpose"`
+	Age      time.Duration `json:"age"`
+	Interval uint64        `json:"interval"`
+	IsStale  bool          `json:"isStale"`
+	Removed  bool          `json:"removed"`
+}
+
+type NetworkWatchtowerFilesResponse struct {
+	Status string               `json:"status"`
+	Error  string               `json:"error"`
+	Files  []WatchtowerFileInfo `json:"files"`
+}
+
+type NetworkRewardsTreeGenerationStatusResponse struct {
+	Status      string    `json:"status"`
+	Error       string    `json:"error"`
+	HasRun      bool      `json:"hasRun"`
+	Running     bool      `json:"running"`
+	Interval    uint64    `json:"interval"`
+	Stage       string    `json:"stage"`
+	Progress    float64   `json:"progress"`
+	StartTime   time.Time `json:"startTime"`
+	LastUpdated time.Time `json:"lastUpdated"`
+
+	FailureKind    string `json:"failureKind"`
+	FailureMessage string `json:"failureMessage"`
+}
+
+type LocalRewardsTreeInfo struct {
+	Index         uint64 `json:"index"`
+	FileName      string `json:"fileName"`
+	FileRoot      string `json:"fileRoot"`
+	CanonicalRoot string `json:"canonicalRoot"`
+	Finalized     bool   `json:"finalized"`
+	Matches       bool   `json:"matches"`
+	Error         string `json:"error"`
+}
+
+type NetworkLocalRewardsTreesResponse struct {
+	Status string                 `json:"status"`
+	Error  string                 `json:"error"`
+	Trees  []LocalRewardsTreeInfo `json:"trees"`
+}
@@ -365,6 +365,13 @@ type NodeGetRewardsInfoResponse struct {
 	ActiveMinipools    int                    `json:"activeMinipools"`
 }
 
+type NodeUpdateRewardsWatchFileResponse struct {
+	Status         string   `json:"status"`
+	Error          string   `json:"error"`
+	AddedIntervals []uint64 `json:"addedIntervals"`
+	WatchFilePath  string   `json:"watchFilePath"`
+}
+
 type CanNodeClaimRewardsResponse struct {
 	Status  string             `json:"status"`
 	Error   string             `json:"error"`
@@ -458,3 +465,18 @@ type SmoothingRewardsResponse struct {
 	Error      string   `json:"error"`
 	EthBalance *big.Int `json:"eth_balance"`
 }
+
+type NodeRewardRankResponse struct {
+	Status         string   `json:"status"`
+	Error          string   `json:"error"`
+	Index          uint64   `json:"index"`
+	TreeFilePath   string   `json:"treeFilePath"`
+	TreeFileExists bool     `json:"treeFileExists"`
+	NodeExists     bool     `json:"nodeExists"`
+	NodeRpl        *big.Int `json:"nodeRpl"`
+	Rank           uint64   `json:"rank"`
+	NodeCount      uint64   `json:"nodeCount"`
+	Percentile     float64  `json:"percentile"`
+	MedianRpl      *big.Int `json:"medianRpl"`
+	MaxRpl         *big.Int `json:"maxRpl"`
+}
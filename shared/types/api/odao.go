@@ -2,6 +2,7 @@ package api
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/dao"
@@ -286,3 +287,39 @@ type GetTNDAOMinipoolSettingsResponse struct {
 	Error       string `json:"error"`
 	ScrubPeriod uint64 `json:"scrubPeriod"`
 }
+
+type TNDAOChallengeStatusResponse struct {
+	Status          string         `json:"status"`
+	Error           string         `json:"error"`
+	Address         common.Address `json:"address"`
+	IsMember        bool           `json:"isMember"`
+	IsChallenged    bool           `json:"isChallenged"`
+	ChallengeBlock  uint64         `json:"challengeBlock"`
+	ChallengeWindow uint64         `json:"challengeWindow"`
+	DeadlineBlock   uint64         `json:"deadlineBlock"`
+	CurrentBlock    uint64         `json:"currentBlock"`
+	SecondsPerBlock uint64         `json:"secondsPerBlock"`
+	GasPrice        *big.Int       `json:"gasPrice"`
+}
+
+type BroadcastChallengeResponseResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
+
+type CanDecideTNDAOChallengeResponse struct {
+	Status             string             `json:"status"`
+	Error              string             `json:"error"`
+	CanDecide          bool               `json:"canDecide"`
+	MemberDoesNotExist bool               `json:"memberDoesNotExist"`
+	NotChallenged      bool               `json:"notChallenged"`
+	WindowActive       bool               `json:"windowActive"`
+	ExpiryTime         time.Time          `json:"expiryTime"`
+	GasInfo            rocketpool.GasInfo `json:"gasInfo"`
+}
+type DecideTNDAOChallengeResponse struct {
+	Status string      `json:"status"`
+	Error  string      `json:"error"`
+	TxHash common.Hash `json:"txHash"`
+}
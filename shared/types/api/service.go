@@ -36,3 +36,30 @@ type ClientStatusResponse struct {
 	EcManagerStatus ClientManagerStatus `json:"ecManagerStatus"`
 	BcManagerStatus ClientManagerStatus `json:"bcManagerStatus"`
 }
+
+type ContainerValidation struct {
+	Name          string   `json:"name"`
+	Exists        bool     `json:"exists"`
+	Running       bool     `json:"running"`
+	ExpectedImage string   `json:"expectedImage"`
+	ActualImage   string   `json:"actualImage"`
+	Issues        []string `json:"issues"`
+}
+
+type ValidateContainersResponse struct {
+	Status     string                `json:"status"`
+	Error      string                `json:"error"`
+	Containers []ContainerValidation `json:"containers"`
+}
+
+type SelfTestCheck struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Details string `json:"details"`
+}
+
+type SelfTestResponse struct {
+	Status string          `json:"status"`
+	Error  string          `json:"error"`
+	Checks []SelfTestCheck `json:"checks"`
+}
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"time"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
@@ -84,7 +86,95 @@ type TestMnemonicResponse struct {
 	RecoveredAddress common.Address `json:"recoveredAddress"`
 }
 
+type ExportKeystoresResponse struct {
+	Status       string   `json:"status"`
+	Error        string   `json:"error"`
+	BackupDir    string   `json:"backupDir"`
+	WrittenFiles []string `json:"writtenFiles"`
+}
+
+type GetPurgeTokenResponse struct {
+	Status    string    `json:"status"`
+	Error     string    `json:"error"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// In a dry run, the deleted keys and the VC restart are what a real purge would do; nothing is actually changed
 type PurgeResponse struct {
-	Status string `json:"status"`
-	Error  string `json:"error"`
+	Status                  string                  `json:"status"`
+	Error                   string                  `json:"error"`
+	DryRun                  bool                    `json:"dryRun"`
+	BackupCreated           bool                    `json:"backupCreated"`
+	BackupPath              string                  `json:"backupPath"`
+	DeletedValidatorPubkeys []string                `json:"deletedValidatorPubkeys"`
+	DeletedCustomKeystores  []string                `json:"deletedCustomKeystores"`
+	ValidatorRestarted      bool                    `json:"validatorRestarted"`
+	SkippedCustomKeystores  []SkippedCustomKeystore `json:"skippedCustomKeystores"`
+	Warnings                []string                `json:"warnings"`
+}
+
+type SkippedCustomKeystore struct {
+	File  string `json:"file"`
+	Error string `json:"error"`
+}
+
+type CustomKeyPasswordCheck struct {
+	File                      string                `json:"file"`
+	Pubkey                    types.ValidatorPubkey `json:"pubkey"`
+	DecryptsWithNodePassword  bool                  `json:"decryptsWithNodePassword"`
+	HasPasswordFileEntry      bool                  `json:"hasPasswordFileEntry"`
+	DecryptsWithPasswordEntry bool                  `json:"decryptsWithPasswordEntry"`
+	PubkeyMismatch            bool                  `json:"pubkeyMismatch"`
+	Error                     string                `json:"error"`
+}
+type TestCustomKeyPasswordsResponse struct {
+	Status string                   `json:"status"`
+	Error  string                   `json:"error"`
+	Keys   []CustomKeyPasswordCheck `json:"keys"`
+}
+
+type CustomKeyLoadStatus struct {
+	File             string                `json:"file"`
+	Pubkey           types.ValidatorPubkey `json:"pubkey"`
+	InValidatorStore bool                  `json:"inValidatorStore"`
+	Loaded           bool                  `json:"loaded"`
+}
+type VerifyCustomKeysLoadedResponse struct {
+	Status          string                `json:"status"`
+	Error           string                `json:"error"`
+	Keys            []CustomKeyLoadStatus `json:"keys"`
+	LoadedKeyCount  int                   `json:"loadedKeyCount"`
+	UnreadableFiles []string              `json:"unreadableFiles"`
+}
+
+type CustomKeyPermissionIssue struct {
+	Path            string `json:"path"`
+	Mode            string `json:"mode"`
+	RecommendedMode string `json:"recommendedMode"`
+	Fixed           bool   `json:"fixed"`
+	Error           string `json:"error"`
+}
+type AuditCustomKeyPermissionsResponse struct {
+	Status          string                     `json:"status"`
+	Error           string                     `json:"error"`
+	CustomKeyPath   string                     `json:"customKeyPath"`
+	DirectoryExists bool                       `json:"directoryExists"`
+	Issues          []CustomKeyPermissionIssue `json:"issues"`
+}
+
+type EncryptCustomKeysResponse struct {
+	Status                string   `json:"status"`
+	Error                 string   `json:"error"`
+	EncryptedFiles        []string `json:"encryptedFiles"`
+	AlreadyEncryptedFiles []string `json:"alreadyEncryptedFiles"`
+	SkippedFiles          []string `json:"skippedFiles"`
+}
+
+type ReencryptCustomKeysResponse struct {
+	Status                 string   `json:"status"`
+	Error                  string   `json:"error"`
+	ReencryptedFiles       []string `json:"reencryptedFiles"`
+	SkippedFiles           []string `json:"skippedFiles"`
+	UpdatedPasswordEntries int      `json:"updatedPasswordEntries"`
 }
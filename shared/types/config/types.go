@@ -10,6 +10,8 @@ type RewardsMode string
 type MevRelayID string
 type MevSelectionMode string
 type NimbusPruningMode string
+type NotificationBackend string
+type EcCallStrategy string
 
 // Enum to describe which container(s) a parameter impacts, so the Smartnode knows which
 // ones to restart upon a settings change
@@ -106,6 +108,21 @@ const (
 	NimbusPruningMode_Prune   NimbusPruningMode = "prune"
 )
 
+// Enum to describe the notification backends
+const (
+	NotificationBackend_None     NotificationBackend = "none"
+	NotificationBackend_Webhook  NotificationBackend = "webhook"
+	NotificationBackend_Discord  NotificationBackend = "discord"
+	NotificationBackend_Telegram NotificationBackend = "telegram"
+	NotificationBackend_Email    NotificationBackend = "email"
+)
+
+// Enum to describe how the rewards tree generator makes its per-node calls to the Execution client
+const (
+	EcCallStrategy_Multicall  EcCallStrategy = "multicall"
+	EcCallStrategy_Individual EcCallStrategy = "individual"
+)
+
 type Config interface {
 	GetConfigTitle() string
 	GetParameters() []*Parameter
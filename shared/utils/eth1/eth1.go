@@ -62,6 +62,71 @@ func CheckForNonceOverride(c *cli.Context, opts *bind.TransactOpts) error {
 
 }
 
+// Identifiers of light clients in web3_clientVersion strings; these can't serve the historical state tree generation needs
+var lightClientIdentifiers = []string{
+	"helios",
+	"light",
+	"les/",
+}
+
+// Checks if the provided error means the EC simply doesn't have the state for a block, which the Archive EC can resolve
+func isMissingStateError(err error) bool {
+	errMessage := err.Error()
+	return strings.Contains(errMessage, "missing trie node") || // Geth
+		strings.Contains(errMessage, "No state available for block") || // Nethermind
+		strings.Contains(errMessage, "Internal error") // Besu
+}
+
+// Checks that the primary EC can serve the historical eth_call requests rewards tree generation relies on.
+// Light clients, and clients that reject historical calls outright, would otherwise fail deep inside the RPL rewards
+// calculation with a confusing RPC error, so this provides a clear one up front that includes the client type.
+func CheckHistoricalCallSupport(ec *services.ExecutionClientManager, primary *rocketpool.RocketPool, cfg *config.RocketPoolConfig, blockNumber *big.Int) error {
+
+	// Get the client type
+	clientVersion, err := ec.ClientVersion(context.Background())
+	if err != nil {
+		clientVersion = fmt.Sprintf("unknown (%s)", err.Error())
+	}
+
+	// Check for a light client
+	lowerVersion := strings.ToLower(clientVersion)
+	for _, identifier := range lightClientIdentifiers {
+		if strings.Contains(lowerVersion, identifier) {
+			return fmt.Errorf("***ERROR*** Your Execution client (%s) appears to be a light client. Rewards tree generation needs full historical eth_call support, which light clients can't provide. Please use a full (or archive) Execution client, or specify an Archive EC URL in the Smartnode settings.", clientVersion)
+		}
+	}
+
+	// Try a historical call
+	opts := &bind.CallOpts{
+		BlockNumber: blockNumber,
+	}
+	_, err = primary.RocketStorage.GetAddress(opts, crypto.Keccak256Hash([]byte("contract.addressrocketTokenRETH")))
+	if err == nil {
+		return nil
+	}
+	if isMissingStateError(err) {
+		// The client supports historical calls but has pruned this block, so the Archive EC can be used instead
+		return nil
+	}
+	return fmt.Errorf("***ERROR*** Your Execution client (%s) rejected a historical eth_call for block %d: %s. Rewards tree generation needs full historical eth_call support; if this is a light client or a provider that only serves recent blocks, please use a full (or archive) Execution client instead.", clientVersion, blockNumber.Uint64(), err.Error())
+
+}
+
+// Checks that the provided client has the account state for the EL block a rewards interval is snapshotted at.
+// A pruned client would otherwise fail partway through the RPL rewards calculation with an unhelpful RPC error.
+func CheckStateAvailable(client *rocketpool.RocketPool, cfg *config.RocketPoolConfig, index uint64, blockNumber *big.Int) error {
+
+	_, err := client.Client.BalanceAt(context.Background(), common.HexToAddress(cfg.Smartnode.GetStorageAddress()), blockNumber)
+	if err == nil {
+		return nil
+	}
+	if isMissingStateError(err) {
+		return fmt.Errorf("***ERROR*** Your Execution client doesn't have the state for EL block %d, which is required to generate the rewards tree for interval %d (%s). This is usually because the client has pruned it. You need an archive node for this interval; please specify an Archive EC URL in the Smartnode settings.", blockNumber.Uint64(), index, err.Error())
+	}
+	return fmt.Errorf("error checking the state of EL block %d for interval %d: %w", blockNumber.Uint64(), index, err)
+
+}
+
 // Determines if the primary EC can be used for historical queries, or if the Archive EC is required
 func GetBestApiClient(primary *rocketpool.RocketPool, cfg *config.RocketPoolConfig, printMessage func(string), blockNumber *big.Int) (*rocketpool.RocketPool, error) {
 
@@ -73,11 +138,8 @@ func GetBestApiClient(primary *rocketpool.RocketPool, cfg *config.RocketPoolConf
 	}
 	address, err := client.RocketStorage.GetAddress(opts, crypto.Keccak256Hash([]byte("contract.addressrocketTokenRETH")))
 	if err != nil {
-		errMessage := err.Error()
-		printMessage(fmt.Sprintf("Error getting state for block %d: %s", blockNumber.Uint64(), errMessage))
-		if strings.Contains(errMessage, "missing trie node") || // Geth
-			strings.Contains(errMessage, "No state available for block") || // Nethermind
-			strings.Contains(errMessage, "Internal error") { // Besu
+		printMessage(fmt.Sprintf("Error getting state for block %d: %s", blockNumber.Uint64(), err.Error()))
+		if isMissingStateError(err) {
 
 			// The state was missing so fall back to the archive node
 			archiveEcUrl := cfg.Smartnode.ArchiveECUrl.Value.(string)
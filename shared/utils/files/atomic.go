@@ -0,0 +1,82 @@
+package files
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// The marker in the names of the temporary files WriteFileAtomic writes before moving them into place
+const tempFileSuffix string = ".tmp-"
+
+// Writes data to a file atomically by writing it to a temporary file in the same directory and renaming it into place.
+// The temporary file is given the requested mode before any data is written, so the file never exists with looser permissions.
+func WriteFileAtomic(path string, data []byte, mode os.FileMode) error {
+
+	dir, name := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	tempFile, err := os.CreateTemp(dir, "."+name+tempFileSuffix+"*")
+	if err != nil {
+		return fmt.Errorf("error creating temporary file: %w", err)
+	}
+	tempPath := tempFile.Name()
+
+	// Clean up the temp file if anything goes wrong
+	success := false
+	defer func() {
+		if !success {
+			tempFile.Close()
+			os.Remove(tempPath)
+		}
+	}()
+
+	// Set the mode explicitly so it isn't affected by the umask
+	err = tempFile.Chmod(mode)
+	if err != nil {
+		return fmt.Errorf("error setting mode of temporary file: %w", err)
+	}
+
+	// Write and flush the data
+	_, err = tempFile.Write(data)
+	if err != nil {
+		return fmt.Errorf("error writing temporary file: %w", err)
+	}
+	err = tempFile.Sync()
+	if err != nil {
+		return fmt.Errorf("error syncing temporary file: %w", err)
+	}
+	err = tempFile.Close()
+	if err != nil {
+		return fmt.Errorf("error closing temporary file: %w", err)
+	}
+
+	// Move it into place
+	err = os.Rename(tempPath, path)
+	if err != nil {
+		return fmt.Errorf("error moving temporary file into place: %w", err)
+	}
+
+	success = true
+	return nil
+
+}
+
+// Remove the temporary files left in a directory by atomic writes that were interrupted before they finished.
+// Returns the paths that were removed.
+func RemovePartialFiles(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, ".*"+tempFileSuffix+"*"))
+	if err != nil {
+		return nil, fmt.Errorf("error finding partial files in %s: %w", dir, err)
+	}
+	removed := []string{}
+	for _, path := range paths {
+		err = os.Remove(path)
+		if err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("error removing partial file %s: %w", path, err)
+		}
+		removed = append(removed, path)
+	}
+	return removed, nil
+}
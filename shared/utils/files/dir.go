@@ -0,0 +1,31 @@
+package files
+
+import (
+	"fmt"
+	"os"
+)
+
+// Checks that a directory exists and that files can be created in it
+func CheckDirWritable(dir string) error {
+
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("error checking directory %s: %w", dir, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+
+	testFile, err := os.CreateTemp(dir, ".write-test-*")
+	if err != nil {
+		return fmt.Errorf("directory %s is not writable: %w", dir, err)
+	}
+	testPath := testFile.Name()
+	testFile.Close()
+	err = os.Remove(testPath)
+	if err != nil {
+		return fmt.Errorf("error removing write test file %s: %w", testPath, err)
+	}
+	return nil
+
+}
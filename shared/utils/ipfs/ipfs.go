@@ -0,0 +1,77 @@
+package ipfs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// The timeout for uploading a file to the IPFS node
+const uploadTimeout time.Duration = 2 * time.Minute
+
+type addResponse struct {
+	Name string `json:"Name"`
+	Hash string `json:"Hash"`
+	Size string `json:"Size"`
+}
+
+// Upload a file to an IPFS node using its HTTP RPC API, pin it, and get its CID
+func AddFile(apiUrl string, name string, data []byte) (string, error) {
+
+	// Build the multipart body
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", filepath.Base(name))
+	if err != nil {
+		return "", fmt.Errorf("error creating IPFS upload form: %w", err)
+	}
+	_, err = part.Write(data)
+	if err != nil {
+		return "", fmt.Errorf("error writing IPFS upload form: %w", err)
+	}
+	err = writer.Close()
+	if err != nil {
+		return "", fmt.Errorf("error finalizing IPFS upload form: %w", err)
+	}
+
+	// Make the request
+	request, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(apiUrl, "/")+"/api/v0/add?pin=true&cid-version=1", body)
+	if err != nil {
+		return "", fmt.Errorf("error creating IPFS upload request: %w", err)
+	}
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	client := http.Client{
+		Timeout: uploadTimeout,
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return "", fmt.Errorf("error uploading to IPFS node: %w", err)
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", fmt.Errorf("error reading IPFS node response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("IPFS node responded with status %s: %s", response.Status, string(responseBody))
+	}
+
+	// Get the CID
+	var added addResponse
+	err = json.Unmarshal(responseBody, &added)
+	if err != nil {
+		return "", fmt.Errorf("error deserializing IPFS node response: %w", err)
+	}
+	if added.Hash == "" {
+		return "", fmt.Errorf("IPFS node response didn't include a CID: %s", string(responseBody))
+	}
+	return added.Hash, nil
+
+}
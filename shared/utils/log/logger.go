@@ -1,16 +1,42 @@
 package log
 
 import (
+	"encoding/json"
+	"fmt"
 	"log"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/fatih/color"
 )
 
+// Output for JSON log lines, which carry their own timestamps
+var jsonOutput = log.New(os.Stderr, "", 0)
+
+// Matches the interval prefix that rewards tree tasks put on their log lines
+var intervalPrefixPattern = regexp.MustCompile(`^\[Interval (\d+)[^\]]*\]\s*`)
+
+// A single log line in JSON mode
+type jsonLogLine struct {
+	Time     string                 `json:"time"`
+	Level    string                 `json:"level"`
+	Task     string                 `json:"task"`
+	Interval *uint64                `json:"interval,omitempty"`
+	Message  string                 `json:"message"`
+	Fields   map[string]interface{} `json:"fields,omitempty"`
+}
+
 // Logger with ANSI color output
 type ColorLogger struct {
 	Color       color.Attribute
 	sprintFunc  func(a ...interface{}) string
 	sprintfFunc func(format string, a ...interface{}) string
+	json        bool
+	task        string
+	level       string
 }
 
 // Create new color logger
@@ -22,22 +48,79 @@ func NewColorLogger(colorAttr color.Attribute) ColorLogger {
 	}
 }
 
+// Get a copy of the logger that writes each line as a JSON object tagged with the task and level, instead of colored text
+func (l ColorLogger) WithJsonOutput(task string, level string) ColorLogger {
+	l.sprintFunc = fmt.Sprint
+	l.sprintfFunc = fmt.Sprintf
+	l.json = true
+	l.task = task
+	l.level = level
+	return l
+}
+
 // Print values
 func (l *ColorLogger) Print(v ...interface{}) {
-	log.Print(l.sprintFunc(v...))
+	l.output(l.sprintFunc(v...), false)
 }
 
 // Print values with a newline
 func (l *ColorLogger) Println(v ...interface{}) {
-	log.Println(l.sprintFunc(v...))
+	l.output(l.sprintFunc(v...), true)
 }
 
 // Print a formatted string
 func (l *ColorLogger) Printf(format string, v ...interface{}) {
-	log.Print(l.sprintfFunc(format, v...))
+	l.output(l.sprintfFunc(format, v...), false)
 }
 
 // Print a formatted string with a newline
 func (l *ColorLogger) Printlnf(format string, v ...interface{}) {
-	log.Println(l.sprintfFunc(format, v...))
+	l.output(l.sprintfFunc(format, v...), true)
+}
+
+// Print a formatted string with a newline; in JSON mode, the provided fields are attached to the line too
+func (l *ColorLogger) PrintlnfWithFields(fields map[string]interface{}, format string, v ...interface{}) {
+	message := l.sprintfFunc(format, v...)
+	if l.json {
+		l.printJson(message, fields)
+		return
+	}
+	log.Println(message)
+}
+
+// Write a message in the logger's output mode
+func (l *ColorLogger) output(message string, newline bool) {
+	if l.json {
+		l.printJson(message, nil)
+	} else if newline {
+		log.Println(message)
+	} else {
+		log.Print(message)
+	}
+}
+
+// Write a message as a single JSON line, pulling the interval out of the message's prefix if it has one
+func (l *ColorLogger) printJson(message string, fields map[string]interface{}) {
+	line := jsonLogLine{
+		Time:   time.Now().UTC().Format(time.RFC3339Nano),
+		Level:  l.level,
+		Task:   l.task,
+		Fields: fields,
+	}
+	message = strings.TrimSpace(message)
+	if match := intervalPrefixPattern.FindStringSubmatch(message); match != nil {
+		interval, err := strconv.ParseUint(match[1], 10, 64)
+		if err == nil {
+			line.Interval = &interval
+			message = message[len(match[0]):]
+		}
+	}
+	line.Message = message
+
+	bytes, err := json.Marshal(line)
+	if err != nil {
+		jsonOutput.Println(message)
+		return
+	}
+	jsonOutput.Println(string(bytes))
 }
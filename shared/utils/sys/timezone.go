@@ -0,0 +1,41 @@
+package sys
+
+import (
+	"fmt"
+	"time"
+)
+
+// The layout used when logging timestamps, which always includes the timezone name
+const TimestampLayout string = "2006-01-02 15:04:05 MST"
+
+// Formats the provided time in UTC with an explicit timezone, so logs can't be confused by local time or DST
+func FormatUTC(t time.Time) string {
+	return t.UTC().Format(TimestampLayout)
+}
+
+// Checks that the system's timezone database is present and the local timezone can be resolved.
+// Returns a description of the problem if something looks misconfigured, or an empty string if everything is fine.
+func CheckTimezoneDatabase() string {
+	// A zone with DST transitions should always be loadable if tzdata is installed
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		return fmt.Sprintf("the timezone database could not be loaded (%s); local times may be interpreted incorrectly", err.Error())
+	}
+
+	// Sanity check that the database actually knows about DST; a broken or truncated database will report no offset change
+	winter := time.Date(2022, time.January, 1, 12, 0, 0, 0, location)
+	summer := time.Date(2022, time.July, 1, 12, 0, 0, 0, location)
+	_, winterOffset := winter.Zone()
+	_, summerOffset := summer.Zone()
+	if winterOffset == summerOffset {
+		return "the timezone database doesn't include daylight saving time rules; local times may be interpreted incorrectly"
+	}
+
+	// Check the local timezone
+	localName, _ := time.Now().Zone()
+	if localName == "" {
+		return "the system's local timezone could not be determined"
+	}
+
+	return ""
+}
@@ -0,0 +1,93 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// The timeout for a single keymanager API request
+const keymanagerRequestTimeout time.Duration = 10 * time.Second
+
+// The interval between keymanager API readiness checks
+const keymanagerPollInterval time.Duration = 5 * time.Second
+
+type keymanagerKeystore struct {
+	ValidatingPubkey types.ValidatorPubkey `json:"validating_pubkey"`
+	DerivationPath   string                `json:"derivation_path"`
+	Readonly         bool                  `json:"readonly"`
+}
+
+type keymanagerListKeystoresResponse struct {
+	Data []keymanagerKeystore `json:"data"`
+}
+
+// Get the pubkeys of every keystore the Validator client has loaded, using the standard keymanager API
+func GetLoadedKeystores(url string, tokenPath string) ([]types.ValidatorPubkey, error) {
+
+	// Read the auth token
+	tokenBytes, err := os.ReadFile(tokenPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading keymanager API token from %s: %w", tokenPath, err)
+	}
+	token := strings.TrimSpace(string(tokenBytes))
+
+	// Make the request
+	request, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(url, "/")+"/eth/v1/keystores", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating keymanager API request: %w", err)
+	}
+	request.Header.Set("Authorization", "Bearer "+token)
+	client := http.Client{
+		Timeout: keymanagerRequestTimeout,
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error querying keymanager API: %w", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading keymanager API response: %w", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("keymanager API responded with status %s: %s", response.Status, string(body))
+	}
+
+	// Deserialize the keystores
+	var keystores keymanagerListKeystoresResponse
+	err = json.Unmarshal(body, &keystores)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing keymanager API response: %w", err)
+	}
+	pubkeys := make([]types.ValidatorPubkey, 0, len(keystores.Data))
+	for _, keystore := range keystores.Data {
+		pubkeys = append(pubkeys, keystore.ValidatingPubkey)
+	}
+	return pubkeys, nil
+
+}
+
+// Wait for the Validator client's keymanager API to respond, then get the pubkeys of every keystore it has loaded
+func WaitForLoadedKeystores(url string, tokenPath string, timeout time.Duration) ([]types.ValidatorPubkey, error) {
+
+	deadline := time.Now().Add(timeout)
+	for {
+		pubkeys, err := GetLoadedKeystores(url, tokenPath)
+		if err == nil {
+			return pubkeys, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("the Validator client's keymanager API wasn't ready after %s: %w", timeout, err)
+		}
+		time.Sleep(keymanagerPollInterval)
+	}
+
+}
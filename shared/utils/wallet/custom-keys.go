@@ -0,0 +1,130 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+// The error returned when a custom keystore's private key isn't for the pubkey the keystore claims to be for
+type PubkeyMismatchError struct {
+	File    string
+	Claimed types.ValidatorPubkey
+	Actual  types.ValidatorPubkey
+}
+
+func (e *PubkeyMismatchError) Error() string {
+	return fmt.Sprintf("private keystore file %s claims to be for validator %s but it's for validator %s", e.File, e.Claimed.Hex(), e.Actual.Hex())
+}
+
+// Decrypt a custom keystore with the provided password and make sure the private key matches the keystore's pubkey
+func DecryptCustomKeystore(keystore api.ValidatorKeystore, name string, password string) (*eth2types.BLSPrivateKey, error) {
+
+	// Get the encryption function it uses
+	functionString, err := getCustomKeystoreKdfFunction(keystore, name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decrypt the private key
+	encryptor := eth2ks.New(eth2ks.WithCipher(functionString))
+	decryptedKey, err := encryptor.Decrypt(keystore.Crypto, password)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting keystore for validator %s: %w", keystore.Pubkey.Hex(), err)
+	}
+	privateKey, err := eth2types.BLSPrivateKeyFromBytes(decryptedKey)
+	if err != nil {
+		return nil, fmt.Errorf("error recreating private key for validator %s: %w", keystore.Pubkey.Hex(), err)
+	}
+
+	// Verify the private key matches the public key
+	reconstructedPubkey := types.BytesToValidatorPubkey(privateKey.PublicKey().Marshal())
+	if reconstructedPubkey != keystore.Pubkey {
+		return nil, &PubkeyMismatchError{
+			File:    name,
+			Claimed: keystore.Pubkey,
+			Actual:  reconstructedPubkey,
+		}
+	}
+
+	return privateKey, nil
+
+}
+
+// Check that a custom keystore decrypts with the provided password to the private key for its pubkey.
+// Returns a *PubkeyMismatchError if it decrypts to the key for a different validator.
+func VerifyCustomKeystore(keystore api.ValidatorKeystore, name string, password string) error {
+	_, err := DecryptCustomKeystore(keystore, name, password)
+	return err
+}
+
+// Re-encrypt a custom keystore's private key with a new password. The keystore is decrypted with the old password first,
+// so this fails if the old password is wrong or the key isn't for the keystore's pubkey. The KDF and every other field of
+// the keystore are kept as they were.
+func ReencryptCustomKeystore(keystoreBytes []byte, name string, oldPassword string, newPassword string) ([]byte, error) {
+
+	// Decrypt it with the old password
+	var keystore api.ValidatorKeystore
+	err := json.Unmarshal(keystoreBytes, &keystore)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing custom keystore %s: %w", name, err)
+	}
+	privateKey, err := DecryptCustomKeystore(keystore, name, oldPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	// Encrypt it again with the new password
+	functionString, err := getCustomKeystoreKdfFunction(keystore, name)
+	if err != nil {
+		return nil, err
+	}
+	encryptor := eth2ks.New(eth2ks.WithCipher(functionString))
+	crypto, err := encryptor.Encrypt(privateKey.Marshal(), newPassword)
+	if err != nil {
+		return nil, fmt.Errorf("error encrypting keystore for validator %s: %w", keystore.Pubkey.Hex(), err)
+	}
+
+	// Swap the new crypto section into the original keystore, so fields the Smartnode doesn't know about are preserved
+	var fields map[string]interface{}
+	err = json.Unmarshal(keystoreBytes, &fields)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing custom keystore %s: %w", name, err)
+	}
+	fields["crypto"] = crypto
+	newKeystoreBytes, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing custom keystore %s: %w", name, err)
+	}
+
+	// Make sure the new keystore decrypts to the same key
+	var newKeystore api.ValidatorKeystore
+	err = json.Unmarshal(newKeystoreBytes, &newKeystore)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing re-encrypted custom keystore %s: %w", name, err)
+	}
+	err = VerifyCustomKeystore(newKeystore, name, newPassword)
+	if err != nil {
+		return nil, fmt.Errorf("re-encrypted custom keystore %s didn't decrypt with the new password: %w", name, err)
+	}
+	return newKeystoreBytes, nil
+
+}
+
+// Get the name of the key derivation function a custom keystore uses
+func getCustomKeystoreKdfFunction(keystore api.ValidatorKeystore, name string) (string, error) {
+	kdf, exists := keystore.Crypto["kdf"]
+	if !exists {
+		return "", fmt.Errorf("error processing custom keystore %s: \"crypto\" didn't contain a subkey named \"kdf\"", name)
+	}
+	kdfMap := kdf.(map[string]interface{})
+	function, exists := kdfMap["function"]
+	if !exists {
+		return "", fmt.Errorf("error processing custom keystore %s: \"crypto.kdf\" didn't contain a subkey named \"function\"", name)
+	}
+	return function.(string), nil
+}
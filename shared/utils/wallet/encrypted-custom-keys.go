@@ -0,0 +1,85 @@
+package wallet
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
+)
+
+// The current version of the node-level encryption layer for custom keystores
+const encryptedCustomKeystoreVersion uint = 1
+
+// A custom keystore wrapped in an additional layer of encryption, keyed by the node password
+type encryptedCustomKeystore struct {
+	Version uint                   `json:"rocketpoolEncryptedKeystore"`
+	Crypto  map[string]interface{} `json:"crypto"`
+}
+
+// Check whether a custom keystore file has been wrapped with the node-level encryption layer
+func IsEncryptedCustomKeystore(fileBytes []byte) bool {
+	var envelope encryptedCustomKeystore
+	err := json.Unmarshal(fileBytes, &envelope)
+	return err == nil && envelope.Version > 0 && envelope.Crypto != nil
+}
+
+// Wrap a custom keystore in the node-level encryption layer, keyed by the node password
+func EncryptCustomKeystore(keystoreBytes []byte, nodePassword string) ([]byte, error) {
+	if nodePassword == "" {
+		return nil, fmt.Errorf("the node password must be set to encrypt custom keystores")
+	}
+	encryptor := eth2ks.New()
+	crypto, err := encryptor.Encrypt(keystoreBytes, nodePassword)
+	if err != nil {
+		return nil, fmt.Errorf("error encrypting custom keystore: %w", err)
+	}
+	envelope := encryptedCustomKeystore{
+		Version: encryptedCustomKeystoreVersion,
+		Crypto:  crypto,
+	}
+	envelopeBytes, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing encrypted custom keystore: %w", err)
+	}
+	return envelopeBytes, nil
+}
+
+// Get the plain EIP-2335 keystore out of a custom keystore file, removing the node-level encryption layer if it has one.
+// Unwrapped keystores are returned unchanged, and don't need the node password.
+func UnwrapCustomKeystore(fileBytes []byte, name string, nodePassword string) ([]byte, error) {
+	if !IsEncryptedCustomKeystore(fileBytes) {
+		return fileBytes, nil
+	}
+	var envelope encryptedCustomKeystore
+	err := json.Unmarshal(fileBytes, &envelope)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing encrypted custom keystore %s: %w", name, err)
+	}
+	if envelope.Version != encryptedCustomKeystoreVersion {
+		return nil, fmt.Errorf("encrypted custom keystore %s has unsupported version %d", name, envelope.Version)
+	}
+	if nodePassword == "" {
+		return nil, fmt.Errorf("custom keystore %s is encrypted with the node password, but the node password isn't set", name)
+	}
+	encryptor := eth2ks.New()
+	keystoreBytes, err := encryptor.Decrypt(envelope.Crypto, nodePassword)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting custom keystore %s with the node password: %w", name, err)
+	}
+	return keystoreBytes, nil
+}
+
+// Deserialize a custom keystore file, removing the node-level encryption layer if it has one
+func ParseCustomKeystore(fileBytes []byte, name string, nodePassword string) (api.ValidatorKeystore, error) {
+	keystore := api.ValidatorKeystore{}
+	keystoreBytes, err := UnwrapCustomKeystore(fileBytes, name, nodePassword)
+	if err != nil {
+		return keystore, err
+	}
+	err = json.Unmarshal(keystoreBytes, &keystore)
+	if err != nil {
+		return keystore, fmt.Errorf("error deserializing custom keystore %s: %w", name, err)
+	}
+	return keystore, nil
+}
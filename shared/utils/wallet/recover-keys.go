@@ -2,7 +2,6 @@ package wallet
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -15,11 +14,9 @@ import (
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
-	"github.com/rocket-pool/smartnode/shared/types/api"
 	hexutils "github.com/rocket-pool/smartnode/shared/utils/hex"
 	"github.com/urfave/cli"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
-	eth2ks "github.com/wealdtech/go-eth2-wallet-encryptor-keystorev4"
 	"gopkg.in/yaml.v2"
 )
 
@@ -75,6 +72,19 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 
 		if len(files) > 0 {
 
+			// Encrypted custom keystores need the node password
+			nodePassword := ""
+			pm, err := services.GetPasswordManager(c)
+			if err != nil {
+				return nil, err
+			}
+			if pm.IsPasswordSet() {
+				nodePassword, err = pm.GetPassword()
+				if err != nil {
+					return nil, fmt.Errorf("error loading node password: %w", err)
+				}
+			}
+
 			// Deserialize the password file
 			passwordFile := cfg.Smartnode.GetCustomKeyPasswordFilePath()
 			fileBytes, err := ioutil.ReadFile(passwordFile)
@@ -95,11 +105,10 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 					return nil, fmt.Errorf("error reading custom keystore %s: %w", file.Name(), err)
 				}
 
-				// Deserialize it
-				keystore := api.ValidatorKeystore{}
-				err = json.Unmarshal(bytes, &keystore)
+				// Deserialize it, removing the node-level encryption if it has any
+				keystore, err := ParseCustomKeystore(bytes, file.Name(), nodePassword)
 				if err != nil {
-					return nil, fmt.Errorf("error deserializing custom keystore %s: %w", file.Name(), err)
+					return nil, err
 				}
 
 				// Check if it's one of the pubkeys for the minipool
@@ -116,45 +125,22 @@ func RecoverMinipoolKeys(c *cli.Context, rp *rocketpool.RocketPool, address comm
 					return nil, fmt.Errorf("custom keystore for pubkey %s needs a password, but none was provided", keystore.Pubkey.Hex())
 				}
 
-				// Get the encryption function it uses
-				kdf, exists := keystore.Crypto["kdf"]
-				if !exists {
-					return nil, fmt.Errorf("error processing custom keystore %s: \"crypto\" didn't contain a subkey named \"kdf\"", file.Name())
-				}
-				kdfMap := kdf.(map[string]interface{})
-				function, exists := kdfMap["function"]
-				if !exists {
-					return nil, fmt.Errorf("error processing custom keystore %s: \"crypto.kdf\" didn't contain a subkey named \"function\"", file.Name())
-				}
-				functionString := function.(string)
-
-				// Decrypt the private key
-				encryptor := eth2ks.New(eth2ks.WithCipher(functionString))
-				decryptedKey, err := encryptor.Decrypt(keystore.Crypto, password)
+				// Decrypt the keystore
+				privateKey, err := DecryptCustomKeystore(keystore, file.Name(), password)
 				if err != nil {
-					return nil, fmt.Errorf("error decrypting keystore for validator %s: %w", keystore.Pubkey.Hex(), err)
-				}
-				privateKey, err := eth2types.BLSPrivateKeyFromBytes(decryptedKey)
-				if err != nil {
-					return nil, fmt.Errorf("error recreating private key for validator %s: %w", keystore.Pubkey.Hex(), err)
-				}
-
-				// Verify the private key matches the public key
-				reconstructedPubkey := types.BytesToValidatorPubkey(privateKey.PublicKey().Marshal())
-				if reconstructedPubkey != keystore.Pubkey {
-					return nil, fmt.Errorf("private keystore file %s claims to be for validator %s but it's for validator %s", file.Name(), keystore.Pubkey.Hex(), reconstructedPubkey.Hex())
+					return nil, err
 				}
 
 				// Store the key
 				if !testOnly {
 					err = w.StoreValidatorKey(privateKey, keystore.Path)
 					if err != nil {
-						return nil, fmt.Errorf("error storing private keystore for %s: %w", reconstructedPubkey.Hex(), err)
+						return nil, fmt.Errorf("error storing private keystore for %s: %w", keystore.Pubkey.Hex(), err)
 					}
 				}
 
 				// Remove the pubkey from pending minipools to handle
-				delete(pubkeyMap, reconstructedPubkey)
+				delete(pubkeyMap, keystore.Pubkey)
 			}
 		}
 	}